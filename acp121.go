@@ -0,0 +1,49 @@
+package dtg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ACP121Regexp matches a Date Time Group in the strict form written in
+// message headers according to ACP 121(I) Communication Instructions
+// General: the day, hour and minute with the zone letter as one group, then
+// the month abbreviation and the last two digits of the year as separate
+// groups, e.g. 151230Z DEC 19. All groups are mandatory, in upper case and
+// separated by exactly one space.
+var ACP121Regexp *regexp.Regexp = regexp.MustCompile(`^([0-9]{2})([0-9]{2})([0-9]{2})([A-Z]) (JAN|FEB|MAR|APR|MAY|JUN|JUL|AUG|SEP|OCT|NOV|DEC) ([0-9]{2})$`)
+
+var ErrNotACP121 error = errors.New("not a strict ACP 121 DTG (ddHHMMZ MMM YY)")
+
+// FormatACP121 returns the Date Time Group of dtg in the strict ACP 121
+// header form, e.g. 151230Z DEC 19, see ACP121Regexp. The zone letter is
+// chosen like DTG.String() does.
+func FormatACP121(dtg DTG) string {
+	return (*Formatter)(nil).FormatACP121(dtg)
+}
+
+// FormatACP121 is like the package level FormatACP121, but uses the
+// Formatter's zone table. Note that a designator with an asterisk is not
+// ACP 121 and will be rejected by ParseACP121.
+func (f *Formatter) FormatACP121(dtg DTG) string {
+	var buf [dtgBufferSize + 2]byte
+	return string(f.append(buf[:0], dtg, groupACP121))
+}
+
+// ParseACP121 parses a Date Time Group only if it is in the strict ACP 121
+// header form produced by FormatACP121, otherwise ErrNotACP121 is returned.
+// Use it where input must be to-the-letter compliant, e.g. when validating
+// headers before transmission. Parse is the lenient alternative.
+func ParseACP121(s string) (DTG, error) {
+	return (*Parser)(nil).ParseACP121(s)
+}
+
+// ParseACP121 is like the package level ParseACP121, but uses the Parser's
+// zone table.
+func (p *Parser) ParseACP121(s string) (DTG, error) {
+	if !ACP121Regexp.MatchString(s) {
+		return DTG{}, ErrNotACP121
+	}
+	return p.Parse(strings.ReplaceAll(s, " ", ""))
+}
@@ -0,0 +1,42 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestFormatACP121(t *testing.T) {
+	for _, s := range []string{"151230ZDEC19", "010000BJAN20", "311159YMAY21"} {
+		expected := s[:7] + " " + s[7:10] + " " + s[10:]
+		got := FormatACP121(mustParse(t, s))
+		if got != expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected, got)
+		}
+		d, err := ParseACP121(got)
+		if err != nil {
+			t.Errorf("Expected \"%s\" to parse, but got %v", got, err)
+		} else if d.String() != s {
+			t.Errorf("Expected \"%s\", but got \"%s\"", s, d)
+		}
+	}
+}
+
+func TestParseACP121(t *testing.T) {
+	for _, s := range []string{
+		"151230ZDEC19",
+		"151230 DEC 19",
+		"151230Z DEC",
+		"151230Z  DEC 19",
+		"151230Z dec 19",
+		" 151230Z DEC 19",
+		"151230Z DEC 2019",
+		"151230D* DEC 19",
+		"151230Z OKT 19",
+	} {
+		if _, err := ParseACP121(s); err != ErrNotACP121 {
+			t.Errorf("Expected ErrNotACP121 for \"%s\", but got %v", s, err)
+		}
+	}
+	if _, err := ParseACP121("321230Z DEC 19"); err == nil || err == ErrNotACP121 {
+		t.Errorf("Expected an invalid date error, but got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package dtg
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"path"
+)
+
+// FileFunc is called by FindAllFS and FindAllFile for every file with its
+// (decompressed) text and the DTGs found in it. Members of a zip archive are
+// named archive.zip/member. Returning an error stops the search.
+type FileFunc func(name, text string, matches []Match) error
+
+// FindAllFS calls fn with the DTGs of every regular file under root in fsys,
+// in lexical order. Gzip compressed files are decompressed and zip archives
+// are searched member by member, as message archives are usually
+// compressed.
+func FindAllFS(fsys fs.FS, root string, fn FileFunc) error {
+	return (*Parser)(nil).FindAllFS(fsys, root, fn)
+}
+
+// FindAllFS is like the package level FindAllFS, but uses the Parser's zone
+// table.
+func (p *Parser) FindAllFS(fsys fs.FS, root string, fn FileFunc) error {
+	return fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return p.FindAllFile(name, f, fn)
+	})
+}
+
+// FindAllFile reads the file name from r and calls fn with its DTGs. Like
+// FindAllFS it decompresses gzip and iterates zip archives, recognized by
+// their content rather than the name, so a compressed standard input works
+// too. The text is decoded according to the Parser's Charset.
+func FindAllFile(name string, r io.Reader, fn FileFunc) error {
+	return (*Parser)(nil).FindAllFile(name, r, fn)
+}
+
+// FindAllFile is like the package level FindAllFile, but uses the Parser's
+// zone table.
+func (p *Parser) FindAllFile(name string, r io.Reader, fn FileFunc) error {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		return p.FindAllFile(name, zr, fn)
+	case bytes.Equal(magic, []byte("PK\x03\x04")):
+		content, err := io.ReadAll(br)
+		if err != nil {
+			return err
+		}
+		zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return err
+		}
+		return p.FindAllFS(zr, ".", func(member, text string, matches []Match) error {
+			return fn(path.Join(name, member), text, matches)
+		})
+	}
+	content, err := io.ReadAll(br)
+	if err != nil {
+		return err
+	}
+	text, err := DecodeText(content, p.charset())
+	if err != nil {
+		return err
+	}
+	return fn(name, text, p.FindAll(text))
+}
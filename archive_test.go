@@ -0,0 +1,87 @@
+package dtg
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func zipped(t *testing.T, members map[string][]byte) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range members {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestFindAllFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"plain.txt":           {Data: []byte("R 151230ZDEC19")},
+		"logs/day2.txt.gz":    {Data: gzipped(t, "R 161200ZDEC19 AND 161300ZDEC19")},
+		"logs/archive.zip":    {Data: zipped(t, map[string][]byte{"a.txt": []byte("171200ZDEC19"), "b/c.gz": gzipped(t, "181200ZDEC19")})},
+		"logs/nothing-here.t": {Data: []byte("no dtgs")},
+	}
+	var got []string
+	err := FindAllFS(fsys, ".", func(name, text string, matches []Match) error {
+		var dtgs []string
+		for _, m := range matches {
+			dtgs = append(dtgs, m.DTG.String())
+		}
+		got = append(got, name+": "+strings.Join(dtgs, " "))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"logs/archive.zip/a.txt: 171200ZDEC19",
+		"logs/archive.zip/b/c.gz: 181200ZDEC19",
+		"logs/day2.txt.gz: 161200ZDEC19 161300ZDEC19",
+		"logs/nothing-here.t: ",
+		"plain.txt: 151230ZDEC19",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestFindAllFile(t *testing.T) {
+	var text string
+	err := FindAllFile("-", bytes.NewReader(gzipped(t, "R 151230ZDEC19")), func(name, s string, matches []Match) error {
+		text = s
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "R 151230ZDEC19" {
+		t.Errorf("Expected decompressed text \"R 151230ZDEC19\", but got \"%s\"", text)
+	}
+}
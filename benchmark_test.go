@@ -0,0 +1,245 @@
+package dtg
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The benchmarks below back the performance budget in PERFORMANCE.md. Keep
+// their names stable so results can be compared over time with benchstat:
+//
+//	go test -run '^$' -bench . -benchmem -count 10 > new.txt
+//	benchstat old.txt new.txt
+
+var (
+	benchmarkSink   interface{}
+	benchmarkDTG    DTG
+	benchmarkInt    int
+	benchmarkString string
+)
+
+func BenchmarkParse(b *testing.B) {
+	for _, input := range []struct{ name, dtg string }{
+		{"Short", "151230"},
+		{"Zone", "151230Z"},
+		{"Month", "151230ZDEC"},
+		{"Full", "151230ZDEC19"},
+		{"Offset", "151230BDEC19"},
+		{"Local", "151230JDEC19"},
+	} {
+		b.Run(input.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				d, err := Parse(input.dtg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				benchmarkSink = d
+			}
+		})
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	for _, input := range []struct {
+		name string
+		t    time.Time
+	}{
+		{"UTC", time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)},
+		{"Offset", time.Date(2019, 12, 15, 12, 30, 0, 0, time.FixedZone("", 2*3600))},
+		{"Fallback", time.Date(2019, 12, 15, 12, 30, 0, 0, time.FixedZone("", 13*3600))},
+	} {
+		d := DTG{Time: input.t}
+		b.Run(input.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				benchmarkString = d.String()
+			}
+		})
+	}
+}
+
+// benchmarkCorpus returns about size bytes of message traffic with a DTG
+// every few lines.
+func benchmarkCorpus(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		fmt.Fprintf(&b, "R %02d%02d%02dZ DEC 19\nFM 2BN\nTO HQ\nBT\nSITREP %d, NOTHING TO REPORT.\nNEXT REPORT NLT %02d1200Z\nBT\n",
+			i%28+1, i%24, i%60, i, (i+1)%28+1)
+	}
+	return b.String()
+}
+
+func BenchmarkFindAll(b *testing.B) {
+	corpus := benchmarkCorpus(1 << 20)
+	b.Run("1MB", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(corpus)))
+		for i := 0; i < b.N; i++ {
+			benchmarkSink = FindAll(corpus)
+		}
+	})
+}
+
+func BenchmarkZoneTable(b *testing.B) {
+	zones := DefaultZoneTable()
+	b.Run("Offset", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchmarkInt, _ = zones.Offset("M")
+		}
+	})
+	b.Run("Designator", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchmarkString, _ = zones.Designator(12 * 3600)
+		}
+	})
+	b.Run("GetNumericTimeZone", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			loc, err := GetNumericTimeZone("M")
+			if err != nil {
+				b.Fatal(err)
+			}
+			benchmarkSink = loc
+		}
+	})
+}
+
+// BenchmarkValidate validates a canonical DTG (the fast path), a short one
+// (the slow path) and 1 MiB of canonical DTGs, one per line.
+func BenchmarkValidate(b *testing.B) {
+	for _, input := range []struct{ name, dtg string }{
+		{"Fast", "151230ZDEC19"},
+		{"Slow", "151230"},
+	} {
+		b.Run(input.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := Validate(input.dtg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+	var lines []string
+	size := 0
+	for i := 0; size < 1<<20; i++ {
+		line := fmt.Sprintf("%02d%02d%02d%cDEC19", i%28+1, i%24, i%60, "ZABR"[i%4])
+		lines = append(lines, line)
+		size += len(line) + 1
+	}
+	b.Run("1MB", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(size))
+		for i := 0; i < b.N; i++ {
+			for _, line := range lines {
+				if err := Validate(line); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkParseBulk parses 1000 DTGs of a few zones with ParseBulk and
+// ParseBulkParallel, compared to calling Parse for each.
+func BenchmarkParseBulk(b *testing.B) {
+	letters := "ZABR"
+	batch := make([]string, 1000)
+	for i := range batch {
+		batch[i] = fmt.Sprintf("%02d%02d%02d%cDEC19", i%28+1, i%24, i%60, letters[i%len(letters)])
+	}
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, s := range batch {
+				benchmarkDTG, _ = Parse(s)
+			}
+		}
+	})
+	b.Run("Bulk", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchmarkSink, _ = ParseBulk(batch)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchmarkSink, _ = ParseBulkParallel(batch, 0)
+		}
+	})
+}
+
+// BenchmarkCachingParser parses a flood of 100 distinct DTG strings (in a
+// cache of 4096) and the same flood thrashing a cache of 64, reporting the
+// measured hit rate.
+func BenchmarkCachingParser(b *testing.B) {
+	flood := make([]string, 100)
+	for i := range flood {
+		flood[i] = fmt.Sprintf("%02d%02d%02dZ", i%28+1, i%24, i%60)
+	}
+	for _, input := range []struct {
+		name string
+		size int
+	}{
+		{"Flood", 0},
+		{"Thrash", 64},
+	} {
+		b.Run(input.name, func(b *testing.B) {
+			c := NewCachingParser(nil, input.size)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				d, err := c.Parse(flood[i%len(flood)])
+				if err != nil {
+					b.Fatal(err)
+				}
+				benchmarkDTG = d
+			}
+			b.ReportMetric(100*c.Stats().HitRate(), "%hits")
+		})
+	}
+}
+
+// TestAllocationBudget enforces the allocation budget of PERFORMANCE.md.
+// Lower a budget (and update the document) when an optimization lands, never
+// raise it without discussion.
+func TestAllocationBudget(t *testing.T) {
+	d := DTG{Time: time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)}
+	zones := DefaultZoneTable()
+	cache := NewCachingParser(nil, 0)
+	for _, budget := range []struct {
+		name   string
+		allocs float64
+		f      func()
+	}{
+		{"Parse/Full", 8, func() { benchmarkSink, _ = Parse("151230ZDEC19") }},
+		{"Parse/Short", 14, func() { benchmarkSink, _ = Parse("151230") }},
+		{"Validate/Fast", 0, func() { benchmarkSink = Validate("151230ZDEC19") }},
+		{"ValidateAndNormalize", 0, func() { benchmarkString, _ = ValidateAndNormalize("151230ZDEC19") }},
+		{"CachingParser/Hit", 0, func() { benchmarkDTG, _ = cache.Parse("151230ZDEC19") }},
+		{"Format", 1, func() { benchmarkString = d.String() }},
+		{"WriteTo", 0, func() { d.WriteTo(io.Discard) }},
+		{"ZoneTable/Offset", 0, func() { benchmarkInt, _ = zones.Offset("M") }},
+		{"ZoneTable/Designator", 0, func() { benchmarkString, _ = zones.Designator(12 * 3600) }},
+	} {
+		if allocs := testing.AllocsPerRun(100, budget.f); allocs > budget.allocs {
+			t.Errorf("Expected %s to allocate at most %v times, but got %v", budget.name, budget.allocs, allocs)
+		}
+	}
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	d := DTG{Time: time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.WriteTo(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
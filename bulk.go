@@ -0,0 +1,99 @@
+package dtg
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ParseBulk parses a batch of DTGs, e.g. during archive ingestion, faster
+// than calling Parse for each: the reference time is taken once for the
+// whole batch and the location of every designator (but J) is looked up
+// once. The DTGs and errors are in the order of dtgStrings, the error of a
+// DTG that parsed being nil.
+func ParseBulk(dtgStrings []string, opts ...Option) ([]DTG, []error) {
+	return (*Parser)(nil).ParseBulk(dtgStrings, opts...)
+}
+
+// ParseBulk is like the package level ParseBulk, but uses the Parser's zone
+// table.
+func (p *Parser) ParseBulk(dtgStrings []string, opts ...Option) ([]DTG, []error) {
+	dtgs := make([]DTG, len(dtgStrings))
+	errs := make([]error, len(dtgStrings))
+	p.bulk(opts).parse(dtgStrings, dtgs, errs)
+	return dtgs, errs
+}
+
+// ParseBulkParallel is like ParseBulk, but splits the batch over workers
+// goroutines, runtime.GOMAXPROCS(0) when workers is 0 or less.
+func ParseBulkParallel(dtgStrings []string, workers int, opts ...Option) ([]DTG, []error) {
+	return (*Parser)(nil).ParseBulkParallel(dtgStrings, workers, opts...)
+}
+
+// ParseBulkParallel is like the package level ParseBulkParallel, but uses
+// the Parser's zone table.
+func (p *Parser) ParseBulkParallel(dtgStrings []string, workers int, opts ...Option) ([]DTG, []error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	dtgs := make([]DTG, len(dtgStrings))
+	errs := make([]error, len(dtgStrings))
+	batch := p.bulk(opts)
+	size := (len(dtgStrings) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(dtgStrings); start += size {
+		end := start + size
+		if end > len(dtgStrings) {
+			end = len(dtgStrings)
+		}
+		// Every worker has its own copy, the memoized locations are not
+		// shared.
+		worker := *batch
+		worker.locations = make(map[string]*time.Location)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			worker.parse(dtgStrings[start:end], dtgs[start:end], errs[start:end])
+		}(start, end)
+	}
+	wg.Wait()
+	return dtgs, errs
+}
+
+// bulk returns a copy of p with opts applied, the reference time fixed and
+// locations memoized.
+func (p *Parser) bulk(opts []Option) *Parser {
+	var c Parser
+	if p != nil {
+		c = *p
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	reference := c.reference()
+	c.Now = func() time.Time { return reference }
+	c.locations = make(map[string]*time.Location)
+	return &c
+}
+
+func (p *Parser) parse(dtgStrings []string, dtgs []DTG, errs []error) {
+	for i, s := range dtgStrings {
+		dtgs[i], errs[i] = p.Parse(s)
+	}
+}
+
+// location is the location of the designator as ZoneTable.locationAt,
+// memoized for designators other than J in Parsers returned by bulk.
+func (p *Parser) location(now time.Time, designator string, dayHourMinuteMonthYear ...string) (*time.Location, error) {
+	if p == nil || p.locations == nil || designator == "" || designator == "J" {
+		return p.zones().locationAt(now, designator, dayHourMinuteMonthYear...)
+	}
+	if loc, ok := p.locations[designator]; ok {
+		return loc, nil
+	}
+	loc, err := p.zones().locationAt(now, designator, dayHourMinuteMonthYear...)
+	if err == nil {
+		p.locations[designator] = loc
+	}
+	return loc, err
+}
@@ -0,0 +1,44 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBulk(t *testing.T) {
+	reference := time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC)
+	inputs := []string{`151230ZDEC19`, `151230Z`, `151260Z`, `161200B`, `151230BDEC19`, `011200X`, `151230ADEC19`}
+	expected := []string{`151230ZDEC19`, `151230ZDEC19`, ``, `161200BDEC19`, `151230BDEC19`, `011200XDEC19`, `151230ADEC19`}
+	check := func(name string, dtgs []DTG, errs []error) {
+		t.Helper()
+		if len(dtgs) != len(inputs) || len(errs) != len(inputs) {
+			t.Fatalf("%s: expected %d results, but got %d and %d", name, len(inputs), len(dtgs), len(errs))
+		}
+		for i, want := range expected {
+			if want == `` {
+				if errs[i] == nil {
+					t.Errorf("%s: expected error for \"%s\", but got nil", name, inputs[i])
+				}
+				continue
+			}
+			if errs[i] != nil || dtgs[i].String() != want {
+				t.Errorf("%s: expected \"%s\", but got \"%s\" (%v)", name, want, dtgs[i], errs[i])
+			}
+		}
+	}
+	dtgs, errs := ParseBulk(inputs, WithReferenceTime(reference))
+	check("ParseBulk", dtgs, errs)
+	for _, workers := range []int{0, 1, 3, 100} {
+		dtgs, errs := ParseBulkParallel(inputs, workers, WithReferenceTime(reference))
+		check("ParseBulkParallel", dtgs, errs)
+	}
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	dtgs, errs = (&Parser{Zones: zones}).ParseBulk([]string{`151200D*DEC19`, `151300D*DEC19`})
+	if errs[1] != nil || dtgs[1].Time.UTC().Hour() != 8 || dtgs[1].Time.UTC().Minute() != 30 {
+		t.Errorf("Expected 0830Z, but got %s (%v)", dtgs[1].Time.UTC(), errs[1])
+	}
+	if dtgs, errs := ParseBulk(nil); len(dtgs) != 0 || len(errs) != 0 {
+		t.Errorf("Expected no results, but got %d", len(dtgs))
+	}
+}
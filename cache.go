@@ -0,0 +1,130 @@
+package dtg
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of DTG strings a CachingParser keeps when
+// created with size 0.
+const DefaultCacheSize int = 4096
+
+// CachingParser is an Interface that caches the results of Parse (errors
+// included) of a Parser, for gateway workloads where message floods contain
+// the same DTG string thousands of times. Entries are keyed by the DTG
+// string and hold while the reference time stays within the day (in the zone
+// of the DTG) the month and year were inferred on, or within the minute for
+// Resolutions other than ResolveCurrent and failed parses. The least
+// recently used entries are evicted. Parse with options bypasses the cache.
+// A CachingParser is safe for concurrent use.
+type CachingParser struct {
+	parser       *Parser
+	size         int
+	mu           sync.Mutex
+	entries      map[string]*list.Element
+	lru          *list.List
+	hits, misses uint64
+}
+
+var _ Interface = (*CachingParser)(nil)
+
+type cacheEntry struct {
+	dtgString string
+	dtg       DTG
+	err       error
+	// from and until are the reference times the entry holds for, until
+	// being exclusive. Zero until means forever.
+	from, until time.Time
+}
+
+// CacheStats are the counters of a CachingParser.
+type CacheStats struct {
+	Hits, Misses uint64
+	// Entries is the number of cached DTG strings.
+	Entries int
+}
+
+// HitRate returns the share of parses answered from the cache, 0-1.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// NewCachingParser returns a CachingParser of p (nil for the package level
+// behaviour) keeping size DTG strings, DefaultCacheSize when 0.
+func NewCachingParser(p *Parser, size int) *CachingParser {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &CachingParser{parser: p, size: size, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+// Parse is like Parser.Parse, but answers repeated DTG strings from the
+// cache.
+func (c *CachingParser) Parse(dtgString string, opts ...Option) (DTG, error) {
+	if len(opts) > 0 {
+		return c.parser.Parse(dtgString, opts...)
+	}
+	reference := c.parser.reference()
+	c.mu.Lock()
+	if element, ok := c.entries[dtgString]; ok {
+		e := element.Value.(*cacheEntry)
+		if !reference.Before(e.from) && (e.until.IsZero() || reference.Before(e.until)) {
+			c.hits++
+			c.lru.MoveToFront(element)
+			c.mu.Unlock()
+			return e.dtg, e.err
+		}
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	details, err := c.parser.ParseDetailed(dtgString, WithReferenceTime(reference))
+	e := &cacheEntry{dtgString: dtgString, dtg: details.DTG, err: err}
+	switch {
+	case err != nil || c.parser.resolution() != ResolveCurrent:
+		e.from = reference.Truncate(time.Minute)
+		e.until = e.from.Add(time.Minute)
+	case !details.ExplicitMonth || !details.ExplicitYear:
+		local := reference.In(details.DTG.Location())
+		e.from = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+		e.until = e.from.AddDate(0, 0, 1)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[dtgString]; ok {
+		element.Value = e
+		c.lru.MoveToFront(element)
+		return e.dtg, e.err
+	}
+	c.entries[dtgString] = c.lru.PushFront(e)
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).dtgString)
+	}
+	return e.dtg, e.err
+}
+
+// Validate is like Parser.Validate, but answers repeated DTG strings from
+// the cache.
+func (c *CachingParser) Validate(dtgString string) error {
+	_, err := c.Parse(dtgString)
+	return err
+}
+
+// Format is Parser.Format, formatting is not cached.
+func (c *CachingParser) Format(dtg DTG) string {
+	return c.parser.Format(dtg)
+}
+
+// Stats returns the hit and miss counters and the number of entries.
+func (c *CachingParser) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Entries: c.lru.Len()}
+}
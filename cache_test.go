@@ -0,0 +1,54 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCachingParser(t *testing.T) {
+	now := time.Date(2019, time.November, 30, 11, 30, 0, 0, time.UTC)
+	c := NewCachingParser(&Parser{Now: func() time.Time { return now }}, 2)
+	parse := func(s, expected string) {
+		t.Helper()
+		d, err := c.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != expected {
+			t.Errorf("Expected \"%s\" to give \"%s\", but got \"%s\"", s, expected, d)
+		}
+	}
+	parse(`151230ZDEC19`, `151230ZDEC19`)
+	parse(`151230ZDEC19`, `151230ZDEC19`)
+	parse(`011200M`, `011200MNOV19`)
+	if _, err := c.Parse(`151260Z`); err == nil {
+		t.Error("Expected error, but got nil")
+	}
+	if err := c.Validate(`151260Z`); err == nil {
+		t.Error("Expected error, but got nil")
+	}
+	if s := c.Stats(); s.Hits != 2 || s.Misses != 3 || s.Entries != 2 {
+		t.Errorf("Expected 2 hits, 3 misses and 2 entries, but got %+v", s)
+	}
+	// 151230ZDEC19 was evicted as least recently used.
+	parse(`151230ZDEC19`, `151230ZDEC19`)
+	if s := c.Stats(); s.Misses != 4 {
+		t.Errorf("Expected 4 misses, but got %d", s.Misses)
+	}
+	// It is already 1 December in M, where the month is inferred.
+	now = now.Add(time.Hour)
+	parse(`011200M`, `011200MDEC19`)
+	parse(`011200M`, `011200MDEC19`)
+	if s := c.Stats(); s.Hits != 3 || s.Misses != 5 {
+		t.Errorf("Expected 3 hits and 5 misses, but got %+v", s)
+	}
+	if got := c.Stats().HitRate(); got != 3.0/8 {
+		t.Errorf("Expected hit rate %v, but got %v", 3.0/8, got)
+	}
+	if d, err := c.Parse(`011200M`, WithReferenceTime(now.AddDate(0, 1, 2))); err != nil || d.String() != `011200MJAN20` {
+		t.Errorf("Expected \"011200MJAN20\", but got \"%s\" (%v)", d, err)
+	}
+	if got := c.Format(mustParse(t, `151230ZDEC19`)); got != `151230ZDEC19` {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\"", got)
+	}
+}
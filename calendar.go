@@ -0,0 +1,133 @@
+package dtg
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	googleCalendarURL string = "https://calendar.google.com/calendar/render"
+	outlookURL        string = "https://outlook.live.com/calendar/0/deeplink/compose"
+	icsLayout         string = "20060102T150405Z"
+)
+
+// Event is a calendar entry for a DTG or Range, e.g. for notification
+// emails with one-click "add to calendar" links. The DTG (or Range) as
+// operators write it is appended to the description, so the entry stays
+// unambiguous whatever time zone the calendar displays.
+type Event struct {
+	Title       string
+	Description string
+	Location    string
+	// Range is the time of the event, use Range{Start: d, End: d} for a
+	// point in time.
+	Range Range
+}
+
+// EventAt returns an Event for a point in time.
+func EventAt(dtg DTG, title string) Event {
+	return Event{Title: title, Range: Range{Start: dtg, End: dtg}}
+}
+
+func (e Event) description() string {
+	if e.Description == "" {
+		return "DTG " + e.Range.String()
+	}
+	return e.Description + "\n\nDTG " + e.Range.String()
+}
+
+// GoogleCalendarURL returns a Google Calendar link that opens a new event
+// pre-filled with the Event.
+func (e Event) GoogleCalendarURL() string {
+	v := url.Values{}
+	v.Set("action", "TEMPLATE")
+	v.Set("text", e.Title)
+	v.Set("dates", e.Range.Start.Time.UTC().Format(icsLayout)+"/"+e.Range.End.Time.UTC().Format(icsLayout))
+	v.Set("details", e.description())
+	if e.Location != "" {
+		v.Set("location", e.Location)
+	}
+	return googleCalendarURL + "?" + v.Encode()
+}
+
+// OutlookURL returns an Outlook on the web link that opens a new event
+// pre-filled with the Event.
+func (e Event) OutlookURL() string {
+	v := url.Values{}
+	v.Set("path", "/calendar/action/compose")
+	v.Set("rru", "addevent")
+	v.Set("subject", e.Title)
+	v.Set("startdt", e.Range.Start.Time.UTC().Format(time.RFC3339))
+	v.Set("enddt", e.Range.End.Time.UTC().Format(time.RFC3339))
+	v.Set("body", e.description())
+	if e.Location != "" {
+		v.Set("location", e.Location)
+	}
+	return outlookURL + "?" + v.Encode()
+}
+
+// ICS returns a minimal iCalendar (RFC 5545) file with the Event, for
+// attaching to emails as text/calendar. The UID is derived from the start
+// and title (see HashIDAt), so sending the same event again updates rather
+// than duplicates it.
+func (e Event) ICS() []byte {
+	var b bytes.Buffer
+	line := func(s string) {
+		// Fold lines longer than 75 octets, without splitting UTF-8
+		// sequences.
+		for len(s) > 75 {
+			i := 75
+			for i > 0 && s[i]&0xc0 == 0x80 {
+				i--
+			}
+			b.WriteString(s[:i] + "\r\n")
+			s = " " + s[i:]
+		}
+		b.WriteString(s + "\r\n")
+	}
+	uid := e.Range.Start.SortKey()
+	if id, err := HashIDAt(e.Range.Start, []byte(e.Title)); err == nil {
+		uid = id.String()
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//sa6mwa//dtg//EN")
+	line("METHOD:PUBLISH")
+	line("BEGIN:VEVENT")
+	line("UID:" + uid + "@dtg")
+	line("DTSTAMP:" + time.Now().UTC().Format(icsLayout))
+	line("DTSTART:" + e.Range.Start.Time.UTC().Format(icsLayout))
+	line("DTEND:" + e.Range.End.Time.UTC().Format(icsLayout))
+	line("SUMMARY:" + icsText(e.Title))
+	line("DESCRIPTION:" + icsText(e.description()))
+	if e.Location != "" {
+		line("LOCATION:" + icsText(e.Location))
+	}
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+	return b.Bytes()
+}
+
+// icsText escapes an iCalendar TEXT value.
+var icsText = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace
+
+// QRPayload returns the Event as a compact iCalendar VEVENT for QR codes on
+// printed orders, which phone cameras offer to add to the calendar when
+// scanned. Unlike ICS there is no VCALENDAR, UID or DTSTAMP, lines end with
+// LF only and DTEND is omitted for a point in time.
+func (e Event) QRPayload() string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VEVENT\nSUMMARY:" + icsText(e.Title) + "\n")
+	b.WriteString("DTSTART:" + e.Range.Start.Time.UTC().Format(icsLayout) + "\n")
+	if !e.Range.End.Time.Equal(e.Range.Start.Time) {
+		b.WriteString("DTEND:" + e.Range.End.Time.UTC().Format(icsLayout) + "\n")
+	}
+	b.WriteString("DESCRIPTION:" + icsText(e.description()) + "\n")
+	if e.Location != "" {
+		b.WriteString("LOCATION:" + icsText(e.Location) + "\n")
+	}
+	b.WriteString("END:VEVENT")
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package dtg
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestEventURLs(t *testing.T) {
+	r, err := ParseRange("151230ADEC19/151400ADEC19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := Event{Title: "Range safety brief", Description: "Bring ear protection", Location: "Range 3", Range: r}
+	google, err := url.Parse(e.GoogleCalendarURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := google.Query()
+	if google.Host != "calendar.google.com" || q.Get("action") != "TEMPLATE" || q.Get("text") != e.Title ||
+		q.Get("dates") != "20191215T113000Z/20191215T130000Z" || q.Get("location") != "Range 3" ||
+		q.Get("details") != "Bring ear protection\n\nDTG 151230ADEC19/151400ADEC19" {
+		t.Errorf("Unexpected Google Calendar URL %s", google)
+	}
+	outlook, err := url.Parse(e.OutlookURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q = outlook.Query()
+	if outlook.Host != "outlook.live.com" || q.Get("subject") != e.Title ||
+		q.Get("startdt") != "2019-12-15T11:30:00Z" || q.Get("enddt") != "2019-12-15T13:00:00Z" {
+		t.Errorf("Unexpected Outlook URL %s", outlook)
+	}
+}
+
+func TestEventICS(t *testing.T) {
+	e := EventAt(mustParse(t, "151230ZDEC19"), "H-hour; all units, "+strings.Repeat("x", 80))
+	ics := string(e.ICS())
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20191215T123000Z\r\n",
+		"DTEND:20191215T123000Z\r\n",
+		`SUMMARY:H-hour\; all units\, xxxx`,
+		"\r\n xxxx",
+		"DESCRIPTION:DTG 151230ZDEC19\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, expected) {
+			t.Errorf("Expected %q in\n%s", expected, ics)
+		}
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected lines of at most 75 octets, but got %q", line)
+		}
+	}
+	if again := string(e.ICS()); strings.Split(again, "UID:")[1][:36] != strings.Split(ics, "UID:")[1][:36] {
+		t.Errorf("Expected the same UID for the same event")
+	}
+}
+
+func TestEventQRPayload(t *testing.T) {
+	e := EventAt(mustParse(t, "151330ADEC19"), "RV; grid 123")
+	expected := "BEGIN:VEVENT\nSUMMARY:RV\\; grid 123\nDTSTART:20191215T123000Z\nDESCRIPTION:DTG 151330ADEC19\nEND:VEVENT"
+	if got := e.QRPayload(); got != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, got)
+	}
+	r, err := ParseRange("151230ADEC19/151400ADEC19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e = Event{Title: "Brief", Location: "Range 3", Range: r}
+	if got := e.QRPayload(); !strings.Contains(got, "\nDTEND:20191215T130000Z\n") || !strings.Contains(got, "\nLOCATION:Range 3\n") {
+		t.Errorf("Expected DTEND and LOCATION, but got \"%s\"", got)
+	}
+}
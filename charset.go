@@ -0,0 +1,76 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+var ErrUnknownCharset error = errors.New("unknown charset (must be auto, utf-8, cp437 or latin-1)")
+
+// Charset is the character encoding of a legacy message archive. Old
+// teletype and BBS era archives are rarely UTF-8, decoding them first keeps
+// the text of matches (and anything around them) readable.
+type Charset string
+
+const (
+	// CharsetAuto uses UTF-8 when the text is valid UTF-8, otherwise
+	// DetectCharset decides between CP437 and latin-1.
+	CharsetAuto   Charset = "auto"
+	CharsetUTF8   Charset = "utf-8"
+	CharsetCP437  Charset = "cp437"
+	CharsetLatin1 Charset = "latin-1"
+)
+
+// ParseCharset returns the Charset named s, accepting common aliases such
+// as utf8, ibm437 and iso-8859-1. An empty s is CharsetUTF8.
+func ParseCharset(s string) (Charset, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto":
+		return CharsetAuto, nil
+	case "", "utf-8", "utf8":
+		return CharsetUTF8, nil
+	case "cp437", "ibm437", "437":
+		return CharsetCP437, nil
+	case "latin-1", "latin1", "iso-8859-1", "iso8859-1":
+		return CharsetLatin1, nil
+	}
+	return "", ErrUnknownCharset
+}
+
+// DetectCharset guesses the encoding of b. Valid UTF-8 (which includes
+// plain ASCII) is CharsetUTF8. Otherwise any byte in 0x80-0x9F, control
+// characters in latin-1 but letters such as å, ä and ö in CP437, means
+// CharsetCP437, else CharsetLatin1.
+func DetectCharset(b []byte) Charset {
+	if utf8.Valid(b) {
+		return CharsetUTF8
+	}
+	for _, c := range b {
+		if c >= 0x80 && c <= 0x9f {
+			return CharsetCP437
+		}
+	}
+	return CharsetLatin1
+}
+
+// DecodeText converts b in charset cs into a (UTF-8) string. An empty cs
+// returns b unchanged.
+func DecodeText(b []byte, cs Charset) (string, error) {
+	if cs == CharsetAuto {
+		cs = DetectCharset(b)
+	}
+	switch cs {
+	case "", CharsetUTF8:
+		return string(b), nil
+	case CharsetCP437:
+		decoded, err := charmap.CodePage437.NewDecoder().Bytes(b)
+		return string(decoded), err
+	case CharsetLatin1:
+		decoded, err := charmap.ISO8859_1.NewDecoder().Bytes(b)
+		return string(decoded), err
+	}
+	return "", ErrUnknownCharset
+}
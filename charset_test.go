@@ -0,0 +1,55 @@
+package dtg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeText(t *testing.T) {
+	// "Hälsning 151230ZDEC19" in CP437, latin-1 and UTF-8.
+	tests := []struct {
+		input    []byte
+		charset  Charset
+		detected Charset
+	}{
+		{[]byte("H\x84lsning 151230ZDEC19"), CharsetCP437, CharsetCP437},
+		{[]byte("H\xe4lsning 151230ZDEC19"), CharsetLatin1, CharsetLatin1},
+		{[]byte("Hälsning 151230ZDEC19"), CharsetUTF8, CharsetUTF8},
+	}
+	for _, test := range tests {
+		if cs := DetectCharset(test.input); cs != test.detected {
+			t.Errorf("Expected %q to be detected as %s, but got %s", test.input, test.detected, cs)
+		}
+		for _, cs := range []Charset{test.charset, CharsetAuto} {
+			text, err := DecodeText(test.input, cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if text != "Hälsning 151230ZDEC19" {
+				t.Errorf("Expected \"Hälsning 151230ZDEC19\", but got \"%s\"", text)
+			}
+		}
+	}
+	if _, err := DecodeText([]byte("x"), "ebcdic"); err != ErrUnknownCharset {
+		t.Errorf("Expected ErrUnknownCharset, but got %v", err)
+	}
+	if cs, err := ParseCharset("ISO-8859-1"); err != nil || cs != CharsetLatin1 {
+		t.Errorf("Expected latin-1, but got %s (%v)", cs, err)
+	}
+}
+
+func TestFindAllFileCharset(t *testing.T) {
+	p := &Parser{Charset: CharsetAuto}
+	err := p.FindAllFile("-", bytes.NewReader([]byte("\x84 151230ZDEC19 \x94")), func(name, text string, matches []Match) error {
+		if text != "ä 151230ZDEC19 ö" {
+			t.Errorf("Expected \"ä 151230ZDEC19 ö\", but got \"%s\"", text)
+		}
+		if len(matches) != 1 || matches[0].Start != 3 || matches[0].Text != "151230ZDEC19" {
+			t.Errorf("Expected 151230ZDEC19 at 3, but got %+v", matches)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
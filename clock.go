@@ -0,0 +1,135 @@
+package dtg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sa6mwa/dtg/internal/ntp"
+)
+
+// DefaultResync is how often a Clock re-syncs against its source when
+// Resync is zero.
+const DefaultResync time.Duration = time.Hour
+
+// maxCatchUp is how far behind a Clock may be before Minutes jumps to the
+// current minute instead of emitting every minute in between.
+const maxCatchUp = 5
+
+// OffsetSource returns how much the local clock must be adjusted to be
+// correct (positive means the local clock is behind).
+type OffsetSource func() (time.Duration, error)
+
+// NTPSource returns an OffsetSource querying server (host or host:port, an
+// empty server uses pool.ntp.org).
+func NTPSource(server string) OffsetSource {
+	return func() (time.Duration, error) {
+		resp, err := ntp.Query(server, 0)
+		if err != nil {
+			return 0, err
+		}
+		return resp.ClockOffset, nil
+	}
+}
+
+// Clock is a drift corrected wall clock for long running displays. It
+// applies the offset measured by its Source to the local clock and
+// re-syncs periodically while Minutes is running. The zero value is the
+// uncorrected local clock. A Clock is safe for concurrent use.
+type Clock struct {
+	// Source measures the local clock offset, no correction is made when
+	// nil.
+	Source OffsetSource
+	// Resync is the interval between syncs in Minutes, DefaultResync when
+	// zero.
+	Resync time.Duration
+	// OnError, when not nil, is called with errors from Source. The
+	// previous offset is kept after an error.
+	OnError func(error)
+
+	mu     sync.Mutex
+	offset time.Duration
+}
+
+// Sync measures the offset of the local clock using Source.
+func (c *Clock) Sync() error {
+	if c.Source == nil {
+		return nil
+	}
+	offset, err := c.Source()
+	if err != nil {
+		if c.OnError != nil {
+			c.OnError(err)
+		}
+		return err
+	}
+	c.mu.Lock()
+	c.offset = offset
+	c.mu.Unlock()
+	return nil
+}
+
+// Offset returns the correction applied to the local clock.
+func (c *Clock) Offset() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offset
+}
+
+// Now returns the corrected current time.
+func (c *Clock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Minutes syncs the clock and returns a channel receiving a Zulu DTG at the
+// start of each minute of the corrected time, beginning with the next
+// minute, until ctx is done. Minutes never repeat when a re-sync sets the
+// clock back (the next minute waits) and are not skipped when it moves
+// forward by a few minutes (the missed minutes are sent at once). A larger
+// step, e.g. after the computer was suspended, continues from the current
+// minute. The channel is closed when ctx is done.
+func (c *Clock) Minutes(ctx context.Context) <-chan DTG {
+	ch := make(chan DTG)
+	go func() {
+		defer close(ch)
+		resync := c.Resync
+		if resync <= 0 {
+			resync = DefaultResync
+		}
+		c.Sync()
+		synced := time.Now()
+		last := c.Now().UTC().Truncate(time.Minute)
+		for {
+			if time.Since(synced) >= resync {
+				c.Sync()
+				synced = time.Now()
+			}
+			minute := nextMinute(last, c.Now())
+			if err := sleep(ctx, minute.Sub(c.Now())); err != nil {
+				return
+			}
+			if c.Now().Before(minute) {
+				// The clock was set back while sleeping.
+				continue
+			}
+			select {
+			case ch <- DTG{Time: minute}:
+			case <-ctx.Done():
+				return
+			}
+			last = minute
+		}
+	}()
+	return ch
+}
+
+// nextMinute returns the minute to emit after last given the current time
+// now: the minute following last, or the current minute if last is more
+// than maxCatchUp minutes behind.
+func nextMinute(last, now time.Time) time.Time {
+	current := now.UTC().Truncate(time.Minute)
+	if current.Sub(last) > maxCatchUp*time.Minute {
+		return current
+	}
+	return last.Add(time.Minute)
+}
@@ -0,0 +1,64 @@
+package dtg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	c := &Clock{Source: func() (time.Duration, error) { return time.Hour, nil }}
+	if err := c.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if d := c.Now().Sub(time.Now()); d < 59*time.Minute || d > 61*time.Minute {
+		t.Errorf("Expected the clock to be an hour ahead, but got %s", d)
+	}
+	failure := errors.New("unreachable")
+	var reported error
+	c.Source = func() (time.Duration, error) { return 0, failure }
+	c.OnError = func(err error) { reported = err }
+	if err := c.Sync(); err != failure || reported != failure {
+		t.Errorf("Expected %v, but got %v (reported %v)", failure, err, reported)
+	}
+	if c.Offset() != time.Hour {
+		t.Errorf("Expected the offset to be kept after an error, but got %s", c.Offset())
+	}
+}
+
+func TestClockMinutes(t *testing.T) {
+	// Put the corrected clock 50ms before a minute.
+	now := time.Now()
+	boundary := now.Truncate(time.Minute).Add(time.Minute)
+	offset := boundary.Add(-50 * time.Millisecond).Sub(now)
+	c := &Clock{Source: func() (time.Duration, error) { return offset, nil }}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	select {
+	case d := <-c.Minutes(ctx):
+		if !d.Time.Equal(boundary.UTC()) || d.String()[6] != 'Z' {
+			t.Errorf("Expected %s, but got %s", boundary.UTC(), d.Time)
+		}
+	case <-ctx.Done():
+		t.Error("Expected a minute within 5s")
+	}
+}
+
+func TestNextMinute(t *testing.T) {
+	last := time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		now      time.Duration
+		expected time.Duration
+	}{
+		{30 * time.Second, time.Minute},
+		{-10 * time.Minute, time.Minute},
+		{3*time.Minute + 30*time.Second, time.Minute},
+		{10*time.Minute + 5*time.Second, 10 * time.Minute},
+	}
+	for _, test := range tests {
+		if got := nextMinute(last, last.Add(test.now)); !got.Equal(last.Add(test.expected)) {
+			t.Errorf("Expected %s at %s, but got %s", last.Add(test.expected), last.Add(test.now), got)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+	"github.com/sa6mwa/dtg/internal/ntp"
+)
+
+// doctor answers the questions every new operator asks: which zone the
+// host is in, which letter that corresponds to, whether DST is in effect and
+// whether the clock can be trusted.
+func doctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	server := fs.String("ntp", ntp.DefaultServer, "NTP `server` to measure clock skew against")
+	timeout := fs.Duration("timeout", ntp.DefaultTimeout, "NTP query timeout")
+	offline := fs.Bool("offline", false, "skip the NTP clock skew check")
+	maxSkew := fs.Duration("max-skew", 5*time.Second, "warn when the clock is off by more than this")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg doctor [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+
+	var warnings []string
+	now := time.Now()
+	abbreviation, offset := now.Zone()
+
+	fmt.Printf("local zone:    %s (%s, %s)\n", localZoneName(), abbreviation, formatOffset(offset))
+
+	if letter, err := dtg.LetterForOffset(offset, dtg.PolicyExact); err == nil {
+		fmt.Printf("zone letter:   %s (J denotes local time, currently %s)\n", letter, dtg.DTG{Time: now})
+	} else {
+		fmt.Printf("zone letter:   none, %s (only J can be used)\n", whyNoLetter(offset))
+	}
+
+	if now.IsDST() {
+		fmt.Printf("dst:           in effect")
+		if std, ok := standardOffset(now); ok {
+			fmt.Printf(" (standard time is %s)", formatOffset(std))
+		}
+		fmt.Println()
+	} else {
+		fmt.Println("dst:           not in effect")
+	}
+	if next, ok := nextTransition(now); ok {
+		a, o := next.Zone()
+		fmt.Printf("next change:   %s, local offset becomes %s (%s)\n", next.UTC().Format("021504Z Jan 2006"), formatOffset(o), a)
+	} else {
+		fmt.Println("next change:   none within a year")
+	}
+
+	for _, o := range yearOffsets(now) {
+		if o%3600 != 0 {
+			warnings = append(warnings, fmt.Sprintf("local offset %s is not a whole hour, J DTGs from this host cannot be expressed with another letter without rounding", formatOffset(o)))
+		} else if _, err := dtg.LetterForOffset(o, dtg.PolicyExact); err != nil {
+			warnings = append(warnings, fmt.Sprintf("local offset %s has no zone letter, only J can be used", formatOffset(o)))
+		}
+	}
+
+	if *offline {
+		fmt.Println("clock skew:    not checked (-offline)")
+	} else if resp, err := ntp.Query(*server, *timeout); err != nil {
+		fmt.Printf("clock skew:    unknown, %v\n", err)
+		warnings = append(warnings, "could not reach "+*server+", clock skew is unknown")
+	} else {
+		fmt.Printf("clock skew:    local clock %+.3fs vs %s (rtt %s, stratum %d)\n", -resp.ClockOffset.Seconds(), *server, resp.RTT.Round(time.Millisecond), resp.Stratum)
+		if resp.ClockOffset > *maxSkew || resp.ClockOffset < -*maxSkew {
+			warnings = append(warnings, fmt.Sprintf("local clock is off by %s, DTGs stamped by this host may be wrong", resp.ClockOffset.Round(time.Millisecond)))
+		}
+	}
+
+	for _, w := range warnings {
+		fmt.Println("warning:", w)
+	}
+	return nil
+}
+
+// localZoneName returns the best available name of time.Local, which is
+// "Local" unless TZ is set.
+func localZoneName() string {
+	if tz, ok := os.LookupEnv("TZ"); ok {
+		if tz == "" {
+			return "UTC (TZ is empty)"
+		}
+		return tz + " (from TZ)"
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+			return target[i+len("zoneinfo/"):]
+		}
+		return filepath.Base(target)
+	}
+	return time.Local.String()
+}
+
+func whyNoLetter(offset int) string {
+	if offset%3600 != 0 {
+		return fmt.Sprintf("offset %s is not a whole hour", formatOffset(offset))
+	}
+	return fmt.Sprintf("offset %s is outside UTC-12 to UTC+12", formatOffset(offset))
+}
+
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// yearOffsets returns the distinct local offsets in use during the year
+// following t.
+func yearOffsets(t time.Time) []int {
+	seen := map[int]bool{}
+	var offsets []int
+	for d := 0; d <= 366; d += 7 {
+		_, o := t.AddDate(0, 0, d).Zone()
+		if !seen[o] {
+			seen[o] = true
+			offsets = append(offsets, o)
+		}
+	}
+	return offsets
+}
+
+// standardOffset returns the local offset during the part of the year when
+// DST is not in effect.
+func standardOffset(t time.Time) (int, bool) {
+	for d := 0; d <= 366; d += 7 {
+		u := t.AddDate(0, 0, d)
+		if !u.IsDST() {
+			_, o := u.Zone()
+			return o, true
+		}
+	}
+	return 0, false
+}
+
+// nextTransition finds the next local offset change within a year of t,
+// accurate to the second.
+func nextTransition(t time.Time) (time.Time, bool) {
+	_, current := t.Zone()
+	lo := t
+	for h := 1; h <= 366*24; h++ {
+		hi := t.Add(time.Duration(h) * time.Hour)
+		if _, o := hi.Zone(); o != current {
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, o := mid.Zone(); o == current {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			return hi.Truncate(time.Second), true
+		}
+		lo = hi
+	}
+	return time.Time{}, false
+}
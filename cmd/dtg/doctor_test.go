@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatOffset(t *testing.T) {
+	testTable := []struct {
+		offset   int
+		expected string
+	}{
+		{0, "UTC+00:00"},
+		{3600, "UTC+01:00"},
+		{5*3600 + 1800, "UTC+05:30"},
+		{5*3600 + 2700, "UTC+05:45"},
+		{-(9*3600 + 1800), "UTC-09:30"},
+		{14 * 3600, "UTC+14:00"},
+	}
+	for _, v := range testTable {
+		if s := formatOffset(v.offset); s != v.expected {
+			t.Errorf("Expected \"%s\" for %d, but got \"%s\"", v.expected, v.offset, s)
+		}
+	}
+}
+
+func TestWhyNoLetter(t *testing.T) {
+	testTable := []struct {
+		offset   int
+		expected string
+	}{
+		{5*3600 + 1800, "offset UTC+05:30 is not a whole hour"},
+		{-(3*3600 + 1800), "offset UTC-03:30 is not a whole hour"},
+		{13 * 3600, "offset UTC+13:00 is outside UTC-12 to UTC+12"},
+		{14 * 3600, "offset UTC+14:00 is outside UTC-12 to UTC+12"},
+	}
+	for _, v := range testTable {
+		if s := whyNoLetter(v.offset); s != v.expected {
+			t.Errorf("Expected \"%s\" for %d, but got \"%s\"", v.expected, v.offset, s)
+		}
+	}
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skip(err)
+	}
+	return loc
+}
+
+func TestYearOffsets(t *testing.T) {
+	testTable := []struct {
+		loc      *time.Location
+		expected []int
+	}{
+		{time.UTC, []int{0}},
+		{time.FixedZone("", 5*3600+1800), []int{5*3600 + 1800}},
+		{loadLocation(t, "Europe/Stockholm"), []int{3600, 7200}},
+		{loadLocation(t, "America/St_Johns"), []int{-(3*3600 + 1800), -(2*3600 + 1800)}},
+	}
+	for _, v := range testTable {
+		offsets := yearOffsets(time.Date(2019, time.December, 15, 12, 30, 0, 0, v.loc))
+		if len(offsets) != len(v.expected) {
+			t.Errorf("Expected %v in %s, but got %v", v.expected, v.loc, offsets)
+			continue
+		}
+		for i := range offsets {
+			if offsets[i] != v.expected[i] {
+				t.Errorf("Expected %v in %s, but got %v", v.expected, v.loc, offsets)
+				break
+			}
+		}
+	}
+}
+
+func TestNextTransition(t *testing.T) {
+	testTable := []struct {
+		loc      *time.Location
+		from     time.Time
+		expected time.Time
+		ok       bool
+	}{
+		{time.UTC, time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC), time.Time{}, false},
+		{loadLocation(t, "Asia/Kolkata"), time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC), time.Time{}, false},
+		{loadLocation(t, "Europe/Stockholm"), time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC), time.Date(2020, time.March, 29, 1, 0, 0, 0, time.UTC), true},
+		{loadLocation(t, "Europe/Stockholm"), time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, time.October, 25, 1, 0, 0, 0, time.UTC), true},
+		{loadLocation(t, "America/St_Johns"), time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC), time.Date(2020, time.March, 8, 5, 30, 0, 0, time.UTC), true},
+	}
+	for _, v := range testTable {
+		transition, ok := nextTransition(v.from.In(v.loc))
+		if ok != v.ok || !transition.Equal(v.expected) {
+			t.Errorf("Expected %s (%t) in %s, but got %s (%t)", v.expected, v.ok, v.loc, transition.UTC(), ok)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// extractRecord is the JSON output of dtg extract -json.
+type extractRecord struct {
+	File          string `json:"file"`
+	Line          int    `json:"line"`
+	Column        int    `json:"column"`
+	Text          string `json:"text"`
+	DTG           string `json:"dtg"`
+	Letter        string `json:"letter"`
+	Offset        string `json:"offset"`
+	ExplicitMonth bool   `json:"explicit_month"`
+	ExplicitYear  bool   `json:"explicit_year"`
+	Instant       string `json:"instant"`
+}
+
+// extract prints every DTG found in the input files (or stdin) with its
+// position, zone letter, offset and whether month and year were written out.
+func extract(args []string) error {
+	fs := flag.NewFlagSet("extract", flag.ContinueOnError)
+	letters := fs.String("letters", "", "only report DTGs with these comma separated zone `letters` (e.g. J)")
+	asJSON := fs.Bool("json", false, "print one JSON object per DTG")
+	charset := fs.String("charset", "utf-8", charsetUsage)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg extract [flags] [file ...]\n\nDirectories are searched recursively, gzip files and zip archives are\ndecompressed. With no file, or when file is -, read standard input.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	p, err := newParser(*charset)
+	if err != nil {
+		return err
+	}
+	filter := letterFilter(*letters)
+	enc := json.NewEncoder(os.Stdout)
+	return eachInput(p, fs.Args(), func(name, text string, matches []dtg.Match) error {
+		for _, m := range matches {
+			if filter != nil && !filter[m.Designator] {
+				continue
+			}
+			line, column := position(text, m.Start)
+			record := extractRecord{
+				File:          name,
+				Line:          line,
+				Column:        column,
+				Text:          m.Text,
+				DTG:           m.DTG.String(),
+				Letter:        m.Designator,
+				Offset:        m.DTG.Time.Format("-0700"),
+				ExplicitMonth: m.ExplicitMonth,
+				ExplicitYear:  m.ExplicitYear,
+				Instant:       m.DTG.Zulu().RFC3339(),
+			}
+			if *asJSON {
+				if err := enc.Encode(record); err != nil {
+					return err
+				}
+				continue
+			}
+			fmt.Printf("%s:%d:%d: %s %s letter=%s offset=%s month=%s year=%s\n",
+				record.File, record.Line, record.Column, record.Text, record.DTG,
+				record.Letter, record.Offset, explicitness(record.ExplicitMonth), explicitness(record.ExplicitYear))
+		}
+		return nil
+	})
+}
+
+// eachInput calls fn with the DTGs of every named file or directory, or
+// standard input if there are none or the name is -. Compressed files and
+// zip archives are searched transparently, see dtg.FindAllFile.
+func eachInput(p *dtg.Parser, names []string, fn dtg.FileFunc) error {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	for _, name := range names {
+		if name == "-" {
+			if err := p.FindAllFile("-", os.Stdin, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if info, err := os.Stat(name); err == nil && info.IsDir() {
+			err := p.FindAllFS(os.DirFS(name), ".", func(member, text string, matches []dtg.Match) error {
+				return fn(filepath.Join(name, member), text, matches)
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = p.FindAllFile(name, f, fn)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const charsetUsage = "`charset` of the input: utf-8, cp437, latin-1 or auto to detect"
+
+// newParser returns a parser decoding input in the named charset.
+func newParser(charset string) (*dtg.Parser, error) {
+	cs, err := dtg.ParseCharset(charset)
+	if err != nil {
+		return nil, err
+	}
+	return &dtg.Parser{Charset: cs}, nil
+}
+
+// letterFilter returns the set of comma separated letters, or nil if s is
+// empty.
+func letterFilter(s string) map[string]bool {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	filter := map[string]bool{}
+	for _, letter := range strings.Split(s, ",") {
+		filter[strings.ToUpper(strings.TrimSpace(letter))] = true
+	}
+	return filter
+}
+
+// position returns the 1-based line and column (in bytes) of offset in text.
+func position(text string, offset int) (line, column int) {
+	line = 1 + strings.Count(text[:offset], "\n")
+	column = offset - strings.LastIndex(text[:offset], "\n")
+	return line, column
+}
+
+func explicitness(explicit bool) string {
+	if explicit {
+		return "explicit"
+	}
+	return "inferred"
+}
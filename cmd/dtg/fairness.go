@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// fairness reports the local time of a recurring meeting for each member of
+// a distributed team and who has to attend in antisocial hours. Several
+// comma separated meeting DTGs compare a rotation.
+func fairness(args []string) error {
+	fs := flag.NewFlagSet("fairness", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg fairness meeting[,meeting ...] name=letter ...\n\nAntisocial hours are %02d00-%02d00 in the participant's zone.\n", dtg.AntisocialStart, dtg.AntisocialEnd)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return errUsage
+	}
+	var meetings []dtg.DTG
+	for _, s := range strings.Split(fs.Arg(0), ",") {
+		meeting, err := dtg.Parse(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s, err)
+		}
+		meetings = append(meetings, meeting)
+	}
+	var team []dtg.Participant
+	for _, arg := range fs.Args()[1:] {
+		name, letter, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			fs.Usage()
+			return errUsage
+		}
+		team = append(team, dtg.Participant{Name: name, Letter: letter})
+	}
+	for _, meeting := range meetings {
+		attendance, err := dtg.Fairness(meeting, team)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", meeting.Zulu())
+		for _, a := range attendance {
+			note := ""
+			if a.Antisocial {
+				note = "  antisocial"
+			}
+			fmt.Printf("  %-16s %s%s\n", a.Participant.Name, a.DTG, note)
+		}
+	}
+	if len(meetings) > 1 {
+		counts, err := dtg.AntisocialCounts(meetings, team)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if counts[names[i]] != counts[names[j]] {
+				return counts[names[i]] > counts[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		fmt.Printf("\nantisocial meetings of %d:\n", len(meetings))
+		for _, name := range names {
+			fmt.Printf("  %-16s %d\n", name, counts[name])
+		}
+	}
+	return nil
+}
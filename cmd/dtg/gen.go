@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// precedences are the ACP 121 precedence prosigns weighted by how common
+// they are in ordinary traffic.
+var precedences = []struct {
+	prosign string
+	weight  int
+}{
+	{"R", 70}, // routine
+	{"P", 20}, // priority
+	{"O", 8},  // immediate
+	{"Z", 2},  // flash
+}
+
+// gen writes dummy message headers with random DTGs spread uniformly over a
+// window, in DTG order, for load testing message handling systems. Each
+// header is followed by the HeaderValue of its DTG.
+func gen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	count := fs.Int("count", 1000, "number of messages")
+	from := fs.String("from", "", "first `DTG` of the window (default now)")
+	to := fs.String("to", "", "last `DTG` of the window (default 24 hours after -from)")
+	letters := fs.String("letters", "Z", "comma separated zone `letters` to write the DTGs in")
+	stations := fs.Int("stations", 20, "number of stations exchanging traffic")
+	seed := fs.Int64("seed", 0, "random seed for reproducible output (default random)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg gen [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	if fs.NArg() != 0 || *count < 0 || *stations < 2 {
+		fs.Usage()
+		return errUsage
+	}
+	start := dtg.DTG{Time: time.Now().UTC().Truncate(time.Minute)}
+	if *from != "" {
+		var err error
+		if start, err = dtg.Parse(*from); err != nil {
+			return fmt.Errorf("-from %s: %w", *from, err)
+		}
+	}
+	end := dtg.DTG{Time: start.Time.Add(24 * time.Hour)}
+	if *to != "" {
+		var err error
+		if end, err = dtg.Parse(*to); err != nil {
+			return fmt.Errorf("-to %s: %w", *to, err)
+		}
+	}
+	window, err := dtg.NewRange(start, end)
+	if err != nil {
+		return err
+	}
+	var zones []*time.Location
+	for _, letter := range strings.Split(*letters, ",") {
+		loc, err := dtg.GetNumericTimeZone(letter)
+		if err != nil {
+			return fmt.Errorf("-letters %s: %w", letter, err)
+		}
+		zones = append(zones, loc)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+	messages := make([]dtg.DTG, *count)
+	for i := range messages {
+		d := dtg.RandomIn(window, rng)
+		messages[i] = dtg.DTG{Time: d.Time.In(zones[rng.Intn(len(zones))])}
+	}
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].Time.Before(messages[j].Time) })
+	w := bufio.NewWriter(os.Stdout)
+	for _, d := range messages {
+		sender := 1 + rng.Intn(*stations)
+		recipient := 1 + rng.Intn(*stations-1)
+		if recipient >= sender {
+			recipient++
+		}
+		fmt.Fprintf(w, "%s %s FM STN%02d TO STN%02d X-DTG %s\n", precedence(rng), d, sender, recipient, dtg.HeaderValue(d))
+	}
+	return w.Flush()
+}
+
+func precedence(rng *rand.Rand) string {
+	n := rng.Intn(100)
+	for _, p := range precedences {
+		if n < p.weight {
+			return p.prosign
+		}
+		n -= p.weight
+	}
+	return precedences[0].prosign
+}
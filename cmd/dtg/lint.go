@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sa6mwa/dtg"
+)
+
+var errLint = errors.New("invalid DTGs found")
+
+// publishDiagnostics are the parameters of an LSP
+// textDocument/publishDiagnostics notification.
+type publishDiagnostics struct {
+	URI         string           `json:"uri"`
+	Diagnostics []dtg.Diagnostic `json:"diagnostics"`
+}
+
+// lint reports problems with the DTGs in documents, as text or as LSP
+// diagnostics for editor extensions.
+func lint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	format := fs.String("format", "text", "output `format`: text or lsp-json (one publishDiagnostics object per file)")
+	charset := fs.String("charset", "utf-8", charsetUsage)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg lint [flags] [file ...]\n\nExits with status 1 if any DTG is invalid.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	if *format != "text" && *format != "lsp-json" {
+		fmt.Fprintf(fs.Output(), "dtg lint: unknown format %q\n", *format)
+		return errUsage
+	}
+	p, err := newParser(*charset)
+	if err != nil {
+		return err
+	}
+	invalid := false
+	enc := json.NewEncoder(os.Stdout)
+	err = eachInput(p, fs.Args(), func(name, text string, _ []dtg.Match) error {
+		findings := p.Lint(text)
+		for _, f := range findings {
+			invalid = invalid || f.Severity == dtg.SeverityError
+		}
+		if *format == "lsp-json" {
+			return enc.Encode(publishDiagnostics{URI: fileURI(name), Diagnostics: dtg.Diagnostics(text, findings)})
+		}
+		for _, f := range findings {
+			line, column := position(text, f.Start)
+			fmt.Printf("%s:%d:%d: %s: %s: %s", name, line, column, f.Severity, f.Text, f.Message)
+			if f.Fix != "" {
+				fmt.Printf(" (fix: %s)", f.Fix)
+			}
+			fmt.Println()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if invalid {
+		return errLint
+	}
+	return nil
+}
+
+// fileURI returns the file URI of name, or name itself for standard input
+// and archive members that have no URI.
+func fileURI(name string) string {
+	if name == "-" {
+		return name
+	}
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return name
+	}
+	return "file://" + filepath.ToSlash(abs)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sa6mwa/dtg"
+	"github.com/sa6mwa/dtg/logformats"
+)
+
+// loki prefixes log lines read from standard input with the DTG of their
+// timestamp (dtg=151230ZDEC19, logfmt) for Promtail and Grafana Loki.
+func loki(args []string) error {
+	fs := flag.NewFlagSet("loki", flag.ContinueOnError)
+	letter := fs.String("letter", "Z", "zone `letter` to write the DTGs in")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg loki [flags] < log\n\nLines without a recognized timestamp are passed through unchanged.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	if _, err := dtg.GetNumericTimeZone(strings.ToUpper(*letter)); err != nil {
+		return fmt.Errorf("%q: %w", *letter, err)
+	}
+	a := &logformats.Annotator{Letter: *letter}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	for scanner.Scan() {
+		line, _ := a.Annotate(scanner.Text())
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
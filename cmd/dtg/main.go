@@ -0,0 +1,69 @@
+// Command dtg is a small toolbox for working with ACP 121 Date-Time Groups
+// from the shell. Run `dtg help` for the list of commands.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command is a dtg sub-command. run receives the arguments following the
+// command name.
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"doctor":   {"report local time zone, zone letter, DST and clock skew", doctor},
+	"extract":  {"list the DTGs in text files with zone letter and offset", extract},
+	"fairness": {"show a meeting in each member's zone, flag antisocial hours", fairness},
+	"gen":      {"generate DTG stamped dummy message headers for load tests", gen},
+	"lint":     {"report invalid and ambiguous DTGs in documents", lint},
+	"loki":     {"prefix log lines with the DTG of their timestamp for Loki", loki},
+	"stats":    {"count the DTGs in text files, optionally per zone letter", stats},
+	"validate": {"check DTGs and show how they are resolved (-v)", validate},
+}
+
+// errUsage signals that the command already printed its usage and the
+// program should exit with status 2.
+var errUsage = errors.New("usage")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: dtg <command> [arguments]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "dtg: unknown command %q\n", name)
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "dtg %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// stats summarizes the DTGs in a corpus, with -by-letter broken down per
+// zone letter to audit e.g. compliance with a Zulu-only SOP.
+func stats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	byLetter := fs.Bool("by-letter", false, "count DTGs per zone letter")
+	charset := fs.String("charset", "utf-8", charsetUsage)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg stats [flags] [file ...]\n\nDirectories are searched recursively, gzip files and zip archives are\ndecompressed. With no file, or when file is -, read standard input.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	p, err := newParser(*charset)
+	if err != nil {
+		return err
+	}
+	counts := dtg.LetterCounts{}
+	files := 0
+	err = eachInput(p, fs.Args(), func(name, text string, matches []dtg.Match) error {
+		files++
+		counts.Add(matches)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	total := counts.Total()
+	fmt.Printf("files: %d\ndtgs:  %d\n", files, total)
+	if *byLetter && total > 0 {
+		fmt.Println()
+		for _, c := range counts.Sorted() {
+			fmt.Printf("%-2s %8d %6.1f%%\n", c.Letter, c.Count, 100*counts.Share(c.Letter))
+		}
+	}
+	return nil
+}
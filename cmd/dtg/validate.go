@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+var errInvalid = errors.New("invalid DTG")
+
+// validate checks DTGs given as arguments and, with -v, shows how each is
+// resolved right now: the full instant, the assumed month and year and the
+// reference time used to infer them.
+func validate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "show how each DTG is resolved")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: dtg validate [flags] dtg ...\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+	invalid := 0
+	for _, s := range fs.Args() {
+		details, err := dtg.ParseDetailed(s)
+		if err != nil {
+			invalid++
+			fmt.Printf("%s: invalid: %v\n", s, err)
+			continue
+		}
+		fmt.Printf("%s: valid, %s\n", s, details.DTG)
+		if *verbose {
+			printDetails(details)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%w (%d of %d)", errInvalid, invalid, fs.NArg())
+	}
+	return nil
+}
+
+func printDetails(d dtg.Details) {
+	fmt.Printf("  instant:    %s (%s)\n", d.DTG.RFC3339(), d.DTG.Time.UTC().Format(time.RFC3339))
+	fmt.Printf("  letter:     %s, %s\n", d.Designator, inferred(d.ExplicitDesignator, "J, local time"))
+	fmt.Printf("  offset:     %s\n", formatOffset(d.Offset))
+	fmt.Printf("  month:      %s, %s\n", strings.ToUpper(d.DTG.Time.Format("Jan")), inferred(d.ExplicitMonth, "the current month"))
+	fmt.Printf("  year:       %d, %s\n", d.DTG.Time.Year(), inferred(d.ExplicitYear, "the current year"))
+	if !d.ExplicitMonth || !d.ExplicitYear || d.Designator == "J" {
+		fmt.Printf("  reference:  %s\n", d.Reference.Format(time.RFC3339))
+	}
+}
+
+func inferred(explicit bool, assumed string) string {
+	if explicit {
+		return "as written"
+	}
+	return "assumed " + assumed
+}
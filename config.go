@@ -0,0 +1,255 @@
+package dtg
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrConfigFormat  error = errors.New("unknown config format (must be .toml, .yaml or .yml)")
+	ErrInvalidConfig error = errors.New("invalid config")
+)
+
+// Config is a DTG policy distributed as a file, see LoadConfig.
+type Config struct {
+	// DefaultZone is the letter an omitted designator (and J) resolves to,
+	// the local time zone when empty.
+	DefaultZone string
+	// Strict is Parser.Strict.
+	Strict bool
+	// CenturyPivot is Parser.CenturyPivot.
+	CenturyPivot int
+	// Locale is the locale for Humanize.
+	Locale string
+	// AllowedLetters restricts the zone table to these letters, all letters
+	// are allowed when empty.
+	AllowedLetters []string
+}
+
+// configKeys are the keys of a config file.
+var configKeys = map[string]func(c *Config, value string) error{
+	"default_zone": func(c *Config, value string) error {
+		c.DefaultZone = strings.ToUpper(configString(value))
+		return nil
+	},
+	"strict": func(c *Config, value string) (err error) {
+		c.Strict, err = strconv.ParseBool(configString(value))
+		return err
+	},
+	"century_pivot": func(c *Config, value string) (err error) {
+		c.CenturyPivot, err = strconv.Atoi(configString(value))
+		return err
+	},
+	"locale": func(c *Config, value string) error {
+		c.Locale = configString(value)
+		return nil
+	},
+	"allowed_letters": func(c *Config, value string) error {
+		c.AllowedLetters = append(c.AllowedLetters, configList(value)...)
+		return nil
+	},
+}
+
+// LoadConfig reads a policy from a TOML (.toml) or YAML (.yaml, .yml) file,
+// so that fleet wide SOP settings can be distributed as a file:
+//
+//	default_zone = "Z"
+//	strict = true
+//	century_pivot = 1929
+//	locale = "sv"
+//	allowed_letters = ["Z", "A", "B"]
+//
+// or in YAML
+//
+//	default_zone: Z
+//	strict: true
+//	allowed_letters:
+//	  - Z
+//	  - A
+//
+// Only these top level keys, strings, booleans, integers and lists of
+// strings are understood, it is not a general TOML or YAML parser.
+func LoadConfig(path string) (Config, error) {
+	var separator string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		separator = "="
+	case ".yaml", ".yml":
+		separator = ":"
+	default:
+		return Config{}, ErrConfigFormat
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+	c, err := readConfig(f, separator)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s:%w", path, err)
+	}
+	return c, nil
+}
+
+// ConfigFromEnv reads a policy from the environment variables
+// DTG_DEFAULT_ZONE, DTG_STRICT, DTG_CENTURY_PIVOT, DTG_LOCALE and
+// DTG_ALLOWED_LETTERS (comma separated), the keys of LoadConfig in upper
+// case, for containerized services that can not ship a config file. Unset
+// or empty variables keep their defaults.
+func ConfigFromEnv() (Config, error) {
+	var c Config
+	for key, set := range configKeys {
+		name := "DTG_" + strings.ToUpper(key)
+		value := strings.TrimSpace(os.Getenv(name))
+		if value == "" {
+			continue
+		}
+		if err := set(&c, value); err != nil {
+			return Config{}, fmt.Errorf("%s: %w: %v", name, ErrInvalidConfig, err)
+		}
+	}
+	return c, nil
+}
+
+// FromEnv returns a Parser configured from the environment, see
+// ConfigFromEnv.
+func FromEnv() (*Parser, error) {
+	c, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return c.Parser()
+}
+
+func readConfig(r io.Reader, separator string) (Config, error) {
+	var c Config
+	var list string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := configComment(scanner.Text())
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if list != "" && strings.HasPrefix(trimmed, "- ") {
+			// A YAML block sequence item.
+			if err := configKeys[list](&c, strings.TrimSpace(trimmed[2:])); err != nil {
+				return Config{}, fmt.Errorf("%d: %w: %v", line, ErrInvalidConfig, err)
+			}
+			continue
+		}
+		list = ""
+		key, value, ok := strings.Cut(trimmed, separator)
+		key = strings.TrimSpace(key)
+		set, known := configKeys[key]
+		if !ok || !known {
+			return Config{}, fmt.Errorf("%d: %w: unknown key %q", line, ErrInvalidConfig, key)
+		}
+		value = strings.TrimSpace(value)
+		if value == "" && separator == ":" {
+			list = key
+			continue
+		}
+		if err := set(&c, value); err != nil {
+			return Config{}, fmt.Errorf("%d: %w: %s: %v", line, ErrInvalidConfig, key, err)
+		}
+	}
+	return c, scanner.Err()
+}
+
+// configComment removes a # comment outside of quotes from line.
+func configComment(line string) string {
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// configString removes the quotes around a string value.
+func configString(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// configList splits a [a, b] or a, b list value.
+func configList(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToUpper(configString(item)); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// zones returns the default zone table restricted to the allowed letters.
+func (c Config) zones() (*ZoneTable, error) {
+	zones := defaultZones
+	if len(c.AllowedLetters) == 0 {
+		return zones, nil
+	}
+	allowed := make(map[string]bool, len(c.AllowedLetters))
+	for _, letter := range c.AllowedLetters {
+		letter = strings.ToUpper(strings.TrimSpace(letter))
+		if _, ok := zones.Offset(letter); !ok && letter != "J" {
+			return nil, fmt.Errorf("%w: allowed letter %q", ErrInvalidTimeZoneLetter, letter)
+		}
+		allowed[letter] = true
+	}
+	zones = zones.Clone()
+	for _, letter := range zones.Designators() {
+		if !allowed[letter] {
+			zones.Remove(letter)
+		}
+	}
+	return zones, nil
+}
+
+// Parser returns a Parser applying the policy.
+func (c Config) Parser() (*Parser, error) {
+	zones, err := c.zones()
+	if err != nil {
+		return nil, err
+	}
+	if c.DefaultZone != "" {
+		offset, ok := defaultZones.Offset(c.DefaultZone)
+		if !ok {
+			return nil, fmt.Errorf("%w: default zone %q", ErrInvalidTimeZoneLetter, c.DefaultZone)
+		}
+		if zones == defaultZones {
+			zones = zones.Clone()
+		}
+		// Pin J, and with it an omitted designator, to the default zone.
+		zones.Set("J", offset)
+	}
+	return &Parser{Zones: zones, Strict: c.Strict, CenturyPivot: c.CenturyPivot}, nil
+}
+
+// Formatter returns a Formatter applying the policy, i.e. only using the
+// allowed letters.
+func (c Config) Formatter() (*Formatter, error) {
+	zones, err := c.zones()
+	if err != nil {
+		return nil, err
+	}
+	return &Formatter{Zones: zones}, nil
+}
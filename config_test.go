@@ -0,0 +1,111 @@
+package dtg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"policy.toml": `# SOP 12
+default_zone = "Z"
+strict = true
+century_pivot = 1929 # archived traffic
+locale = 'sv'
+allowed_letters = ["Z", "a", "B"]
+`,
+		"policy.yaml": `---
+default_zone: Z # zulu
+strict: true
+century_pivot: 1929
+locale: "sv"
+allowed_letters:
+  - Z
+  - a
+  - "B"
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.DefaultZone != "Z" || !c.Strict || c.CenturyPivot != 1929 || c.Locale != "sv" || len(c.AllowedLetters) != 3 || c.AllowedLetters[1] != "A" {
+			t.Errorf("Unexpected config from %s: %+v", name, c)
+		}
+	}
+	if _, err := LoadConfig(filepath.Join(dir, "policy.ini")); err != ErrConfigFormat {
+		t.Errorf("Expected %v, but got %v", ErrConfigFormat, err)
+	}
+	path := filepath.Join(dir, "invalid.toml")
+	if err := os.WriteFile(path, []byte("strict = true\nzone = \"Z\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); !errors.Is(err, ErrInvalidConfig) || err.Error() != path+`:2: invalid config: unknown key "zone"` {
+		t.Errorf("Expected an unknown key error on line 2, but got %v", err)
+	}
+}
+
+func TestConfigParser(t *testing.T) {
+	c := Config{DefaultZone: "Z", CenturyPivot: 1929, AllowedLetters: []string{"Z", "A"}}
+	p, err := c.Parser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := p.Parse("271337JAN29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Year() != 1929 || d.ZoneLetter() != "Z" {
+		t.Errorf("Expected 271337ZJAN29 in 1929, but got %s in %d", d, d.Year())
+	}
+	if _, err := p.Parse("271337BJAN29"); !errors.Is(err, ErrInvalidTimeZoneLetter) {
+		t.Errorf("Expected %v for a letter not allowed, but got %v", ErrInvalidTimeZoneLetter, err)
+	}
+	f, err := c.Formatter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := f.Format(d); s != "271337ZJAN29" {
+		t.Errorf("Expected \"271337ZJAN29\", but got \"%s\"", s)
+	}
+	if _, err := (Config{AllowedLetters: []string{"Ö"}}).Parser(); !errors.Is(err, ErrInvalidTimeZoneLetter) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidTimeZoneLetter, err)
+	}
+	if _, err := (Config{DefaultZone: "Ö"}).Parser(); !errors.Is(err, ErrInvalidTimeZoneLetter) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidTimeZoneLetter, err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("DTG_DEFAULT_ZONE", "z")
+	t.Setenv("DTG_STRICT", "false")
+	t.Setenv("DTG_LOCALE", "sv")
+	t.Setenv("DTG_ALLOWED_LETTERS", "Z, A")
+	t.Setenv("DTG_CENTURY_PIVOT", "")
+	c, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.DefaultZone != "Z" || c.Strict || c.CenturyPivot != 0 || c.Locale != "sv" || len(c.AllowedLetters) != 2 || c.AllowedLetters[1] != "A" {
+		t.Errorf("Unexpected config from the environment: %+v", c)
+	}
+	p, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, err := p.Parse("151230DEC19"); err != nil || d.String() != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\" (%v)", d, err)
+	}
+	t.Setenv("DTG_STRICT", "maybe")
+	if _, err := FromEnv(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidConfig, err)
+	}
+}
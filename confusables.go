@@ -0,0 +1,79 @@
+package dtg
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps Cyrillic and Greek letters that look like the Latin
+// letters of a DTG (zone letters and month abbreviations) to those letters.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'У': 'Y', 'Ѕ': 'S', 'І': 'I',
+	'Ј': 'J', 'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y',
+	'х': 'x', 'ѕ': 's', 'і': 'i', 'ј': 'j',
+	// Greek
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o',
+}
+
+// Normalization is a character replaced by Normalize.
+type Normalization struct {
+	// Offset is the byte offset of Original in the input.
+	Offset int
+	// Original is the character as written, Replacement what it became
+	// (empty if it was removed).
+	Original, Replacement string
+}
+
+// Normalize prepares pasted text for strict parsing: every character is
+// NFKC normalized (turning e.g. full-width digits and letters into ASCII),
+// Cyrillic and Greek look-alikes of Latin letters are replaced by the Latin
+// letter, any Unicode space (such as a non-breaking space) becomes an
+// ordinary space and zero-width characters are removed. It returns the
+// normalized text and what was changed, so the changes can be shown to
+// the operator rather than silently accepted.
+func Normalize(s string) (string, []Normalization) {
+	var b strings.Builder
+	var changes []Normalization
+	for offset, r := range s {
+		original := string(r)
+		replacement := original
+		switch {
+		case r < 0x80:
+		case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\u2060' || r == '\ufeff':
+			replacement = ""
+		case unicode.IsSpace(r):
+			replacement = " "
+		default:
+			if c, ok := confusables[r]; ok {
+				replacement = string(c)
+			} else {
+				replacement = norm.NFKC.String(original)
+			}
+		}
+		if replacement != original {
+			changes = append(changes, Normalization{Offset: offset, Original: original, Replacement: replacement})
+		}
+		b.WriteString(replacement)
+	}
+	return b.String(), changes
+}
+
+// ParseNormalized parses s with Parse after passing it through Normalize
+// and returns the normalizations made, also when parsing fails.
+func ParseNormalized(s string) (DTG, []Normalization, error) {
+	return (*Parser)(nil).ParseNormalized(s)
+}
+
+// ParseNormalized is like the package level ParseNormalized, but uses the
+// Parser's zone table.
+func (p *Parser) ParseNormalized(s string) (DTG, []Normalization, error) {
+	normalized, changes := Normalize(s)
+	dtg, err := p.Parse(normalized)
+	return dtg, changes, err
+}
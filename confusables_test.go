@@ -0,0 +1,50 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		changes  int
+	}{
+		{"151230ZDEC19", "151230ZDEC19", 0},
+		{"１５１２３０ＺＤＥＣ１９", "151230ZDEC19", 12},
+		{"151230Z\u00a0DEC\u00a019", "151230Z DEC 19", 2},
+		{"151230\u200bZD\u0415\u042119", "151230ZDEC19", 3},
+		{"151230ΖΟCT19", "151230ZOCT19", 2},
+		{"Hälsning", "Hälsning", 0},
+	}
+	for _, test := range tests {
+		got, changes := Normalize(test.input)
+		if got != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, got)
+		}
+		if len(changes) != test.changes {
+			t.Errorf("Expected %d normalizations of \"%s\", but got %d: %+v", test.changes, test.input, len(changes), changes)
+		}
+	}
+	_, changes := Normalize("1\u00a0\u0421")
+	expected := []Normalization{{1, "\u00a0", " "}, {3, "\u0421", "C"}}
+	if len(changes) != len(expected) || changes[0] != expected[0] || changes[1] != expected[1] {
+		t.Errorf("Expected %+v, but got %+v", expected, changes)
+	}
+}
+
+func TestParseNormalized(t *testing.T) {
+	dtg, changes, err := ParseNormalized("１５１２３０ＺD\u0415\u0421１９")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dtg.String() != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\"", dtg)
+	}
+	if len(changes) != 11 {
+		t.Errorf("Expected 11 normalizations, but got %d: %+v", len(changes), changes)
+	}
+	if _, err := Parse("１５１２３０ＺＤＥＣ１９"); err == nil {
+		t.Errorf("Expected Parse to stay strict about full-width digits")
+	}
+}
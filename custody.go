@@ -0,0 +1,105 @@
+package dtg
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCustody   error = errors.New("invalid custody statement (must be DTG time hash signature)")
+	ErrCustodySignature error = errors.New("custody statement signature or document does not verify")
+)
+
+// custodyContext is signed with every custody statement so that the
+// signatures cannot be confused with those of other protocols using the
+// same key.
+const custodyContext string = "dtg custody v1"
+
+// Custody is a chain of custody statement for evidentiary logging: the
+// holder of an ed25519 key states that the document with the SHA-256 Hash
+// was processed, e.g. received by an automated system, at the DTG. The
+// signature covers the hash and the instant of the DTG to the nanosecond,
+// the zone of the DTG is how it is written.
+type Custody struct {
+	Hash      [sha256.Size]byte
+	DTG       DTG
+	Signature []byte
+}
+
+// SignCustody returns the Custody statement of document processed at at,
+// signed with key.
+func SignCustody(key ed25519.PrivateKey, document []byte, at DTG) Custody {
+	return SignCustodyHash(key, sha256.Sum256(document), at)
+}
+
+// SignCustodyHash is like SignCustody for a document already hashed with
+// SHA-256.
+func SignCustodyHash(key ed25519.PrivateKey, hash [sha256.Size]byte, at DTG) Custody {
+	c := Custody{Hash: hash, DTG: at}
+	c.Signature = ed25519.Sign(key, c.message())
+	return c
+}
+
+// message returns what the signature of c covers.
+func (c Custody) message() []byte {
+	return []byte(custodyContext + " " + c.DTG.Time.UTC().Format(time.RFC3339Nano) + " " + hex.EncodeToString(c.Hash[:]))
+}
+
+// Verify returns nil if c is signed by the key of public, otherwise
+// ErrCustodySignature.
+func (c Custody) Verify(public ed25519.PublicKey) error {
+	if len(public) != ed25519.PublicKeySize || !ed25519.Verify(public, c.message(), c.Signature) {
+		return ErrCustodySignature
+	}
+	return nil
+}
+
+// VerifyDocument is like Verify, but also checks that c is a statement of
+// document.
+func (c Custody) VerifyDocument(public ed25519.PublicKey, document []byte) error {
+	if sha256.Sum256(document) != c.Hash {
+		return ErrCustodySignature
+	}
+	return c.Verify(public)
+}
+
+// String returns c on one line for a log, the DTG, its instant in RFC 3339
+// (UTC), the hex encoded hash and the base64 encoded signature, e.g.
+// "151230ZDEC19 2019-12-15T12:30:05.1Z 9f86...0a08 Zm9v...".
+func (c Custody) String() string {
+	return c.DTG.String() + " " + c.DTG.Time.UTC().Format(time.RFC3339Nano) + " " +
+		hex.EncodeToString(c.Hash[:]) + " " + base64.StdEncoding.EncodeToString(c.Signature)
+}
+
+// ParseCustody parses a Custody statement in the form returned by String.
+// It does not verify the signature, see Verify.
+func ParseCustody(s string) (Custody, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 4 {
+		return Custody{}, ErrInvalidCustody
+	}
+	d, err := Parse(fields[0])
+	if err != nil {
+		return Custody{}, ErrInvalidCustody
+	}
+	t, err := time.Parse(time.RFC3339Nano, fields[1])
+	if err != nil || !t.Truncate(time.Minute).Equal(d.Time) {
+		return Custody{}, ErrInvalidCustody
+	}
+	hash, err := hex.DecodeString(fields[2])
+	if err != nil || len(hash) != sha256.Size {
+		return Custody{}, ErrInvalidCustody
+	}
+	c := Custody{DTG: DTG{Time: t.In(d.Location())}}
+	copy(c.Hash[:], hash)
+	c.Signature, err = base64.StdEncoding.DecodeString(fields[3])
+	if err != nil || len(c.Signature) != ed25519.SignatureSize {
+		return Custody{}, ErrInvalidCustody
+	}
+	return c, nil
+}
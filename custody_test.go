@@ -0,0 +1,55 @@
+package dtg
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+	"time"
+)
+
+func TestCustody(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(bytes.NewReader(make([]byte, ed25519.SeedSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := []byte("151230ZDEC19 FM HQ TO ALL UNITS")
+	received := DTG{Time: time.Date(2019, time.December, 15, 13, 30, 5, 100000000, time.FixedZone("+0100", 3600))}
+	c := SignCustody(private, document, received)
+	if err := c.VerifyDocument(public, document); err != nil {
+		t.Fatal(err)
+	}
+	s := c.String()
+	if expected := "151330ADEC19 2019-12-15T12:30:05.1Z "; s[:len(expected)] != expected {
+		t.Errorf("Expected \"%s...\", but got \"%s\"", expected, s)
+	}
+	parsed, err := ParseCustody(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.DTG.Equal(received.Time) || parsed.DTG.ZoneLetter() != "A" || parsed.String() != s {
+		t.Errorf("Expected \"%s\", but got \"%s\"", s, parsed)
+	}
+	if err := parsed.VerifyDocument(public, document); err != nil {
+		t.Fatal(err)
+	}
+	tampered := parsed
+	tampered.DTG = DTG{Time: received.Add(time.Second)}
+	if err := tampered.Verify(public); err != ErrCustodySignature {
+		t.Errorf("Expected %v for another DTG, but got %v", ErrCustodySignature, err)
+	}
+	if err := parsed.VerifyDocument(public, []byte("another document")); err != ErrCustodySignature {
+		t.Errorf("Expected %v for another document, but got %v", ErrCustodySignature, err)
+	}
+	other, _, err := ed25519.GenerateKey(bytes.NewReader(bytes.Repeat([]byte{1}, ed25519.SeedSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := parsed.Verify(other); err != ErrCustodySignature {
+		t.Errorf("Expected %v for another key, but got %v", ErrCustodySignature, err)
+	}
+	for _, invalid := range []string{"", s[:len(s)-4], "151331ADEC19" + s[12:], "151330ADEC19 2019-12-15T12:30:05.1Z 00 AA=="} {
+		if _, err := ParseCustody(invalid); err != ErrInvalidCustody {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrInvalidCustody, invalid, err)
+		}
+	}
+}
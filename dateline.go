@@ -0,0 +1,78 @@
+package dtg
+
+import (
+	"strings"
+	"time"
+)
+
+// ZuluDay returns the day of the month of dtg in UTC (zone Z), which is
+// what the two first digits of the DTG would be when written in Zulu.
+func ZuluDay(dtg DTG) int {
+	return dtg.Time.UTC().Day()
+}
+
+// DayIn returns the day of the month of dtg when written with zone letter,
+// e.g. whether 151230Z is still the 15th in M zone (it is not, it is
+// 160030M). J is the local time zone.
+func DayIn(dtg DTG, letter string) (int, error) {
+	t, err := timeIn(dtg, letter)
+	if err != nil {
+		return 0, err
+	}
+	return t.Day(), nil
+}
+
+// DateShift reports whether writing dtg with zone letter moves it to
+// another calendar date: -1 if it becomes the day before, 1 the day after
+// and 0 if the date stays the same. As zone offsets are at most 12 hours
+// (the date line lies between M and Y), the result is never more than one
+// day in either direction for the default zones.
+func DateShift(dtg DTG, letter string) (int, error) {
+	t, err := timeIn(dtg, letter)
+	if err != nil {
+		return 0, err
+	}
+	return calendarDays(dtg.Time, t), nil
+}
+
+// CrossesDateLine reports whether converting from one zone letter to
+// another crosses the International Date Line, i.e. the offsets are more
+// than 12 hours apart so the date changes even though the zones are
+// neighbours on the globe (e.g. M and Y).
+func CrossesDateLine(from, to string) (bool, error) {
+	now := time.Now()
+	f, err := timeIn(DTG{Time: now}, from)
+	if err != nil {
+		return false, err
+	}
+	t, err := timeIn(DTG{Time: now}, to)
+	if err != nil {
+		return false, err
+	}
+	_, fromOffset := f.Zone()
+	_, toOffset := t.Zone()
+	difference := fromOffset - toOffset
+	return difference > 12*3600 || difference < -12*3600, nil
+}
+
+// timeIn returns dtg.Time in the zone of letter in the default zone table.
+// J is the local offset in effect at dtg.
+func timeIn(dtg DTG, letter string) (time.Time, error) {
+	local := dtg.Time.In(time.Local)
+	loc, err := defaultZones.location(strings.ToUpper(strings.TrimSpace(letter)),
+		local.Format(dayLayout), local.Format(hourLayout), local.Format(minuteLayout), local.Format(monthLayout), local.Format(yearLayout))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return dtg.Time.In(loc), nil
+}
+
+// calendarDays returns the number of calendar days from the date of a (in
+// its location) to the date of b (in its location).
+func calendarDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	da := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	db := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
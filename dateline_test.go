@@ -0,0 +1,68 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestDayIn(t *testing.T) {
+	tests := []struct {
+		dtg    string
+		letter string
+		zulu   int
+		day    int
+		shift  int
+	}{
+		{"151230ZDEC19", "M", 15, 16, 1},
+		{"151230ZDEC19", "Y", 15, 15, 0},
+		{"151130ZDEC19", "m", 15, 15, 0},
+		{"150030BDEC19", "Z", 14, 14, -1},
+		{"150030BDEC19", "B", 14, 15, 0},
+		{"312330ZDEC19", "A", 31, 1, 1},
+		{"010030MJAN20", "Y", 31, 31, -1},
+	}
+	for _, test := range tests {
+		d := mustParse(t, test.dtg)
+		if got := ZuluDay(d); got != test.zulu {
+			t.Errorf("Expected Zulu day of %s to be %d, but got %d", test.dtg, test.zulu, got)
+		}
+		day, err := DayIn(d, test.letter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if day != test.day {
+			t.Errorf("Expected %s to be day %d in %s, but got %d", test.dtg, test.day, test.letter, day)
+		}
+		shift, err := DateShift(d, test.letter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shift != test.shift {
+			t.Errorf("Expected %s to shift %d days in %s, but got %d", test.dtg, test.shift, test.letter, shift)
+		}
+	}
+	if _, err := DayIn(mustParse(t, "151230ZDEC19"), "Ö"); err != ErrInvalidTimeZoneLetter {
+		t.Errorf("Expected ErrInvalidTimeZoneLetter, but got %v", err)
+	}
+}
+
+func TestCrossesDateLine(t *testing.T) {
+	tests := []struct {
+		from, to string
+		expected bool
+	}{
+		{"M", "Y", true},
+		{"L", "X", true},
+		{"Z", "M", false},
+		{"Y", "Z", false},
+		{"A", "B", false},
+	}
+	for _, test := range tests {
+		got, err := CrossesDateLine(test.from, test.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.expected {
+			t.Errorf("Expected %s to %s crossing the date line to be %v", test.from, test.to, test.expected)
+		}
+	}
+}
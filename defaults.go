@@ -0,0 +1,94 @@
+package dtg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultCenturyPivot is the first year of the hundred years two digit
+// years are resolved to unless Parser.CenturyPivot says otherwise: 69-99
+// are 1969-1999 and 00-68 are 2000-2068, as with the 06 layout of
+// time.Parse.
+const DefaultCenturyPivot int = 1969
+
+// Defaults are the inference defaults in effect, as returned by
+// DefaultsSnapshot.
+type Defaults struct {
+	// Reference is the reference clock reading, the time month and year
+	// (when omitted) and the offset of J are inferred from.
+	Reference time.Time
+	// Location is the local time zone of J.
+	Location *time.Location
+	// LocalLetter is the letter J currently resolves to (J when the local
+	// offset has no letter), the default zone if J is pinned in the zone
+	// table.
+	LocalLetter string
+	// CenturyPivot is the first year two digit years are resolved to, see
+	// DefaultCenturyPivot.
+	CenturyPivot int
+	// Designators are the designators of the zone table.
+	Designators []string
+	// LetterPolicy is how String picks the letter of an offset that is not
+	// a whole hour, PolicyTruncate.
+	LetterPolicy Policy
+	// Strict, Lenient and Resolution are those of the Parser.
+	Strict     bool
+	Lenient    bool
+	Resolution Resolution
+}
+
+var (
+	policyNames     = []string{"exact", "nearest", "truncate"}
+	resolutionNames = []string{"current", "nearest", "past", "future"}
+)
+
+// DefaultsSnapshot returns the inference defaults in effect right now, so
+// that long running services can log their DTG configuration at startup
+// for audits.
+func DefaultsSnapshot() Defaults {
+	return (*Parser)(nil).DefaultsSnapshot()
+}
+
+// DefaultsSnapshot is like the package level DefaultsSnapshot, but returns
+// the settings in effect for p, e.g. its Location, clock and zone table.
+func (p *Parser) DefaultsSnapshot() Defaults {
+	now := p.reference()
+	d := Defaults{
+		Reference:    now,
+		Location:     now.Location(),
+		LocalLetter:  "J",
+		CenturyPivot: p.centuryPivot(),
+		Designators:  p.zones().Designators(),
+		LetterPolicy: PolicyTruncate,
+		Strict:       p.strict(),
+		Lenient:      p != nil && p.Lenient,
+		Resolution:   p.resolution(),
+	}
+	if loc, err := p.location(now, "J"); err == nil {
+		_, offset := now.In(loc).Zone()
+		if letter, err := p.zones().letterForOffset(offset, d.LetterPolicy); err == nil {
+			d.LocalLetter = letter
+		}
+	}
+	return d
+}
+
+// String returns the defaults on one line as key=value pairs suitable for
+// a log, e.g. "reference=2019-12-15T12:30:00+01:00 location=Local
+// offset=+0100 local_letter=A century_pivot=1969 designators=A,B,...
+// letter_policy=truncate strict=false lenient=false resolution=current".
+func (d Defaults) String() string {
+	_, offset := d.Reference.In(d.Location).Zone()
+	return fmt.Sprintf("reference=%s location=%s offset=%s local_letter=%s century_pivot=%d designators=%s letter_policy=%s strict=%t lenient=%t resolution=%s",
+		d.Reference.Format(time.RFC3339), d.Location, numericTimeZone(offset), d.LocalLetter, d.CenturyPivot, strings.Join(d.Designators, ","),
+		enumName(policyNames, int(d.LetterPolicy)), d.Strict, d.Lenient, enumName(resolutionNames, int(d.Resolution)))
+}
+
+// enumName returns names[i], or i if it is out of range.
+func enumName(names []string, i int) string {
+	if i < 0 || i >= len(names) {
+		return fmt.Sprint(i)
+	}
+	return names[i]
+}
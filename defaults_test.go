@@ -0,0 +1,49 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultsSnapshot(t *testing.T) {
+	d := DefaultsSnapshot()
+	if time.Since(d.Reference) > time.Minute || d.Location != time.Local || d.CenturyPivot != 1969 || len(d.Designators) != 25 {
+		t.Errorf("Unexpected defaults %+v", d)
+	}
+	if d.LocalLetter != (DTG{Time: time.Now()}).ZoneLetter() {
+		t.Errorf("Expected local letter \"%s\", but got \"%s\"", (DTG{Time: time.Now()}).ZoneLetter(), d.LocalLetter)
+	}
+	d = Defaults{
+		Reference:    time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC),
+		Location:     time.FixedZone("CET", 3600),
+		LocalLetter:  "A",
+		CenturyPivot: 1969,
+		Designators:  []string{"A", "Z"},
+		LetterPolicy: PolicyTruncate,
+		Lenient:      true,
+		Resolution:   ResolvePast,
+	}
+	expected := "reference=2019-12-15T12:30:00Z location=CET offset=+0100 local_letter=A century_pivot=1969 designators=A,Z letter_policy=truncate strict=false lenient=true resolution=past"
+	if s := d.String(); s != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, s)
+	}
+	reference := time.Date(2019, 7, 15, 12, 30, 0, 0, time.UTC)
+	zones := DefaultZoneTable().Clone()
+	zones.Set("J", 3*3600)
+	p := &Parser{Zones: zones, Location: time.FixedZone("", 5*3600+1800), Now: func() time.Time { return reference }, Strict: true, CenturyPivot: 1929, Resolution: ResolveNearest}
+	d = p.DefaultsSnapshot()
+	if !d.Reference.Equal(reference) || d.Location != p.Location || d.LocalLetter != "C" || d.CenturyPivot != 1929 ||
+		!d.Strict || d.Lenient || d.Resolution != ResolveNearest || d.LetterPolicy != PolicyTruncate || len(d.Designators) != 26 {
+		t.Errorf("Unexpected defaults of the Parser %+v", d)
+	}
+	d = (&Parser{Location: time.FixedZone("", 5*3600+1800)}).DefaultsSnapshot()
+	if d.LocalLetter != "E" {
+		t.Errorf("Expected local letter \"E\" for +0530, but got \"%s\"", d.LocalLetter)
+	}
+	if y := mustParse(t, "010000ZJAN69").Year(); y != DefaultCenturyPivot {
+		t.Errorf("Expected %d, but got %d", DefaultCenturyPivot, y)
+	}
+	if y := mustParse(t, "010000ZJAN68").Year(); y != 2068 {
+		t.Errorf("Expected 2068, but got %d", y)
+	}
+}
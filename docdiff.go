@@ -0,0 +1,101 @@
+package dtg
+
+import (
+	"time"
+)
+
+// ChangeKind is the kind of a DocumentChange.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota
+	Removed
+	Changed
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// DocumentChange is a difference between the DTGs of two documents. Old is
+// the zero Match for Added, New is the zero Match for Removed. Delta is how
+// much a Changed DTG moved (New minus Old).
+type DocumentChange struct {
+	Kind  ChangeKind
+	Old   Match
+	New   Match
+	Delta time.Duration
+}
+
+// CompareDocuments reports how the DTGs of document b differ from those of
+// document a, e.g. between two versions of a FRAGO. The DTGs of each
+// document (as found by FindAll) are compared in order of appearance as
+// instants, so a DTG rewritten in another zone or form is unchanged. Where
+// DTGs were both removed and added at the same place, they are paired up in
+// order and reported as Changed.
+func CompareDocuments(a, b string) []DocumentChange {
+	return compareMatches(FindAll(a), FindAll(b))
+}
+
+func compareMatches(a, b []Match) []DocumentChange {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i].DTG.Time.Equal(b[j].DTG.Time) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var changes []DocumentChange
+	var removed, added []Match
+	flush := func() {
+		n := len(removed)
+		if len(added) < n {
+			n = len(added)
+		}
+		for k := 0; k < n; k++ {
+			changes = append(changes, DocumentChange{Kind: Changed, Old: removed[k], New: added[k], Delta: added[k].DTG.Time.Sub(removed[k].DTG.Time)})
+		}
+		for _, m := range removed[n:] {
+			changes = append(changes, DocumentChange{Kind: Removed, Old: m})
+		}
+		for _, m := range added[n:] {
+			changes = append(changes, DocumentChange{Kind: Added, New: m})
+		}
+		removed, added = removed[:0], added[:0]
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i].DTG.Time.Equal(b[j].DTG.Time):
+			flush()
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	flush()
+	return changes
+}
@@ -0,0 +1,54 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareDocuments(t *testing.T) {
+	a := "FRAGO 1\nH-HOUR 160600ZDEC19\nPL ALPHA NLT 160730ZDEC19\nPL BRAVO NLT 161000ZDEC19\nRESUPPLY 170600ZDEC19\n"
+	b := "FRAGO 2\nH-HOUR 161200ZDEC19\nPL ALPHA NLT 161330ZDEC19\nPL BRAVO NLT 161100ADEC19\nRESUPPLY 170600Z DEC 19\nENDEX 201200ZDEC19\n"
+	changes := CompareDocuments(a, b)
+	expected := []struct {
+		kind  ChangeKind
+		old   string
+		new   string
+		delta time.Duration
+	}{
+		{Changed, `160600ZDEC19`, `161200ZDEC19`, 6 * time.Hour},
+		{Changed, `160730ZDEC19`, `161330ZDEC19`, 6 * time.Hour},
+		{Added, ``, `201200ZDEC19`, 0},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, but got %d: %v", len(expected), len(changes), changes)
+	}
+	for i, c := range changes {
+		e := expected[i]
+		if c.Kind != e.kind || c.Delta != e.delta {
+			t.Errorf("Expected change %d to be %s by %s, but got %s by %s", i, e.kind, e.delta, c.Kind, c.Delta)
+		}
+		if e.old != "" && c.Old.DTG.String() != e.old {
+			t.Errorf("Expected old DTG %s, but got %s", e.old, c.Old.DTG)
+		}
+		if e.new != "" && c.New.DTG.String() != e.new {
+			t.Errorf("Expected new DTG %s, but got %s", e.new, c.New.DTG)
+		}
+	}
+	removed := CompareDocuments(b, "H-HOUR 161200ZDEC19")
+	if len(removed) != 4 {
+		t.Fatalf("Expected 4 removed DTGs, but got %v", removed)
+	}
+	for _, c := range removed {
+		if c.Kind != Removed || c.New.Text != "" {
+			t.Errorf("Expected a removal, but got %s %v", c.Kind, c)
+		}
+	}
+	if changes := CompareDocuments(a, a); len(changes) != 0 {
+		t.Errorf("Expected no changes comparing a document to itself, but got %v", changes)
+	}
+	for k, s := range map[ChangeKind]string{Added: "added", Removed: "removed", Changed: "changed", ChangeKind(42): "unknown"} {
+		if k.String() != s {
+			t.Errorf("Expected \"%s\", but got \"%s\"", s, k)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrEntryMismatch error = errors.New("DTG entries do not agree")
+	ErrSamePrincipal error = errors.New("second DTG entry must be made by another principal")
+)
+
+// Entry is a DTG as entered by a principal, e.g. an operator's call sign.
+type Entry struct {
+	Principal string
+	DTG       string
+}
+
+// MismatchError reports two entries of a critical DTG that do not agree to
+// the minute. It matches ErrEntryMismatch with errors.Is.
+type MismatchError struct {
+	First, Second       Entry
+	FirstDTG, SecondDTG DTG
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%v: %s (%s) by %q, %s (%s) by %q", ErrEntryMismatch,
+		e.First.DTG, e.FirstDTG.Zulu(), e.First.Principal, e.Second.DTG, e.SecondDTG.Zulu(), e.Second.Principal)
+}
+
+func (e *MismatchError) Unwrap() error {
+	return ErrEntryMismatch
+}
+
+// DoubleCheck parses a critical DTG, e.g. an execution time, entered twice
+// and returns it only if both entries are the same minute, otherwise a
+// *MismatchError, as in the manual double-check procedure. The entries may
+// be written in different zones, the DTG of the first entry is returned.
+func DoubleCheck(first, second Entry) (DTG, error) {
+	return (*Parser)(nil).DoubleCheck(first, second)
+}
+
+// DoubleCheck is like the package level DoubleCheck, but parses the entries
+// with the Parser.
+func (p *Parser) DoubleCheck(first, second Entry) (DTG, error) {
+	a, err := p.Parse(first.DTG)
+	if err != nil {
+		return DTG{}, err
+	}
+	b, err := p.Parse(second.DTG)
+	if err != nil {
+		return DTG{}, err
+	}
+	if !a.Time.Truncate(time.Minute).Equal(b.Time.Truncate(time.Minute)) {
+		return DTG{}, &MismatchError{First: first, Second: second, FirstDTG: a, SecondDTG: b}
+	}
+	return a, nil
+}
+
+// TwoPersonCheck collects the two entries of a critical DTG one at a time,
+// e.g. from two terminals, and releases the DTG once they agree, see
+// DoubleCheck. The zero value is ready to use and accepts both entries from
+// the same principal. A TwoPersonCheck is safe for concurrent use.
+type TwoPersonCheck struct {
+	// Parser parses the entries, the package level functions when nil.
+	Parser *Parser
+	// Distinct requires the second entry to be made by another principal
+	// than the first.
+	Distinct bool
+	mu       sync.Mutex
+	first    *Entry
+}
+
+// Enter records an entry. The first entry is only parsed and Enter returns
+// false. The second entry is double-checked against the first and Enter
+// returns the DTG and true if they agree. A mismatch discards both entries
+// so that the DTG is entered twice again. An entry that does not parse, or
+// one by the principal of the first when Distinct (ErrSamePrincipal), is not
+// recorded.
+func (c *TwoPersonCheck) Enter(principal, s string) (DTG, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := Entry{Principal: principal, DTG: s}
+	if c.first == nil {
+		if _, err := c.Parser.Parse(s); err != nil {
+			return DTG{}, false, err
+		}
+		c.first = &entry
+		return DTG{}, false, nil
+	}
+	if c.Distinct && principal == c.first.Principal {
+		return DTG{}, false, ErrSamePrincipal
+	}
+	d, err := c.Parser.DoubleCheck(*c.first, entry)
+	if err != nil {
+		var mismatch *MismatchError
+		if errors.As(err, &mismatch) {
+			c.first = nil
+		}
+		return DTG{}, false, err
+	}
+	c.first = nil
+	return d, true, nil
+}
+
+// Pending returns the first entry while waiting for the second.
+func (c *TwoPersonCheck) Pending() (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.first == nil {
+		return Entry{}, false
+	}
+	return *c.first, true
+}
+
+// Reset discards a pending first entry.
+func (c *TwoPersonCheck) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.first = nil
+}
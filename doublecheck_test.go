@@ -0,0 +1,63 @@
+package dtg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDoubleCheck(t *testing.T) {
+	d, err := DoubleCheck(Entry{"alice", "151230ZDEC19"}, Entry{"bob", "151330ADEC19"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.String() != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\"", d)
+	}
+	_, err = DoubleCheck(Entry{"alice", "151230ZDEC19"}, Entry{"bob", "151231ZDEC19"})
+	var mismatch *MismatchError
+	if !errors.Is(err, ErrEntryMismatch) || !errors.As(err, &mismatch) || mismatch.Second.Principal != "bob" || mismatch.SecondDTG.String() != "151231ZDEC19" {
+		t.Errorf("Expected a mismatch, but got %v", err)
+	}
+	expected := `DTG entries do not agree: 151230ZDEC19 (151230ZDEC19) by "alice", 151231ZDEC19 (151231ZDEC19) by "bob"`
+	if err != nil && err.Error() != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, err)
+	}
+	if _, err := DoubleCheck(Entry{"alice", "151230ZDEC19"}, Entry{"bob", "1512"}); !errors.Is(err, ErrInvalidDTG) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidDTG, err)
+	}
+}
+
+func TestTwoPersonCheck(t *testing.T) {
+	c := TwoPersonCheck{Distinct: true}
+	if _, ok, err := c.Enter("alice", "441230ZDEC19"); ok || err == nil {
+		t.Errorf("Expected an invalid first entry, but got %v, %v", ok, err)
+	}
+	if _, ok, err := c.Enter("alice", "151230ZDEC19"); ok || err != nil {
+		t.Errorf("Expected a pending first entry, but got %v, %v", ok, err)
+	}
+	if _, _, err := c.Enter("alice", "151230ZDEC19"); err != ErrSamePrincipal {
+		t.Errorf("Expected %v, but got %v", ErrSamePrincipal, err)
+	}
+	if first, ok := c.Pending(); !ok || first.Principal != "alice" {
+		t.Errorf("Expected alice pending, but got %v, %v", first, ok)
+	}
+	if _, ok, err := c.Enter("bob", "151231ZDEC19"); ok || !errors.Is(err, ErrEntryMismatch) {
+		t.Errorf("Expected %v, but got %v, %v", ErrEntryMismatch, ok, err)
+	}
+	if _, ok := c.Pending(); ok {
+		t.Error("Expected a mismatch to discard the first entry")
+	}
+	c.Enter("bob", "151231ZDEC19")
+	d, ok, err := c.Enter("alice", "151231ZDEC19")
+	if !ok || err != nil || d.String() != "151231ZDEC19" {
+		t.Errorf("Expected \"151231ZDEC19\", but got \"%s\" (%v, %v)", d, ok, err)
+	}
+	if _, ok := c.Pending(); ok {
+		t.Error("Expected a released DTG to clear the entries")
+	}
+	c.Enter("bob", "151231ZDEC19")
+	c.Reset()
+	if _, ok := c.Pending(); ok {
+		t.Error("Expected Reset to discard the first entry")
+	}
+}
@@ -28,15 +28,21 @@ package dtg
 
 import (
 	"errors"
-	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
 )
 
+// MonthPattern is the month group of DtgRegexp and the other regular
+// expressions of DTGs, also for patterns of other packages: the English month names (full names first, so that they are
+// not matched as their abbreviation) and abbreviations, and the Swedish MAJ
+// and OKT.
+const MonthPattern string = `(JANUARY|FEBRUARY|MARCH|APRIL|JUNE|JULY|AUGUST|SEPTEMBER|OCTOBER|NOVEMBER|DECEMBER|JAN|FEB|MAR|APR|MAY|MAJ|JUN|JUL|AUG|SEP|OCT|OKT|NOV|DEC)`
+
 var (
-	DtgRegexp                *regexp.Regexp = regexp.MustCompile(`^([0-9]{2})([0-9]{2})([0-9]{2})([A-Z]{0,1})(JAN|FEB|MAR|APR|MAY|MAJ|JUN|JUL|AUG|SEP|OCT|OKT|NOV|DEC){0,1}([0-9]{2}){0,1}$`)
+	DtgRegexp                *regexp.Regexp = regexp.MustCompile(`^([0-9]{2})([0-9]{2})([0-9]{2})((?:[A-Z]\*{0,1}){0,1})` + MonthPattern + `{0,1}([0-9]{4}|[0-9]{2}){0,1}$`)
 	ErrInvalidDTG            error          = errors.New("invalid DTG format (minimally ddHHMM to complete ddHHMMZmmmYY)")
 	ErrInvalidTimeZoneLetter error          = errors.New("invalid time zone letter")
 	ErrInvalidDtgVariadic    error          = errors.New("invalid DTG slice passed as variadic")
@@ -66,56 +72,111 @@ type DTG struct {
 	time.Time
 }
 
-// String returns a NATO ACP 121 Date Time Group of the DTG Time field.
+// String returns a NATO ACP 121 Date Time Group of the DTG Time field. Offsets
+// that are not a whole hour are truncated to the nearest hour towards UTC
+// and offsets beyond 12 hours are printed as the local time zone letter J.
 func (dtg DTG) String() string {
-	_, offset := dtg.Time.Zone()
-	hours := offset / (60 * 60)
-	letter := rune('J')
-	if hours == 0 {
-		letter = 'Z'
-	} else if hours >= 1 && hours <= 9 {
-		letter = 'A'
-		letter += rune(hours - 1)
-	} else if hours >= 10 && hours <= 12 {
-		letter = 'A'
-		letter += rune(hours)
-	} else if hours >= -12 && hours <= -1 {
-		letter = 'N'
-		letter -= rune(hours + 1)
+	return (*Formatter)(nil).Format(dtg)
+}
+
+// StringE is like String, but returns ErrNotWholeHour or ErrNoZoneLetter
+// instead of a truncated designator or J when the offset of dtg.Time has no
+// zone letter, e.g. +0545. See Formatter.Fallback for other fallbacks.
+func (dtg DTG) StringE() (string, error) {
+	return (&Formatter{Fallback: FallbackError}).FormatE(dtg)
+}
+
+// Zulu returns the DTG converted to UTC, so that String prints it with the
+// Z designator, e.g. 151230ZDEC19 for 151330ADEC19.
+func (dtg DTG) Zulu() DTG {
+	return DTG{Time: dtg.Time.UTC()}
+}
+
+// InLetter returns the DTG re-expressed in the zone of letter, e.g.
+// 150700RDEC19 for 151200ZDEC19 in R, so that String prints the letter.
+// The instant is the same. J converts to the local offset in effect at the
+// DTG, which String prints as its letter. (In is the In method of
+// time.Time.)
+func (dtg DTG) InLetter(letter string) (DTG, error) {
+	t, err := timeIn(dtg, letter)
+	if err != nil {
+		return DTG{}, err
 	}
-	return dtg.Time.Format(`021504`) + string(letter) + strings.ToUpper(dtg.Time.Format(`Jan06`))
+	return DTG{Time: t}, nil
+}
+
+// InLocation returns the DTG converted to loc, e.g. Europe/Stockholm, for
+// operators who think in local time rather than offsets, and the zone
+// letter that applies in loc at that instant (A in winter and B in summer
+// for Stockholm), as String would print it. (Local is the Local method of
+// time.Time.)
+func (dtg DTG) InLocation(loc *time.Location) (DTG, string) {
+	d := DTG{Time: dtg.Time.In(loc)}
+	return d, d.ZoneLetter()
+}
+
+// ZoneLetter returns the zone designator String() prints, e.g. Z or B. The
+// other components are the Day, Hour, Minute, Month and Year methods of
+// time.Time, which are in the zone of the DTG as well.
+func (dtg DTG) ZoneLetter() string {
+	return (*Formatter)(nil).designator(dtg)
 }
 
 // Parse transforms a NATO (ACP 121 Communication Instructions General) Date
 // Time Group into a time.Time object via the DTG struct. The String() function
 // of the DTG object reproduces a full Date Time Group from the time.Time object.
-func Parse(dtgString string) (dtg DTG, err error) {
-	dtgString = strings.ToUpper(strings.TrimSpace(dtgString))
-	matches := DtgRegexp.FindAllStringSubmatch(dtgString, 1)
-	if len(matches) != 1 || len(matches[0]) != 7 {
-		return dtg, ErrInvalidDTG
-	}
-	match := matches[0]
-	var numericTimeZone *time.Location
-	numericTimeZone, err = GetNumericTimeZone(match[dtgSubMatchTimeZone], match[dtgSubMatchDay], match[dtgSubMatchHour], match[dtgSubMatchMinute], match[dtgSubMatchMonth], match[dtgSubMatchYear])
-	if err != nil {
-		return dtg, err
-	}
-	if utf8.RuneCountInString(match[dtgSubMatchMonth]) < 3 {
-		match[dtgSubMatchMonth] = strings.ToUpper(time.Now().In(numericTimeZone).Format(monthLayout))
-	}
-	if utf8.RuneCountInString(match[dtgSubMatchYear]) < 2 {
-		match[dtgSubMatchYear] = time.Now().In(numericTimeZone).Format(yearLayout)
-	}
-	expandedDtg := match[dtgSubMatchDay] + match[dtgSubMatchHour] +
-		match[dtgSubMatchMinute] + numericTimeZone.String() +
-		match[dtgSubMatchMonth] + match[dtgSubMatchYear]
+//
+// An omitted month and year are those of the current time and J (or an
+// omitted designator) is the local time zone. Options such as
+// WithReferenceTime and WithLocation control these implicit inputs.
+//
+// The year is two digits (resolved as WithCenturyPivot describes) or four,
+// e.g. 271337ZJAN2029 of some national formats and long retention archives,
+// which is that year as written. The month is abbreviated or a full English
+// month name, e.g. 151200ZDECEMBER19. String() prints the two digit year and
+// the abbreviated month.
+//
+// Days that do not exist in the month are ErrNoSuchDay, e.g. 291200ZFEB23
+// (but not 291200ZFEB24). 29 February without a year is in the year of the
+// reference time and ErrNoSuchDay unless that is a leap year, use
+// WithResolution to pick the nearest, previous or next leap year instead.
+// Strict parsing rejects it as any DTG without a year.
+func Parse(dtgString string, opts ...Option) (dtg DTG, err error) {
+	return (*Parser)(nil).Parse(dtgString, opts...)
+}
 
-	dtg.Time, err = time.ParseInLocation(expandedDtgLayout, expandedDtg, numericTimeZone)
+// ParseInLocation is like Parse, but local DTGs (J or no designator, e.g.
+// 142339 and 142339J) are in loc, the location of the originator, instead of
+// time.Local, which is wrong on servers in UTC processing traffic from a unit
+// in another zone. It is Parse with WithLocation(loc).
+func ParseInLocation(dtgString string, loc *time.Location) (DTG, error) {
+	return (*Parser)(nil).ParseInLocation(dtgString, loc)
+}
+
+// ParseStrictNATO is like Parse, but accepts only fully qualified canonical
+// DTGs for message format compliance checking: upper case, with a
+// designator other than J and with month and year, e.g. 151230ZDEC19.
+// Anything else is ErrNotStrict (or ErrInvalidDTG). It is Parse with
+// WithStrict().
+func ParseStrictNATO(dtgString string) (DTG, error) {
+	return Parse(dtgString, WithStrict())
+}
+
+// ValidateStrictNATO is Validate with the rules of ParseStrictNATO.
+func ValidateStrictNATO(dtgString string) error {
+	_, err := ParseStrictNATO(dtgString)
+	return err
+}
+
+// MustParse is like Parse but panics if the DTG can not be parsed. It
+// simplifies initialization of variables holding DTGs, e.g. in tests and
+// configuration tables.
+func MustParse(dtgString string) DTG {
+	dtg, err := Parse(dtgString)
 	if err != nil {
-		return dtg, err
+		panic(`dtg: MustParse(` + strconv.Quote(dtgString) + `): ` + err.Error())
 	}
-	return dtg, nil
+	return dtg
 }
 
 // Return a time.Location (and error) with the numeric time zone representation
@@ -125,131 +186,107 @@ func Parse(dtgString string) (dtg DTG, err error) {
 // time.Time to use instead of time.Now() for the local time zone letter J (to
 // present a daylight saving - DST - compensated offset). There is a String()
 // function in time.Location to extract the numeric time zone as name is
-// non-exported.
+// non-exported. Letters other than J are looked up in the DefaultZoneTable.
 //
-// UTC-12: Y (e.g., Fiji)
-// UTC-11: X (American Samoa)
-// UTC-10: W (Honolulu, HI)
-// UTC-9: V (Juneau, AK)
-// UTC-8: U (PST, Los Angeles, CA)
-// UTC-7: T (MST, Denver, CO)
-// UTC-6: S (CST, Dallas, TX)
-// UTC-5: R (EST, New York, NY)
-// UTC-4: Q (Halifax, Nova Scotia)
-// UTC-3: P (Buenos Aires, Argentina)
-// UTC-2: O (Godthab, Greenland)
-// UTC-1: N (Azores)
-// UTC+-0: Z (Zulu time)
-// UTC+1: A (France)
-// UTC+2: B (Athens, Greece)
-// UTC+3: C (Arab Standard Time, Iraq, Bahrain, Kuwait, Saudi Arabia, Yemen, Qatar)
-// UTC+4: D (Used for Moscow, Russia, and Afghanistan, however, Afghanistan is technically +4:30 from UTC)
-// UTC+5: E (Pakistan, Kazakhstan, Tajikistan, Uzbekistan, and Turkmenistan)
-// UTC+6: F (Bangladesh)
-// UTC+7: G (Thailand)
-// UTC+8: H (Beijing, China)
-// UTC+9: I (Tokyo, Japan)
-// UTC+10: K (Brisbane, Australia)
-// UTC+11: L (Sydney, Australia)
-// UTC+12: M (Wellington, New Zealand)
+// The letters and their offsets are listed in the documentation of
+// DefaultZoneTable. An invalid letter is a *ParseError of the Letter wrapping
+// ErrInvalidTimeZoneLetter.
 func GetNumericTimeZone(dtgTimeZoneLetter string, dayHourMinuteMonthYear ...string) (*time.Location, error) {
-	dtgTimeZoneLetter = strings.ToUpper(strings.TrimSpace(dtgTimeZoneLetter))
-	if utf8.RuneCountInString(dtgTimeZoneLetter) > 1 {
-		return nil, ErrInvalidTimeZoneLetter
-	} else if utf8.RuneCountInString(dtgTimeZoneLetter) < 1 {
-		dtgTimeZoneLetter = "J"
+	at := newErrorAt(dtgTimeZoneLetter)
+	letter := strings.ToUpper(strings.TrimSpace(dtgTimeZoneLetter))
+	if utf8.RuneCountInString(letter) > 1 {
+		return nil, at.err("Letter", 0, utf8.RuneCountInString(letter), ErrInvalidTimeZoneLetter, "")
 	}
-	letter := int([]rune(dtgTimeZoneLetter)[0])
-	if letter < 'A' || letter > 'Z' {
-		return nil, ErrInvalidTimeZoneLetter
+	loc, err := defaultZones.location(letter, dayHourMinuteMonthYear...)
+	if errors.Is(err, ErrInvalidTimeZoneLetter) {
+		return nil, at.err("Letter", 0, utf8.RuneCountInString(letter), err, "")
 	}
-	if letter == 'J' {
-		var localTime time.Time
-		var err error
-		layout := dayLayout + hourLayout + minuteLayout + monthLayout + yearLayout
-		location := time.Now().Location()
-		switch len(dayHourMinuteMonthYear) {
-		case 0:
-			localTime = time.Now()
-		case 1:
-			remaining := time.Now().Format(hourLayout + minuteLayout + monthLayout + yearLayout)
-			localTime, err = time.ParseInLocation(layout, dayHourMinuteMonthYear[0]+remaining, location)
-			if err != nil {
-				// ddHHMM is mandatory
-				return nil, err
-			}
-		case 2:
-			remaining := time.Now().Format(minuteLayout + monthLayout + yearLayout)
-			localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear, "")+remaining, location)
+	return loc, err
+}
+
+// localTimeZone returns the numeric time zone of the local time zone letter
+// J. The optional dayHourMinuteMonthYear is parsed into a time.Time in the
+// local time zone (instead of time.Now()) to present the DST compensated
+// offset at that time, see GetNumericTimeZone.
+func localTimeZone(dayHourMinuteMonthYear ...string) (*time.Location, error) {
+	return localTimeZoneAt(time.Now(), dayHourMinuteMonthYear...)
+}
+
+// localTimeZoneAt is localTimeZone with now as the current time and its
+// location as the local time zone.
+func localTimeZoneAt(now time.Time, dayHourMinuteMonthYear ...string) (*time.Location, error) {
+	var localTime time.Time
+	var err error
+	layout := dayLayout + hourLayout + minuteLayout + monthLayout + yearLayout
+	location := now.Location()
+	switch len(dayHourMinuteMonthYear) {
+	case 0:
+		localTime = now
+	case 1:
+		remaining := now.Format(hourLayout + minuteLayout + monthLayout + yearLayout)
+		localTime, err = time.ParseInLocation(layout, dayHourMinuteMonthYear[0]+remaining, location)
+		if err != nil {
+			// ddHHMM is mandatory
+			return nil, err
+		}
+	case 2:
+		remaining := now.Format(minuteLayout + monthLayout + yearLayout)
+		localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear, "")+remaining, location)
+		if err != nil {
+			// ddHHMM is mandatory
+			return nil, err
+		}
+	case 3:
+		remaining := now.Format(monthLayout + yearLayout)
+		localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear, "")+remaining, location)
+		if err != nil {
+			// ddHHMM is mandatory
+			return nil, err
+		}
+	case 4:
+		if utf8.RuneCountInString(dayHourMinuteMonthYear[3]) < 3 {
+			remaining := now.Format(monthLayout + yearLayout)
+			localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear[:3], "")+remaining, location)
 			if err != nil {
-				// ddHHMM is mandatory
 				return nil, err
 			}
-		case 3:
-			remaining := time.Now().Format(monthLayout + yearLayout)
+		} else {
+			remaining := now.Format(yearLayout)
 			localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear, "")+remaining, location)
-			if err != nil {
-				// ddHHMM is mandatory
-				return nil, err
-			}
-		case 4:
-			if utf8.RuneCountInString(dayHourMinuteMonthYear[3]) < 3 {
-				remaining := time.Now().Format(monthLayout + yearLayout)
-				localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear[:3], "")+remaining, location)
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				remaining := time.Now().Format(yearLayout)
-				localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear, "")+remaining, location)
-				if err != nil {
-					return nil, err
-				}
-			}
-		case 5:
-			m := dayHourMinuteMonthYear[3]
-			y := dayHourMinuteMonthYear[4]
-			if utf8.RuneCountInString(m) < 3 {
-				m = time.Now().Format(monthLayout)
-			}
-			if utf8.RuneCountInString(y) < 2 {
-				y = time.Now().Format(yearLayout)
-			}
-			localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear[:3], "")+m+y, location)
 			if err != nil {
 				return nil, err
 			}
-		default:
-			return nil, ErrInvalidDtgVariadic
 		}
-		_, offset := localTime.Zone()
-		return time.FixedZone(localTime.Format(numericTimeZoneLayout), offset), nil
-	}
-	var hours int = 0
-	if letter == 'Z' {
-		// Zulu time.
-		hours = 0
-	} else if letter >= 'A' && letter <= 'I' {
-		// A to I are positive, A starts at +1.
-		hours = 1 + (letter - 'A')
-	} else if letter >= 'K' && letter <= 'M' {
-		// K, L, M are also positive, K starts at +10.
-		hours = 10 + (letter - 'K')
-	} else if letter >= 'N' && letter <= 'Y' {
-		// N to Y are negative, N starts at -1.
-		hours = -1 - (letter - 'N')
-	}
-	/* // Will never reach this...
-	 	 else {
-			return nil, ErrInvalidTimeZoneLetter
+	case 5:
+		m := dayHourMinuteMonthYear[3]
+		y := dayHourMinuteMonthYear[4]
+		if utf8.RuneCountInString(m) < 3 {
+			m = now.Format(monthLayout)
 		}
-	*/
-	return time.FixedZone(fmt.Sprintf("%+03d00", hours), hours*3600), nil
+		if utf8.RuneCountInString(y) < 2 {
+			y = now.Format(yearLayout)
+		}
+		localTime, err = time.ParseInLocation(layout, strings.Join(dayHourMinuteMonthYear[:3], "")+m+y, location)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrInvalidDtgVariadic
+	}
+	_, offset := localTime.Zone()
+	return time.FixedZone(localTime.Format(numericTimeZoneLayout), offset), nil
 }
 
 // Validate attempts to parse the DTG string, discards the DTG object and
 // returns error if parsing failed (invalid DTG) or nil (valid DTG).
 func Validate(dtgString string) error {
-	_, err := Parse(dtgString)
-	return err
+	return (*Parser)(nil).Validate(dtgString)
+}
+
+// ValidateAndNormalize is like Validate, but also returns the canonical form
+// of a valid DTG, e.g. 151230ZDEC19 for " 151230z" in December 2019, for
+// form backends that store what the operator meant in one call. Canonical
+// input is returned as is without parsing.
+func ValidateAndNormalize(dtgString string) (string, error) {
+	return (*Parser)(nil).ValidateAndNormalize(dtgString)
 }
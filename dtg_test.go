@@ -1,37 +1,12 @@
 package dtg
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 )
 
-// UTC-12: Y (e.g., Fiji)
-// UTC-11: X (American Samoa)
-// UTC-10: W (Honolulu, HI)
-// UTC-9: V (Juneau, AK)
-// UTC-8: U (PST, Los Angeles, CA)
-// UTC-7: T (MST, Denver, CO)
-// UTC-6: S (CST, Dallas, TX)
-// UTC-5: R (EST, New York, NY)
-// UTC-4: Q (Halifax, Nova Scotia)
-// UTC-3: P (Buenos Aires, Argentina)
-// UTC-2: O (Godthab, Greenland)
-// UTC-1: N (Azores)
-// UTC+-0: Z (Zulu time)
-// UTC+1: A (France)
-// UTC+2: B (Athens, Greece)
-// UTC+3: C (Arab Standard Time, Iraq, Bahrain, Kuwait, Saudi Arabia, Yemen, Qatar)
-// UTC+4: D (Used for Moscow, Russia, and Afghanistan, however, Afghanistan is technically +4:30 from UTC)
-// UTC+5: E (Pakistan, Kazakhstan, Tajikistan, Uzbekistan, and Turkmenistan)
-// UTC+6: F (Bangladesh)
-// UTC+7: G (Thailand)
-// UTC+8: H (Beijing, China)
-// UTC+9: I (Tokyo, Japan)
-// UTC+10: K (Brisbane, Australia)
-// UTC+11: L (Sydney, Australia)
-// UTC+12: M (Wellington, New Zealand)
-
 func Test_DTG_String(t *testing.T) {
 	var err error
 	dtg := DTG{}
@@ -94,8 +69,6 @@ func TestParse(t *testing.T) {
 		{`010000N`, `010000-0100` + month + year, `010000N` + month + year},
 		{`271337V`, `271337-0900` + month + year, `271337V` + month + year},
 		{`271337Y`, `271337-1200` + month + year, `271337Y` + month + year},
-		{`271337ZJAN29`, `271337+0000JAN29`, `271337ZJAN29`},
-		{`271337BDEC10`, `271337+0200DEC10`, `271337BDEC10`},
 	}
 	for _, v := range testTable {
 		dtg, err := Parse(v.input)
@@ -110,6 +83,19 @@ func TestParse(t *testing.T) {
 			t.Errorf("Expected \"%s\" for time-zone-letter-expanded formatted time.Time, but got \"%s\"", v.expectedExpandedDTG, ts)
 		}
 	}
+	// Test fully qualified reference vectors
+	for _, v := range testVectors {
+		dtg, err := Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dtg.String() != v.Canonical {
+			t.Errorf("Expected \"%s\", but got \"%s\"", v.Canonical, dtg.String())
+		}
+		if !dtg.Time.Equal(v.Instant) {
+			t.Errorf("Expected \"%s\" to be %s, but got %s", v.Input, v.Instant, dtg.Time.UTC())
+		}
+	}
 	// Test local time zone DTGs
 	testTable2 := []struct {
 		input               string
@@ -138,6 +124,36 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseInLocation(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skip(err)
+	}
+	honolulu, err := time.LoadLocation("Pacific/Honolulu")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		input    string
+		loc      *time.Location
+		expected string
+	}{
+		{`142339JJUN22`, stockholm, `142339BJUN22`},
+		{`142339DEC22`, stockholm, `142339ADEC22`},
+		{`142339JJUN22`, honolulu, `142339WJUN22`},
+		{`142339ZJUN22`, honolulu, `142339ZJUN22`},
+	}
+	for _, test := range tests {
+		d, err := ParseInLocation(test.input, test.loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("Expected \"%s\" in %s to give \"%s\", but got \"%s\"", test.input, test.loc, test.expected, d)
+		}
+	}
+}
+
 func TestGetNumericTimeZone(t *testing.T) {
 	_, offsetHere := time.Now().Zone()
 
@@ -226,3 +242,149 @@ func TestValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateAndNormalize(t *testing.T) {
+	reference := time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC)
+	p := &Parser{Now: func() time.Time { return reference }}
+	for _, test := range []struct{ input, expected string }{
+		{`151230ZDEC19`, `151230ZDEC19`},
+		{` 151230z`, `151230ZDEC19`},
+		{`151230bdec19`, `151230BDEC19`},
+		{`151230ZOKT19`, ``},
+		{`311230ZNOV19`, ``},
+	} {
+		got, err := p.ValidateAndNormalize(test.input)
+		if test.expected == `` {
+			if err == nil || got != `` {
+				t.Errorf("Expected error for \"%s\", but got \"%s\"", test.input, got)
+			}
+			continue
+		}
+		if err != nil || got != test.expected {
+			t.Errorf("Expected \"%s\" to give \"%s\", but got \"%s\" (%v)", test.input, test.expected, got, err)
+		}
+	}
+	if got, err := ValidateAndNormalize(`151230ADEC19`); err != nil || got != `151230ADEC19` {
+		t.Errorf("Expected \"151230ADEC19\", but got \"%s\" (%v)", got, err)
+	}
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 3*3600)
+	if got, err := (&Parser{Zones: zones}).ValidateAndNormalize(`151230D*DEC19`); err != nil || got != `151230CDEC19` {
+		t.Errorf("Expected \"151230CDEC19\", but got \"%s\" (%v)", got, err)
+	}
+}
+
+func TestZulu(t *testing.T) {
+	for _, v := range testVectors {
+		d, err := Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z := d.Zulu()
+		if s := z.String(); s[6] != 'Z' || !z.Time.Equal(v.Instant) {
+			t.Errorf("Expected \"%s\" in Zulu at %s, but got \"%s\"", v.Input, v.Instant, s)
+		}
+	}
+	if s := mustParse(t, "010030ADEC19").Zulu().String(); s != "302330ZNOV19" {
+		t.Errorf("Expected \"302330ZNOV19\", but got \"%s\"", s)
+	}
+}
+
+func TestInLetter(t *testing.T) {
+	d := mustParse(t, "151200ZDEC19")
+	tests := []struct {
+		letter   string
+		expected string
+	}{
+		{"R", "150700RDEC19"},
+		{"b", "151400BDEC19"},
+		{" M ", "160000MDEC19"},
+		{"Y", "150000YDEC19"},
+		{"Z", "151200ZDEC19"},
+	}
+	for _, test := range tests {
+		got, err := d.InLetter(test.letter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != test.expected || !got.Time.Equal(d.Time) {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, got)
+		}
+	}
+	local, err := d.InLetter("J")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, expected := d.Time.In(time.Local).Zone()
+	if _, offset := local.Time.Zone(); !local.Time.Equal(d.Time) || offset != expected {
+		t.Errorf("Expected %s in local time, but got %s", d.Time, local.Time)
+	}
+	if _, err := d.InLetter("Ö"); err != ErrInvalidTimeZoneLetter {
+		t.Errorf("Expected %v, but got %v", ErrInvalidTimeZoneLetter, err)
+	}
+}
+
+func TestInLocation(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		dtg      string
+		expected string
+		letter   string
+	}{
+		{"151200ZDEC19", "151300ADEC19", "A"},
+		{"151200ZJUN19", "151400BJUN19", "B"},
+		{"270059ZOCT19", "270259BOCT19", "B"},
+		{"270100ZOCT19", "270200AOCT19", "A"},
+	}
+	for _, test := range tests {
+		d, letter := mustParse(t, test.dtg).InLocation(stockholm)
+		if d.String() != test.expected || letter != test.letter || d.Time.Location() != stockholm {
+			t.Errorf("Expected \"%s\" (%s) in Stockholm, but got \"%s\" (%s)", test.expected, test.letter, d, letter)
+		}
+	}
+}
+
+func TestZoneLetter(t *testing.T) {
+	for _, v := range testVectors {
+		d, err := Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := d.String()
+		if letter := d.ZoneLetter(); letter != s[6:len(s)-5] {
+			t.Errorf("Expected zone letter \"%s\" of \"%s\", but got \"%s\"", s[6:len(s)-5], s, letter)
+		}
+		if components := fmt.Sprintf("%02d%02d%02d%s", d.Day(), d.Hour(), d.Minute(), d.ZoneLetter()); components != s[:len(s)-5] {
+			t.Errorf("Expected components \"%s\", but got \"%s\"", s[:len(s)-5], components)
+		}
+	}
+	if letter := (DTG{Time: time.Date(2019, 12, 15, 12, 30, 0, 0, time.FixedZone("", 13*3600))}).ZoneLetter(); letter != "J" {
+		t.Errorf("Expected \"J\" for +1300, but got \"%s\"", letter)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if d := MustParse("151230ZDEC19"); d.String() != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\"", d)
+	}
+	defer func() {
+		expected := `dtg: MustParse("15126ZDEC19"): ` + ErrInvalidDTG.Error() + `: 'Z' is not a digit`
+		if r := recover(); r != expected {
+			t.Errorf("Expected panic \"%s\", but got %v", expected, r)
+		}
+	}()
+	MustParse("15126ZDEC19")
+}
+
+// mustParse parses a DTG or fails the test.
+func mustParse(t *testing.T, s string) DTG {
+	t.Helper()
+	dtg, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dtg
+}
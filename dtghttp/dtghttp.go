@@ -0,0 +1,65 @@
+// Package dtghttp contains helpers for using ACP 121 Date Time Groups in
+// HTTP APIs and web applications, for example as route parameters in REST
+// APIs, with proper 400 Bad Request responses on invalid input.
+package dtghttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/sa6mwa/dtg"
+)
+
+var ErrMissingParam error = errors.New("missing DTG parameter")
+
+// ParamError reports an invalid or missing DTG in a request parameter. All
+// ParamErrors are client errors, see WriteError.
+type ParamError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	if e.Err == ErrMissingParam {
+		return fmt.Sprintf("%v %q", e.Err, e.Name)
+	}
+	return fmt.Sprintf("invalid DTG %q in parameter %q: %v", e.Value, e.Name, e.Err)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamFunc returns the raw value of a named route parameter. Adapt the
+// router in use, e.g. chi.URLParam for go-chi, or for gorilla/mux:
+//
+//	func(r *http.Request, name string) string { return mux.Vars(r)[name] }
+type ParamFunc func(r *http.Request, name string) string
+
+// PathParam decodes the DTG in the route parameter name, obtained from the
+// router by param. The value is parsed with dtg.ParseURLSegment, so it may
+// still be percent encoded. Errors are of type *ParamError.
+func PathParam(r *http.Request, name string, param ParamFunc) (dtg.DTG, error) {
+	value := param(r, name)
+	if value == "" {
+		return dtg.DTG{}, &ParamError{Name: name, Err: ErrMissingParam}
+	}
+	d, err := dtg.ParseURLSegment(value)
+	if err != nil {
+		return dtg.DTG{}, &ParamError{Name: name, Value: value, Err: err}
+	}
+	return d, nil
+}
+
+// WriteError replies to the request with 400 Bad Request for a *ParamError
+// and 500 Internal Server Error for any other error.
+func WriteError(w http.ResponseWriter, err error) {
+	var pe *ParamError
+	if errors.As(err, &pe) {
+		http.Error(w, pe.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,62 @@
+package dtghttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// lastSegment mimics a router exposing the last path segment as "dtg".
+func lastSegment(r *http.Request, name string) string {
+	if name != "dtg" {
+		return ""
+	}
+	return r.URL.EscapedPath()[strings.LastIndex(r.URL.EscapedPath(), "/")+1:]
+}
+
+func TestPathParam(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d, err := PathParam(r, "dtg", lastSegment)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		w.Write([]byte(d.URLSegment()))
+	})
+	testTable := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{`/messages/152359zdec19`, http.StatusOK, `152359ZDEC19`},
+		{`/messages/152359%5ADEC19`, http.StatusOK, `152359ZDEC19`},
+		{`/messages/441200ZDEC19`, http.StatusBadRequest, `invalid DTG "441200ZDEC19" in parameter "dtg"`},
+		{`/messages/`, http.StatusBadRequest, `missing DTG parameter "dtg"`},
+	}
+	for _, v := range testTable {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, v.path, nil))
+		if rec.Code != v.status {
+			t.Errorf("Expected status %d for %s, but got %d", v.status, v.path, rec.Code)
+		}
+		if !strings.HasPrefix(rec.Body.String(), v.body) {
+			t.Errorf("Expected body starting with \"%s\" for %s, but got \"%s\"", v.body, v.path, rec.Body.String())
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, errors.New("database is down"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, but got %d", http.StatusInternalServerError, rec.Code)
+	}
+	pe := &ParamError{Name: "dtg", Value: "x", Err: dtg.ErrInvalidDTG}
+	if !errors.Is(pe, dtg.ErrInvalidDTG) {
+		t.Error("Expected ParamError to unwrap to the parse error")
+	}
+}
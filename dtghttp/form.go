@@ -0,0 +1,85 @@
+package dtghttp
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// InputPattern is a pattern attribute for HTML input elements giving the
+// browser a first, loose check of a DTG before the form is submitted. The
+// server must still validate the value, e.g. with BindQuery. The months are
+// those of dtg.MonthPattern in any case, as the attribute has no flag for
+// it.
+var InputPattern string = `\s*[0-9]{6}([A-Za-z]\*?)?(` + caseless(dtg.MonthPattern) + `([0-9]{4}|[0-9]{2})?)?\s*`
+
+// caseless returns pattern with every letter A-Z replaced by a class of
+// both cases, e.g. [Jj][Aa][Nn] for JAN.
+func caseless(pattern string) string {
+	var b strings.Builder
+	for _, r := range pattern {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteString("[" + string(r) + string(r+'a'-'A') + "]")
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// BindQuery parses the DTG in the form field of the request, from the URL
+// query or (for POST, PUT and PATCH) the url-encoded form body, see
+// http.Request.FormValue. Errors are of type *ParamError and should be
+// answered with 400 Bad Request, see WriteError.
+func BindQuery(r *http.Request, field string) (dtg.DTG, error) {
+	value := strings.TrimSpace(r.FormValue(field))
+	if value == "" {
+		return dtg.DTG{}, &ParamError{Name: field, Err: ErrMissingParam}
+	}
+	d, err := dtg.Parse(value)
+	if err != nil {
+		return dtg.DTG{}, &ParamError{Name: field, Value: value, Err: err}
+	}
+	return d, nil
+}
+
+// InputValue formats v for the value attribute of a DTG input element. A
+// dtg.DTG (or *dtg.DTG) and a time.Time are printed as the canonical DTG. A
+// string, typically what the operator typed, is echoed in canonical form if
+// it is a valid DTG and as typed otherwise, so the operator can correct it.
+// Anything else yields an empty string.
+func InputValue(v interface{}) string {
+	switch value := v.(type) {
+	case dtg.DTG:
+		if value.Time.IsZero() {
+			return ""
+		}
+		return value.String()
+	case *dtg.DTG:
+		if value == nil {
+			return ""
+		}
+		return InputValue(*value)
+	case time.Time:
+		return InputValue(dtg.DTG{Time: value})
+	case string:
+		if d, err := dtg.Parse(value); err == nil {
+			return d.String()
+		}
+		return value
+	}
+	return ""
+}
+
+// FuncMap returns template functions for forms collecting DTGs:
+//
+//	<input name="dtg" value="{{dtgValue .DTG}}" pattern="{{dtgPattern}}">
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"dtgValue":   InputValue,
+		"dtgPattern": func() string { return InputPattern },
+	}
+}
@@ -0,0 +1,94 @@
+package dtghttp
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+func TestBindQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?dtg=+152359zdec19+&bad=441200Z", nil)
+	d, err := BindQuery(r, "dtg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.String() != `152359ZDEC19` {
+		t.Errorf("Expected \"152359ZDEC19\", but got \"%s\"", d)
+	}
+	if _, err := BindQuery(r, "bad"); err == nil {
+		t.Error("Expected to fail on field \"bad\", but succeeded")
+	}
+	var pe *ParamError
+	if _, err := BindQuery(r, "missing"); !errors.As(err, &pe) || pe.Err != ErrMissingParam {
+		t.Errorf("Expected %v, but got %v", ErrMissingParam, err)
+	}
+
+	form := url.Values{"when": {"010000AJAN20"}}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if d, err = BindQuery(r, "when"); err != nil {
+		t.Fatal(err)
+	}
+	if d.String() != `010000AJAN20` {
+		t.Errorf("Expected \"010000AJAN20\", but got \"%s\"", d)
+	}
+}
+
+func TestInputValue(t *testing.T) {
+	d, err := dtg.Parse(`152359ZDEC19`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testTable := []struct {
+		value    interface{}
+		expected string
+	}{
+		{d, `152359ZDEC19`},
+		{&d, `152359ZDEC19`},
+		{(*dtg.DTG)(nil), ``},
+		{dtg.DTG{}, ``},
+		{d.Time, `152359ZDEC19`},
+		{` 152359zdec19`, `152359ZDEC19`},
+		{`441200Z`, `441200Z`},
+		{42, ``},
+	}
+	for _, v := range testTable {
+		if s := InputValue(v.value); s != v.expected {
+			t.Errorf("Expected \"%s\" for %#v, but got \"%s\"", v.expected, v.value, s)
+		}
+	}
+}
+
+func TestFuncMap(t *testing.T) {
+	tmpl := template.Must(template.New("form").Funcs(FuncMap()).Parse(`<input name="dtg" value="{{dtgValue .}}" pattern="{{dtgPattern}}">`))
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, `"><script>`); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "<script>") {
+		t.Errorf("Expected the echoed value to be escaped, but got %s", b.String())
+	}
+	pattern := regexp.MustCompile(`^(?:` + InputPattern + `)$`)
+	for _, v := range []string{`152359ZDEC19`, `152359`, `152359z`, ` 152359Zdec `, `151230ZDEC2019`, `151230ZDECEMBER19`, `151230M*DEC19`, `151230zseptember2019`} {
+		if !pattern.MatchString(v) {
+			t.Errorf("Expected InputPattern to match \"%s\"", v)
+		}
+	}
+	for _, v := range []string{`151230ZDECEMBR19`, `151230**DEC19`, `151230ZFOO19`, `151230ZDEC201`} {
+		if pattern.MatchString(v) {
+			t.Errorf("Expected InputPattern not to match \"%s\"", v)
+		}
+	}
+	if pattern.MatchString(time.Now().Format(time.RFC3339)) {
+		t.Error("Expected InputPattern not to match an RFC 3339 time")
+	}
+}
@@ -0,0 +1,141 @@
+// Package dtgtea is a Bubble Tea (github.com/charmbracelet/bubbletea)
+// component for entering ACP 121 Date Time Groups in terminal applications.
+// It wraps a bubbles text input with autocorrection, live validation (green
+// or red), tab completion of zone letters, months and years and a preview of
+// the DTG in Zulu.
+//
+// dtgtea is a separate module so that the dtg package itself stays free of
+// terminal dependencies.
+package dtgtea
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sa6mwa/dtg"
+)
+
+// Model is a DTG entry field. Embed it in a parent model and forward
+// messages to Update, like any bubbles component.
+type Model struct {
+	// Input is the underlying text input, set its Prompt, Placeholder or
+	// Width as needed.
+	Input textinput.Model
+	// Prompt validates and completes the input, see dtg.Prompt.
+	Prompt *dtg.Prompt
+	// ValidStyle, InvalidStyle and PendingStyle render the feedback line
+	// for a valid DTG, a mistake and incomplete input respectively.
+	ValidStyle   lipgloss.Style
+	InvalidStyle lipgloss.Style
+	PendingStyle lipgloss.Style
+
+	dtg         dtg.DTG
+	err         error
+	completions []string
+	completion  int
+}
+
+// New returns a focused DTG entry field.
+func New() Model {
+	input := textinput.New()
+	input.Placeholder = "ddHHMMZmmmYY"
+	input.CharLimit = 32
+	input.Focus()
+	m := Model{
+		Input:        input,
+		Prompt:       &dtg.Prompt{},
+		ValidStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		InvalidStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		PendingStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+	m.validate()
+	return m
+}
+
+// Init makes the cursor blink.
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles key presses: tab (repeatedly) cycles through the
+// completions of the group being typed, anything else is passed to the
+// text input after which the input is autocorrected and validated.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyTab {
+		if m.completions == nil {
+			m.completions = m.Prompt.Complete(m.Input.Value())
+			m.completion = 0
+		}
+		if len(m.completions) > 0 {
+			m.Input.SetValue(m.completions[m.completion%len(m.completions)])
+			m.Input.CursorEnd()
+			m.completion++
+		}
+		m.validate()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.Input, cmd = m.Input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.completions = nil
+		if corrected := Autocorrect(m.Input.Value()); corrected != m.Input.Value() {
+			m.Input.SetValue(corrected)
+			m.Input.CursorEnd()
+		}
+	}
+	m.validate()
+	return m, cmd
+}
+
+// View renders the input and a feedback line below it: the DTG and its
+// Zulu equivalent when valid, the error otherwise.
+func (m Model) View() string {
+	var feedback string
+	switch {
+	case m.err == nil:
+		feedback = m.ValidStyle.Render("✓ " + m.dtg.String() + " = " + m.dtg.Zulu().String())
+	case m.Input.Value() == "" || m.Prompt.ValidatePrefix(m.Input.Value()) == nil:
+		feedback = m.PendingStyle.Render("… ddHHMM[Z[mmm[YY]]]")
+	default:
+		feedback = m.InvalidStyle.Render("✗ " + m.err.Error())
+	}
+	return m.Input.View() + "\n" + feedback
+}
+
+// Value returns the entered DTG and whether it is valid.
+func (m Model) Value() (dtg.DTG, bool) {
+	return m.dtg, m.err == nil
+}
+
+// Err returns why the input is not a valid DTG, or nil.
+func (m Model) Err() error {
+	return m.err
+}
+
+// Focus focuses the input, see textinput.Model.Focus.
+func (m *Model) Focus() tea.Cmd {
+	return m.Input.Focus()
+}
+
+// Blur removes focus from the input.
+func (m *Model) Blur() {
+	m.Input.Blur()
+}
+
+func (m *Model) validate() {
+	m.err = m.Prompt.Validate(m.Input.Value())
+	m.dtg = dtg.DTG{}
+	if m.err == nil {
+		m.dtg, m.err = m.Prompt.Parser.Parse(m.Input.Value())
+	}
+}
+
+// Autocorrect fixes what operators commonly get wrong when typing or
+// pasting a DTG: look-alike and full-width characters (see dtg.Normalize),
+// lower case and spaces between the groups.
+func Autocorrect(s string) string {
+	s, _ = dtg.Normalize(s)
+	return strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+}
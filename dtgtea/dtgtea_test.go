@@ -0,0 +1,50 @@
+package dtgtea
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeString(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestModel(t *testing.T) {
+	m := typeString(New(), "151230zdec 19")
+	if m.Input.Value() != "151230ZDEC19" {
+		t.Errorf("Expected autocorrected \"151230ZDEC19\", but got \"%s\"", m.Input.Value())
+	}
+	if d, ok := m.Value(); !ok || d.String() != "151230ZDEC19" {
+		t.Errorf("Expected a valid 151230ZDEC19, but got %s (%v)", d, m.Err())
+	}
+	if !strings.Contains(m.View(), "✓ 151230ZDEC19 = 151230ZDEC19") {
+		t.Errorf("Expected a valid feedback line, but got %q", m.View())
+	}
+
+	m = typeString(New(), "151230ad")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Input.Value() != "151230ADEC" {
+		t.Errorf("Expected completion to \"151230ADEC\", but got \"%s\"", m.Input.Value())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Input.Value() != "151230ADEC" {
+		t.Errorf("Expected the only completion to stay, but got \"%s\"", m.Input.Value())
+	}
+	if !strings.Contains(m.View(), "= 151130ZDEC") {
+		t.Errorf("Expected the Zulu preview 151130ZDEC, but got %q", m.View())
+	}
+
+	m = typeString(New(), "1512")
+	if _, ok := m.Value(); ok || !strings.Contains(m.View(), "…") {
+		t.Errorf("Expected pending feedback for incomplete input, but got %q", m.View())
+	}
+	m = typeString(New(), "1560")
+	if !strings.Contains(m.View(), "✗") {
+		t.Errorf("Expected an error for minute 60, but got %q", m.View())
+	}
+}
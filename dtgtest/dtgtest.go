@@ -0,0 +1,134 @@
+// Package dtgtest is a differential testing harness for DTG parsers. It
+// generates corpora of DTG strings, valid and invalid, and reports where two
+// implementations disagree, e.g. the fast and slow paths of dtg.Validate, or
+// a fork's patched parser and the upstream one.
+package dtgtest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// ParseFunc is an implementation under test, e.g. a closure calling the
+// Parse method of a Parser with a fixed reference time.
+type ParseFunc func(dtgString string) (dtg.DTG, error)
+
+// Validator adapts a validation function, e.g. dtg.Validate, to a ParseFunc
+// comparing errors only.
+func Validator(validate func(dtgString string) error) ParseFunc {
+	return func(dtgString string) (dtg.DTG, error) {
+		return dtg.DTG{}, validate(dtgString)
+	}
+}
+
+// Difference is an input two implementations disagree on.
+type Difference struct {
+	Input           string
+	Want, Got       dtg.DTG
+	WantErr, GotErr error
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%q: want %s (%v), got %s (%v)", d.Input, describe(d.Want), d.WantErr, describe(d.Got), d.GotErr)
+}
+
+func describe(d dtg.DTG) string {
+	if d.Time.IsZero() {
+		return "no DTG"
+	}
+	return d.String() + " " + d.Time.Format("2006-01-02T15:04Z07:00")
+}
+
+// Diff runs want and got over the corpus and returns the inputs they
+// disagree on. Results agree if both are errors with the same message, or
+// both are the same instant in the same offset.
+func Diff(corpus []string, want, got ParseFunc) []Difference {
+	var differences []Difference
+	for _, input := range corpus {
+		w, wantErr := want(input)
+		g, gotErr := got(input)
+		if agree(w, g, wantErr, gotErr) {
+			continue
+		}
+		differences = append(differences, Difference{Input: input, Want: w, Got: g, WantErr: wantErr, GotErr: gotErr})
+	}
+	return differences
+}
+
+func agree(want, got dtg.DTG, wantErr, gotErr error) bool {
+	if wantErr != nil || gotErr != nil {
+		return wantErr != nil && gotErr != nil && wantErr.Error() == gotErr.Error()
+	}
+	_, wantOffset := want.Time.Zone()
+	_, gotOffset := got.Time.Zone()
+	return want.Time.Equal(got.Time) && wantOffset == gotOffset
+}
+
+// Check fails t with the first differences (at most 10) of Diff.
+func Check(t testing.TB, corpus []string, want, got ParseFunc) {
+	t.Helper()
+	differences := Diff(corpus, want, got)
+	for i, d := range differences {
+		if i == 10 {
+			t.Errorf("... and %d more differences", len(differences)-i)
+			break
+		}
+		t.Error(d)
+	}
+}
+
+var (
+	corpusMonths = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC", "MAJ", "OKT", "dec", "Feb", "XYZ"}
+)
+
+// Corpus returns n DTG strings generated from seed, the same for the same
+// seed and n. Most are well-formed with edge values (day 00-39, hour 00-29,
+// minute 00-69, leap days, every letter, MAJ and OKT, lower case, every
+// combination of omitted parts), some have a random byte inserted,
+// replaced or removed, or surrounding space.
+func Corpus(seed int64, n int) []string {
+	rng := rand.New(rand.NewSource(seed))
+	corpus := make([]string, n)
+	for i := range corpus {
+		var b strings.Builder
+		day := rng.Intn(40)
+		if rng.Intn(4) == 0 {
+			day = 28 + rng.Intn(4)
+		}
+		fmt.Fprintf(&b, "%02d%02d%02d", day, rng.Intn(30), rng.Intn(70))
+		switch r := rng.Intn(10); {
+		case r < 6:
+			b.WriteByte(byte('A' + rng.Intn(26)))
+		case r == 6:
+			b.WriteByte(byte('a' + rng.Intn(26)))
+		case r == 7:
+			b.WriteString("D*")
+		}
+		if rng.Intn(4) != 0 {
+			b.WriteString(corpusMonths[rng.Intn(len(corpusMonths))])
+			if rng.Intn(4) != 0 {
+				fmt.Fprintf(&b, "%02d", rng.Intn(100))
+			}
+		}
+		s := b.String()
+		switch rng.Intn(10) {
+		case 0:
+			at := rng.Intn(len(s) + 1)
+			s = s[:at] + string([]byte{byte(rng.Intn(256))}) + s[at:]
+		case 1:
+			at := rng.Intn(len(s))
+			s = s[:at] + string([]byte{byte(rng.Intn(256))}) + s[at+1:]
+		case 2:
+			at := rng.Intn(len(s))
+			s = s[:at] + s[at+1:]
+		case 3:
+			s = " " + s + "\n"
+		}
+		corpus[i] = s
+	}
+	return corpus
+}
@@ -0,0 +1,66 @@
+package dtgtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+func TestValidateFastPath(t *testing.T) {
+	reference := time.Date(2020, time.February, 15, 12, 0, 0, 0, time.UTC)
+	zones := dtg.DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	for _, p := range []*dtg.Parser{
+		{Now: func() time.Time { return reference }},
+		{Now: func() time.Time { return reference }, Zones: zones, CenturyPivot: 1929},
+		{Now: func() time.Time { return reference }, Strict: true},
+	} {
+		slow := Validator(func(s string) error {
+			_, err := p.Parse(s)
+			return err
+		})
+		Check(t, Corpus(1, 100000), slow, Validator(p.Validate))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	corpus := Corpus(2, 1000)
+	parse := func(s string) (dtg.DTG, error) { return dtg.Parse(s) }
+	buggy := func(s string) (dtg.DTG, error) {
+		d, err := parse(s)
+		if err == nil && strings.HasPrefix(s, "29") {
+			d.Time = d.Time.Add(time.Minute)
+		}
+		return d, err
+	}
+	differences := Diff(corpus, parse, buggy)
+	if len(differences) == 0 {
+		t.Fatal("Expected differences, but got none")
+	}
+	for _, d := range differences {
+		if !strings.HasPrefix(d.Input, "29") || d.WantErr != nil || !d.Got.Time.Equal(d.Want.Time.Add(time.Minute)) {
+			t.Errorf("Unexpected difference %s", d)
+		}
+	}
+	if differences := Diff(corpus, parse, parse); len(differences) != 0 {
+		t.Errorf("Expected no differences, but got %s", differences[0])
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	a, b := Corpus(3, 1000), Corpus(3, 1000)
+	valid := 0
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Expected the same corpus for the same seed, but got \"%s\" and \"%s\"", a[i], b[i])
+		}
+		if dtg.Validate(a[i]) == nil {
+			valid++
+		}
+	}
+	if valid < 200 || valid > 800 {
+		t.Errorf("Expected a mix of valid and invalid DTGs, but got %d valid of %d", valid, len(a))
+	}
+}
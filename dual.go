@@ -0,0 +1,45 @@
+package dtg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var ErrDualMismatch error = errors.New("local time does not match the DTG")
+
+// dualRegexp matches the form written by FormatDual.
+var dualRegexp *regexp.Regexp = regexp.MustCompile(`(?i)^\s*(\S+)\s+\(([0-9]{6})\s+local\)\s*$`)
+
+// FormatDual returns the Zulu DTG of dtg with the local time in loc next to
+// it, e.g. "151230ZDEC19 (151330 local)" for Central European Time, for
+// SOPs requiring both times to be printed together. The local time is
+// ddHHMM, as the month and year are rarely different.
+func FormatDual(dtg DTG, loc *time.Location) string {
+	return dtg.Zulu().String() + " (" + dtg.Time.In(loc).Format(dayLayout+hourLayout+minuteLayout) + " local)"
+}
+
+// ParseDual parses the form written by FormatDual and verifies that the
+// local time is the DTG in loc, returning ErrDualMismatch otherwise, so a
+// hand edited pair that no longer agrees is not silently accepted.
+func ParseDual(s string, loc *time.Location) (DTG, error) {
+	return (*Parser)(nil).ParseDual(s, loc)
+}
+
+// ParseDual is like the package level ParseDual, but uses the Parser's
+// zone table.
+func (p *Parser) ParseDual(s string, loc *time.Location) (DTG, error) {
+	match := dualRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return DTG{}, ErrInvalidDTG
+	}
+	dtg, err := p.Parse(match[1])
+	if err != nil {
+		return DTG{}, err
+	}
+	if dtg.Time.In(loc).Format(dayLayout+hourLayout+minuteLayout) != strings.TrimSpace(match[2]) {
+		return DTG{}, ErrDualMismatch
+	}
+	return dtg, nil
+}
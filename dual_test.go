@@ -0,0 +1,48 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDual(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	tests := []struct {
+		dtg      string
+		loc      *time.Location
+		expected string
+	}{
+		{"151230ZDEC19", cet, "151230ZDEC19 (151330 local)"},
+		{"151330ADEC19", cet, "151230ZDEC19 (151330 local)"},
+		{"312330ZDEC19", cet, "312330ZDEC19 (010030 local)"},
+		{"151230ZDEC19", time.FixedZone("", -10*3600), "151230ZDEC19 (150230 local)"},
+	}
+	for _, test := range tests {
+		got := FormatDual(mustParse(t, test.dtg), test.loc)
+		if got != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, got)
+		}
+		d, err := ParseDual(got, test.loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !d.Time.Equal(mustParse(t, test.dtg).Time) {
+			t.Errorf("Expected \"%s\" to parse as %s, but got %s", got, test.dtg, d)
+		}
+	}
+}
+
+func TestParseDual(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	if _, err := ParseDual("151230ZDEC19 (151230 local)", cet); err != ErrDualMismatch {
+		t.Errorf("Expected ErrDualMismatch, but got %v", err)
+	}
+	if d, err := ParseDual("  151330adec19 (151330 LOCAL) ", cet); err != nil || d.String() != "151330ADEC19" {
+		t.Errorf("Expected 151330ADEC19, but got %s (%v)", d, err)
+	}
+	for _, invalid := range []string{"151230ZDEC19", "151230ZDEC19 (1513 local)", "151230ZDEC19 151330 local"} {
+		if _, err := ParseDual(invalid, cet); err != ErrInvalidDTG {
+			t.Errorf("Expected ErrInvalidDTG for \"%s\", but got %v", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package dtg
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TextRegexp finds Date Time Group candidates in free text. Unlike
+// DtgRegexp it is not anchored, the zone designator is mandatory (to avoid
+// matching any six digit number) and a single space is allowed between the
+// groups of the DTG, as in "151230Z DEC 19". The last group must be
+// followed by a character other than a letter or digit, or the end of the
+// text; that character is part of the match, see FindAll.
+var TextRegexp *regexp.Regexp = regexp.MustCompile(`(?i)\b([0-9]{2})([0-9]{2})([0-9]{2})([A-Z]\*?)(?: ?` + MonthPattern + `(?: ?([0-9]{4}|[0-9]{2}))?)?(?:[^0-9A-Za-z]|$)`)
+
+// Match is a DTG found in a text. The embedded Details tell the zone
+// designator and offset of the DTG and whether month and year were written
+// out, so e.g. all local time (J) DTGs of an archive can be picked out.
+type Match struct {
+	Details
+	// Text is the DTG as written, Start and End its byte offsets in the
+	// text.
+	Text       string
+	Start, End int
+}
+
+// FindAll returns all valid Date Time Groups in text, in order of
+// appearance. Candidates that do not parse (e.g. 441200Z) are skipped.
+// DTGs without month and year are completed like Parse does.
+func FindAll(text string) []Match {
+	return (*Parser)(nil).FindAll(text)
+}
+
+// FindAll is like the package level FindAll, but uses the Parser's zone
+// table.
+func (p *Parser) FindAll(text string) []Match {
+	var matches []Match
+	for _, loc := range TextRegexp.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		// Drop the terminating character (if any) from the match.
+		for end > start && !isDtgByte(text[end-1]) {
+			end--
+		}
+		candidate := text[start:end]
+		details, err := p.ParseDetailed(strings.ReplaceAll(candidate, " ", ""))
+		if err != nil {
+			continue
+		}
+		matches = append(matches, Match{Details: details, Text: candidate, Start: start, End: end})
+	}
+	return matches
+}
+
+// isDtgByte reports whether b can end a DTG in a text.
+func isDtgByte(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || b == '*'
+}
@@ -0,0 +1,58 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "R 151230Z DEC 19 FM HQ\nTO 2BN\nBT\nH-HOUR 160600ZDEC19, PL ALPHA NLT 160730Zdec19.\n" +
+		"CALL 0701-123456 OR 441200Z OR 1512301Z OR 151230ZDECEMBER. (170000Z)\nEND 181200Z DEC19"
+	expected := []struct {
+		text      string
+		canonical string
+	}{
+		{`151230Z DEC 19`, `151230ZDEC19`},
+		{`160600ZDEC19`, `160600ZDEC19`},
+		{`160730Zdec19`, `160730ZDEC19`},
+		{`151230ZDECEMBER`, ``},
+		{`170000Z`, ``},
+		{`181200Z DEC19`, `181200ZDEC19`},
+	}
+	matches := FindAll(text)
+	if len(matches) != len(expected) {
+		t.Fatalf("Expected %d matches, but got %d: %v", len(expected), len(matches), matches)
+	}
+	for i, m := range matches {
+		if m.Text != expected[i].text {
+			t.Errorf("Expected match %d to be \"%s\", but got \"%s\"", i, expected[i].text, m.Text)
+		}
+		if text[m.Start:m.End] != m.Text {
+			t.Errorf("Expected offsets %d:%d to give \"%s\", but got \"%s\"", m.Start, m.End, m.Text, text[m.Start:m.End])
+		}
+		if expected[i].canonical != "" && m.DTG.String() != expected[i].canonical {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected[i].canonical, m.DTG)
+		}
+	}
+	if m := FindAll("EXDATE 271337Z JAN 2029."); len(m) != 1 || m[0].Text != "271337Z JAN 2029" || m[0].DTG.Year() != 2029 {
+		t.Errorf("Expected 271337Z JAN 2029, but got %+v", m)
+	}
+	if m := FindAll("ORDERS 151200Z DECEMBER 19 AND 161200ZSEPTEMBER2019."); len(m) != 2 ||
+		m[0].Text != "151200Z DECEMBER 19" || m[0].DTG.String() != "151200ZDEC19" ||
+		m[1].Text != "161200ZSEPTEMBER2019" || m[1].DTG.String() != "161200ZSEP19" {
+		t.Errorf("Expected 151200Z DECEMBER 19 and 161200ZSEPTEMBER2019, but got %+v", m)
+	}
+	if m := FindAll("A 151230J B 151230ZDEC C 151230B DEC 19"); len(m) != 3 ||
+		m[0].Designator != "J" || !m[0].ExplicitDesignator || m[0].ExplicitMonth ||
+		m[1].Designator != "Z" || !m[1].ExplicitMonth || m[1].ExplicitYear ||
+		m[2].Designator != "B" || m[2].Offset != 7200 || !m[2].ExplicitYear {
+		t.Errorf("Expected matches with letters J, Z and B and explicit month and year as written, but got %+v", m)
+	}
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	if m := (&Parser{Zones: zones}).FindAll("AT 151200D* DEC 19 AND"); len(m) != 1 || m[0].Text != `151200D* DEC 19` {
+		t.Errorf("Expected extended designators to be found, but got %v", m)
+	}
+	if m := FindAll(""); len(m) != 0 {
+		t.Errorf("Expected no matches, but got %v", m)
+	}
+}
@@ -0,0 +1,76 @@
+package dtg
+
+import "time"
+
+// Antisocial hours are from AntisocialStart until AntisocialEnd local time,
+// i.e. 2200-0700.
+const (
+	AntisocialStart = 22
+	AntisocialEnd   = 7
+)
+
+// Participant is a member of a distributed team working in the zone of
+// Letter (J is the local time zone).
+type Participant struct {
+	Name   string
+	Letter string
+}
+
+// Attendance is when a Participant attends a meeting.
+type Attendance struct {
+	Participant Participant
+	// DTG is the meeting written in the participant's zone letter.
+	DTG DTG
+	// DateShift is -1 or 1 when the meeting is on the day before or after
+	// the Zulu date for the participant, see DateShift.
+	DateShift int
+	// Antisocial is true when the meeting starts in antisocial hours for
+	// the participant.
+	Antisocial bool
+}
+
+// Fairness returns the local time of meeting for each participant, in
+// order, and flags those that have to attend in antisocial hours.
+func Fairness(meeting DTG, participants []Participant) ([]Attendance, error) {
+	attendance := make([]Attendance, len(participants))
+	for i, p := range participants {
+		t, err := timeIn(meeting, p.Letter)
+		if err != nil {
+			return nil, err
+		}
+		attendance[i] = Attendance{
+			Participant: p,
+			DTG:         DTG{Time: t},
+			DateShift:   calendarDays(meeting.Time.UTC(), t),
+			Antisocial:  antisocial(t),
+		}
+	}
+	return attendance, nil
+}
+
+// AntisocialCounts returns how many of the meetings each participant has to
+// attend in antisocial hours, by name, so rotations of a recurring meeting
+// (e.g. alternating between 0800Z and 2000Z) can be compared for how evenly
+// they spread the burden.
+func AntisocialCounts(meetings []DTG, participants []Participant) (map[string]int, error) {
+	counts := make(map[string]int, len(participants))
+	for _, p := range participants {
+		counts[p.Name] = 0
+	}
+	for _, meeting := range meetings {
+		attendance, err := Fairness(meeting, participants)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range attendance {
+			if a.Antisocial {
+				counts[a.Participant.Name]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func antisocial(t time.Time) bool {
+	return inHours(t, AntisocialStart*60, AntisocialEnd*60)
+}
@@ -0,0 +1,46 @@
+package dtg
+
+import "testing"
+
+func TestFairness(t *testing.T) {
+	team := []Participant{
+		{Name: "Stockholm", Letter: "A"},
+		{Name: "Washington", Letter: "r"},
+		{Name: "Wellington", Letter: "M"},
+		{Name: "Honolulu", Letter: "W"},
+	}
+	attendance, err := Fairness(mustParse(t, "151400ZDEC19"), team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		dtg        string
+		shift      int
+		antisocial bool
+	}{
+		{"151500ADEC19", 0, false},
+		{"150900RDEC19", 0, false},
+		{"160200MDEC19", 1, true},
+		{"150400WDEC19", 0, true},
+	}
+	for i, e := range expected {
+		a := attendance[i]
+		if a.Participant != team[i] || a.DTG.String() != e.dtg || a.DateShift != e.shift || a.Antisocial != e.antisocial {
+			t.Errorf("Expected %s at %s (shift %d, antisocial %t), but got %s (shift %d, antisocial %t)", team[i].Name, e.dtg, e.shift, e.antisocial, a.DTG, a.DateShift, a.Antisocial)
+		}
+	}
+	if _, err := Fairness(mustParse(t, "151400ZDEC19"), []Participant{{Name: "x", Letter: "Ö"}}); err != ErrInvalidTimeZoneLetter {
+		t.Errorf("Expected %v, but got %v", ErrInvalidTimeZoneLetter, err)
+	}
+}
+
+func TestAntisocialCounts(t *testing.T) {
+	team := []Participant{{Name: "Stockholm", Letter: "A"}, {Name: "Wellington", Letter: "M"}}
+	counts, err := AntisocialCounts([]DTG{mustParse(t, "150800ZDEC19"), mustParse(t, "151400ZDEC19"), mustParse(t, "161400ZDEC19")}, team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["Stockholm"] != 0 || counts["Wellington"] != 2 || len(counts) != 2 {
+		t.Errorf("Expected Stockholm 0 and Wellington 2, but got %v", counts)
+	}
+}
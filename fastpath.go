@@ -0,0 +1,83 @@
+package dtg
+
+import "time"
+
+// The validation fast path checks fully qualified canonical DTGs, e.g.
+// 151230ZDEC19, without the regular expression and time parsing of Parse,
+// so Validate over large corpora of well-formed traffic is bound by memory
+// rather than parsing. The digits of the day, hour, minute and year are
+// checked eight bytes at a time (SWAR) rather than one by one. Anything the
+// fast path does not accept takes the slow path, which also produces the
+// errors, so the fast path must never accept what Parse rejects (see the
+// differential tests in fastpath_test.go and package dtgtest).
+
+const (
+	swarHighNibbles uint64 = 0xf0f0f0f0f0f0f0f0
+	swarDigitZeroes uint64 = 0x3030303030303030
+	swarDigitCarry  uint64 = 0x0606060606060606
+)
+
+// validFast reports whether s is a canonical DTG (ddHHMMZMMMYY, with a
+// designator of the Parser's zone table other than J and an English month
+// abbreviation) that Parse accepts. False means the slow path decides.
+func (p *Parser) validFast(s string) bool {
+	n := len(s)
+	if n != 12 && (n != 13 || s[7] != '*') {
+		return false
+	}
+	x := uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[n-2])<<48 | uint64(s[n-1])<<56
+	// Digits have the high nibble 3 and a low nibble that does not carry
+	// when 6 is added.
+	if x&swarHighNibbles != swarDigitZeroes || (x+swarDigitCarry)&swarHighNibbles != swarDigitZeroes {
+		return false
+	}
+	v := x - swarDigitZeroes
+	day := uint(v&0xff)*10 + uint(v>>8&0xff)
+	hour := uint(v>>16&0xff)*10 + uint(v>>24&0xff)
+	minute := uint(v>>32&0xff)*10 + uint(v>>40&0xff)
+	month := monthOf(s[n-5], s[n-4], s[n-3])
+	if day-1 >= 31 || hour >= 24 || minute >= 60 || month == 0 || s[6] == 'J' {
+		return false
+	}
+	if _, ok := p.zones().offsets[s[6:n-5]]; !ok {
+		return false
+	}
+	if day < 29 {
+		return true
+	}
+	year := p.century(int(v>>48&0xff)*10 + int(v>>56))
+	return int(day) <= time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// monthOf returns the month of an upper case English month abbreviation,
+// or 0.
+func monthOf(a, b, c byte) time.Month {
+	switch uint32(a)<<16 | uint32(b)<<8 | uint32(c) {
+	case 'J'<<16 | 'A'<<8 | 'N':
+		return time.January
+	case 'F'<<16 | 'E'<<8 | 'B':
+		return time.February
+	case 'M'<<16 | 'A'<<8 | 'R':
+		return time.March
+	case 'A'<<16 | 'P'<<8 | 'R':
+		return time.April
+	case 'M'<<16 | 'A'<<8 | 'Y':
+		return time.May
+	case 'J'<<16 | 'U'<<8 | 'N':
+		return time.June
+	case 'J'<<16 | 'U'<<8 | 'L':
+		return time.July
+	case 'A'<<16 | 'U'<<8 | 'G':
+		return time.August
+	case 'S'<<16 | 'E'<<8 | 'P':
+		return time.September
+	case 'O'<<16 | 'C'<<8 | 'T':
+		return time.October
+	case 'N'<<16 | 'O'<<8 | 'V':
+		return time.November
+	case 'D'<<16 | 'E'<<8 | 'C':
+		return time.December
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package dtg
+
+import (
+	"fmt"
+	"testing"
+)
+
+// slowValid is Validate without the fast path.
+func slowValid(p *Parser, s string) bool {
+	_, err := p.Parse(s)
+	return err == nil
+}
+
+func TestValidFastDayHourMinute(t *testing.T) {
+	if testing.Short() {
+		t.Skip("exhaustive")
+	}
+	// Every ddHHMM, for a month with 31 days and one with 29.
+	for _, suffix := range []string{"ZDEC19", "AFEB20"} {
+		for i := 0; i < 1000000; i++ {
+			s := fmt.Sprintf("%06d%s", i, suffix)
+			if fast, slow := (*Parser)(nil).validFast(s), slowValid(nil, s); fast != slow {
+				t.Fatalf("Expected fast path %v for \"%s\" as the slow path, but got %v", slow, s, fast)
+			}
+		}
+	}
+}
+
+func TestValidFastCalendar(t *testing.T) {
+	months := append(append([]string{}, monthAbbreviations...), "MAJ", "OKT", "Dec", "XYZ")
+	designators := []string{"J", "D*", "Z*", "z", "1"}
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		designators = append(designators, string(letter))
+	}
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	for _, p := range []*Parser{nil, {CenturyPivot: 1900}, {Zones: zones}, {Strict: true}} {
+		for day := 0; day < 40; day++ {
+			for _, month := range months {
+				for _, year := range []int{0, 19, 20, 21, 24, 68, 69, 96, 99} {
+					for _, designator := range designators {
+						s := fmt.Sprintf("%02d1230%s%s%02d", day, designator, month, year)
+						fast, slow := p.validFast(s), slowValid(p, s)
+						if fast && !slow {
+							t.Fatalf("Expected the fast path to reject \"%s\" like the slow path (%+v)", s, p)
+						}
+						canonical := designator != "J" && designator != "z" && month != "MAJ" && month != "OKT" && month != "Dec"
+						if !fast && slow && canonical {
+							t.Fatalf("Expected the fast path to accept canonical \"%s\" (%+v)", s, p)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestValidFastBytes(t *testing.T) {
+	// Every byte at every position of a canonical DTG (and a D* one).
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	p := &Parser{Zones: zones}
+	for _, canonical := range []string{"151230ZDEC19", "291230BFEB20", "151230D*DEC19"} {
+		for i := 0; i <= len(canonical); i++ {
+			next := i + 1
+			if next > len(canonical) {
+				next = len(canonical)
+			}
+			for b := 0; b < 256; b++ {
+				for _, s := range []string{
+					canonical[:i] + string([]byte{byte(b)}) + canonical[i:],
+					canonical[:i] + string([]byte{byte(b)}) + canonical[next:],
+				} {
+					if p.validFast(s) && !slowValid(p, s) {
+						t.Fatalf("Expected the fast path to reject %q like the slow path", s)
+					}
+				}
+			}
+		}
+	}
+	if !p.validFast("151230D*DEC19") || (*Parser)(nil).validFast("151230D*DEC19") {
+		t.Error("Expected D* to be valid only in the Parser's zone table")
+	}
+}
+
+func TestValidate_FastPathErrors(t *testing.T) {
+	// Rejected by the fast path, the slow path returns the error.
+	if err := Validate("311230ZFEB20"); err == nil || err.Error() != "no such day in the month: FEB 2020 has 29 days" {
+		t.Errorf("Expected ErrNoSuchDay, but got %v", err)
+	}
+	if err := Validate(" 151230ZDEC19 "); err != nil {
+		t.Errorf("Expected the slow path to accept surrounding space, but got %v", err)
+	}
+}
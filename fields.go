@@ -0,0 +1,131 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrFieldRange error = errors.New("out of range")
+
+// Fields are the parts of a DTG as picked in a GUI, e.g. from a calendar
+// and a zone letter drop-down, see FromFields. An empty Letter means J, the
+// local time zone, as in Parse.
+type Fields struct {
+	Year   int
+	Month  time.Month
+	Day    int
+	Hour   int
+	Minute int
+	Letter string
+}
+
+// FieldError reports an invalid field of Fields, named as in the struct
+// (Year, Month, Day, Hour, Minute or Letter), so a GUI can mark the field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", strings.ToLower(e.Field), e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors are all invalid fields of a Fields, in struct order.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Field returns the error of the named field, or nil if the field is valid.
+func (e FieldErrors) Field(name string) *FieldError {
+	for _, err := range e {
+		if err.Field == name {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromFields constructs a DTG from its parts without going through string
+// formatting. Every field is validated and all invalid fields are returned
+// as FieldErrors. The year must survive the two digit year of a DTG, i.e.
+// be 1969-2068 unless Parser.CenturyPivot says otherwise, and the day must
+// exist in the month.
+func FromFields(f Fields) (DTG, error) {
+	return (*Parser)(nil).FromFields(f)
+}
+
+// FromFields is like the package level FromFields, but uses the Parser's
+// zone table, and its Location and Now for J.
+func (p *Parser) FromFields(f Fields) (DTG, error) {
+	var errs FieldErrors
+	check := func(field string, value, min, max int) {
+		if value < min || value > max {
+			errs = append(errs, &FieldError{Field: field, Err: fmt.Errorf("%d %w (%d-%d)", value, ErrFieldRange, min, max)})
+		}
+	}
+	check("Year", f.Year, p.centuryPivot(), p.centuryPivot()+99)
+	check("Month", int(f.Month), 1, 12)
+	days := 31
+	if f.Month >= time.January && f.Month <= time.December {
+		days = time.Date(f.Year, f.Month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	}
+	check("Day", f.Day, 1, days)
+	check("Hour", f.Hour, 0, 23)
+	check("Minute", f.Minute, 0, 59)
+	letter := strings.ToUpper(strings.TrimSpace(f.Letter))
+	var parts []string
+	if len(errs) == 0 {
+		// Resolve J at the DTG rather than now, in case of DST.
+		t := time.Date(f.Year, f.Month, f.Day, f.Hour, f.Minute, 0, 0, time.UTC)
+		parts = []string{t.Format(dayLayout), t.Format(hourLayout), t.Format(minuteLayout), t.Format(monthLayout), t.Format(yearLayout)}
+	}
+	loc, err := p.location(p.reference(), letter, parts...)
+	if err != nil {
+		errs = append(errs, &FieldError{Field: "Letter", Err: err})
+	}
+	if len(errs) > 0 {
+		return DTG{}, errs
+	}
+	return DTG{Time: time.Date(f.Year, f.Month, f.Day, f.Hour, f.Minute, 0, 0, loc)}, nil
+}
+
+// New constructs a DTG from its components in the order they are written,
+// e.g. New(15, 12, 30, "Z", time.December, 2019) for 151230ZDEC19, with
+// the validation of FromFields. A year below 100 is the two digit year of a
+// DTG, 69-99 being 1969-1999 and 00-68 2000-2068 (see Parser.CenturyPivot).
+func New(day, hour, minute int, letter string, month time.Month, year int) (DTG, error) {
+	return (*Parser)(nil).New(day, hour, minute, letter, month, year)
+}
+
+// New is like the package level New, but uses the Parser's zone table.
+func (p *Parser) New(day, hour, minute int, letter string, month time.Month, year int) (DTG, error) {
+	if year >= 0 && year < 100 {
+		year = p.century(year)
+	}
+	return p.FromFields(Fields{Year: year, Month: month, Day: day, Hour: hour, Minute: minute, Letter: letter})
+}
+
+// ToFields returns the parts of dtg as written by String, for populating
+// GUI date pickers.
+func ToFields(dtg DTG) Fields {
+	return Fields{
+		Year:   dtg.Year(),
+		Month:  dtg.Month(),
+		Day:    dtg.Day(),
+		Hour:   dtg.Hour(),
+		Minute: dtg.Minute(),
+		Letter: dtg.ZoneLetter(),
+	}
+}
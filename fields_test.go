@@ -0,0 +1,95 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromFields(t *testing.T) {
+	tests := []struct {
+		fields   Fields
+		expected string
+	}{
+		{Fields{2019, time.December, 15, 12, 30, "Z"}, "151230ZDEC19"},
+		{Fields{2020, time.February, 29, 0, 0, "b"}, "290000BFEB20"},
+		{Fields{2068, time.December, 31, 23, 59, "Y"}, "312359YDEC68"},
+		{Fields{1969, time.January, 1, 0, 0, "M"}, "010000MJAN69"},
+	}
+	for _, test := range tests {
+		d, err := FromFields(test.fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, d)
+		}
+		if f := ToFields(d); f.Letter != strings.ToUpper(test.fields.Letter) || f.Day != test.fields.Day || f.Year != test.fields.Year {
+			t.Errorf("Expected ToFields to return %+v, but got %+v", test.fields, f)
+		}
+	}
+	p := &Parser{Location: time.FixedZone("", 5*3600+1800)}
+	d, err := p.FromFields(Fields{2019, time.December, 15, 12, 30, "J"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := p.Parse("151230JDEC19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Equal(parsed.Time) || d.Format("-0700") != "+0530" {
+		t.Errorf("Expected %s at +0530, but got %s", parsed.Time, d.Time)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		day, hour, minute int
+		letter            string
+		month             time.Month
+		year              int
+		expected          string
+	}{
+		{15, 12, 30, "Z", time.December, 2019, "151230ZDEC19"},
+		{15, 12, 30, "b", time.December, 19, "151230BDEC19"},
+		{1, 0, 0, "M", time.January, 69, "010000MJAN69"},
+		{31, 23, 59, "Y", time.December, 68, "312359YDEC68"},
+		{29, 0, 0, "A", time.February, 0, "290000AFEB00"},
+	}
+	for _, test := range tests {
+		d, err := New(test.day, test.hour, test.minute, test.letter, test.month, test.year)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, d)
+		}
+	}
+	_, err := New(29, 24, 0, "Ö", time.February, 19)
+	var errs FieldErrors
+	if !errors.As(err, &errs) || len(errs) != 3 || errs.Field("Day") == nil || errs.Field("Hour") == nil || errs.Field("Letter") == nil {
+		t.Errorf("Expected Day, Hour and Letter errors, but got %v", err)
+	}
+}
+
+func TestFromFieldsErrors(t *testing.T) {
+	_, err := FromFields(Fields{2019, time.February, 29, 24, 30, "Ö"})
+	var errs FieldErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected FieldErrors, but got %v", err)
+	}
+	if len(errs) != 3 || errs.Field("Day") == nil || errs.Field("Hour") == nil || errs.Field("Letter") == nil || errs.Field("Minute") != nil {
+		t.Errorf("Expected errors for Day, Hour and Letter, but got %v", errs)
+	}
+	if !errors.Is(errs.Field("Day"), ErrFieldRange) || !errors.Is(errs.Field("Letter"), ErrInvalidTimeZoneLetter) {
+		t.Errorf("Expected ErrFieldRange and ErrInvalidTimeZoneLetter, but got %v", errs)
+	}
+	expected := "invalid day: 29 out of range (1-28), invalid hour: 24 out of range (0-23), invalid letter: invalid time zone letter"
+	if err.Error() != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, err)
+	}
+	if _, err := FromFields(Fields{2069, 13, 1, 0, 0, ""}); len(err.(FieldErrors)) != 2 {
+		t.Errorf("Expected errors for Year and Month, but got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package dtg
+
+import (
+	"io"
+	"sync"
+)
+
+// Formatter prints Date Time Groups using a configurable zone table. The
+// zero value (and a nil *Formatter) produces the same output as
+// DTG.String().
+type Formatter struct {
+	// Zones is the zone designator table, DefaultZoneTable() when nil.
+	Zones *ZoneTable
+	// Fallback selects the designator of offsets without one in Zones, see
+	// Fallback.
+	Fallback Fallback
+}
+
+// Fallback selects what a Formatter prints when the offset of a DTG has no
+// designator, e.g. +0545 (Nepal) or +1300 (Tonga).
+type Fallback int
+
+const (
+	// FallbackTruncate truncates the offset to whole hours towards UTC and
+	// uses J if there is no designator for that either (+0545 is E, +1300
+	// J). This is the zero value and what String does.
+	FallbackTruncate Fallback = iota
+	// FallbackNearest rounds the offset to the nearest hour, see
+	// PolicyNearest, and uses J if there is no designator for that either
+	// (+0545 is F).
+	FallbackNearest
+	// FallbackNumeric prints the numeric offset instead of a designator,
+	// e.g. 151230+0545DEC19. Such a DTG can not be parsed.
+	FallbackNumeric
+	// FallbackError makes FormatE return ErrNotWholeHour or
+	// ErrNoZoneLetter. Format then falls back to FallbackTruncate.
+	FallbackError
+)
+
+func (f *Formatter) fallback() Fallback {
+	if f == nil {
+		return FallbackTruncate
+	}
+	return f.Fallback
+}
+
+func (f *Formatter) zones() *ZoneTable {
+	if f == nil || f.Zones == nil {
+		return defaultZones
+	}
+	return f.Zones
+}
+
+// Format returns the Date Time Group of dtg. The designator is the one in
+// the zone table matching the offset of dtg.Time. If no designator matches
+// exactly, the Fallback applies: by default the offset is truncated to
+// whole hours towards UTC and looked up again, and if that fails too, the
+// local time zone letter J is used.
+func (f *Formatter) Format(dtg DTG) string {
+	var buf [dtgBufferSize]byte
+	return string(f.append(buf[:0], dtg, groupCompact))
+}
+
+// FormatE is like Format, but returns ErrNotWholeHour or ErrNoZoneLetter
+// when the Fallback is FallbackError and the offset has no designator.
+func (f *Formatter) FormatE(dtg DTG) (string, error) {
+	if f.fallback() == FallbackError {
+		_, offset := dtg.Time.Zone()
+		if _, err := f.zones().letterForOffset(offset, PolicyExact); err != nil {
+			return "", err
+		}
+	}
+	return f.Format(dtg), nil
+}
+
+// dtgBufferSize fits any Date Time Group, ddHHMM, a designator with an
+// asterisk (or a numeric offset, see FallbackNumeric) and MMMYY.
+const dtgBufferSize int = 16
+
+// grouping selects the spaces a Formatter writes between the groups of a
+// Date Time Group.
+type grouping int
+
+const (
+	// groupCompact has no spaces, 151230ZDEC19.
+	groupCompact grouping = iota
+	// groupACP121 separates the month and year, 151230Z DEC 19.
+	groupACP121
+	// groupSpaced also separates the day, 15 1230Z DEC 19.
+	groupSpaced
+)
+
+// append appends the Date Time Group of dtg to b with the spaces of g, see
+// Format.
+func (f *Formatter) append(b []byte, dtg DTG, g grouping) []byte {
+	n := len(b)
+	b = dtg.Time.AppendFormat(b, `021504`)
+	if g == groupSpaced {
+		b = append(b, 0)
+		copy(b[n+3:], b[n+2:n+6])
+		b[n+2] = ' '
+	}
+	b = append(b, f.designator(dtg)...)
+	spaced := g != groupCompact
+	if spaced {
+		b = append(b, ' ')
+	}
+	b = dtg.Time.AppendFormat(b, `Jan`)
+	// Upper case the month, Jan is always ASCII.
+	for i := len(b) - 2; i < len(b); i++ {
+		b[i] -= 'a' - 'A'
+	}
+	if spaced {
+		b = append(b, ' ')
+	}
+	return dtg.Time.AppendFormat(b, `06`)
+}
+
+// bufferPool holds the buffers of DTG.WriteTo.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, dtgBufferSize)
+		return &b
+	},
+}
+
+// WriteTo writes the Date Time Group (as returned by String) to w, using a
+// pooled buffer so that high volume writers, e.g. log encoders, do not
+// allocate a string per DTG. It implements io.WriterTo.
+func (dtg DTG) WriteTo(w io.Writer) (int64, error) {
+	bp := bufferPool.Get().(*[]byte)
+	b := (*Formatter)(nil).append((*bp)[:0], dtg, groupCompact)
+	n, err := w.Write(b)
+	*bp = b
+	bufferPool.Put(bp)
+	return int64(n), err
+}
+
+func (f *Formatter) designator(dtg DTG) string {
+	_, offset := dtg.Time.Zone()
+	policy := PolicyTruncate
+	switch f.fallback() {
+	case FallbackNearest:
+		policy = PolicyNearest
+	case FallbackNumeric:
+		policy = PolicyExact
+	}
+	designator, err := f.zones().letterForOffset(offset, policy)
+	switch {
+	case err == nil:
+		return designator
+	case f.fallback() == FallbackNumeric:
+		return numericTimeZone(offset)
+	}
+	return "J"
+}
@@ -0,0 +1,101 @@
+package dtg
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestFormatter_Format(t *testing.T) {
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	zones.Remove("R")
+	f := &Formatter{Zones: zones}
+	p := &Parser{Zones: zones}
+
+	testTable := []struct {
+		offset   int
+		expected string
+		fallback string
+	}{
+		{4*3600 + 1800, `151200D*DEC19`, `151200DDEC19`},
+		{5*3600 + 1800, `151200EDEC19`, `151200EDEC19`},
+		{-5 * 3600, `151200JDEC19`, `151200RDEC19`},
+		{-5*3600 - 1800, `151200JDEC19`, `151200RDEC19`},
+		{13 * 3600, `151200JDEC19`, `151200JDEC19`},
+		{0, `151200ZDEC19`, `151200ZDEC19`},
+	}
+	for _, v := range testTable {
+		dtg := DTG{time.Date(2019, time.December, 15, 12, 0, 0, 0, time.FixedZone("", v.offset))}
+		if s := f.Format(dtg); s != v.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", v.expected, s)
+		}
+		if s := dtg.String(); s != v.fallback {
+			t.Errorf("Expected String() to give \"%s\", but got \"%s\"", v.fallback, s)
+		}
+	}
+	dtg, err := p.Parse(`010000D*JAN20`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := f.Format(dtg); s != `010000D*JAN20` {
+		t.Errorf("Expected round trip to give \"010000D*JAN20\", but got \"%s\"", s)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	var b bytes.Buffer
+	for _, s := range []string{"151230ZDEC19", "010000DJAN20", "311159YMAY21"} {
+		b.Reset()
+		n, err := mustParse(t, s).WriteTo(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != s || n != int64(len(s)) {
+			t.Errorf("Expected \"%s\" (%d bytes), but got \"%s\" (%d bytes)", s, len(s), b.String(), n)
+		}
+	}
+	d := mustParse(t, "151230ZDEC19")
+	if allocs := testing.AllocsPerRun(100, func() { d.WriteTo(io.Discard) }); allocs != 0 {
+		t.Errorf("Expected WriteTo not to allocate, but got %v allocations", allocs)
+	}
+}
+
+func TestFormatterFallback(t *testing.T) {
+	instant := time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)
+	nepal := DTG{Time: instant.In(time.FixedZone("NPT", 5*3600+2700))}
+	tonga := DTG{Time: instant.In(time.FixedZone("TOT", 13*3600))}
+	zulu := DTG{Time: instant}
+	tests := []struct {
+		fallback Fallback
+		dtg      DTG
+		expected string
+	}{
+		{FallbackTruncate, nepal, "151815EDEC19"},
+		{FallbackTruncate, tonga, "160130JDEC19"},
+		{FallbackNearest, nepal, "151815FDEC19"},
+		{FallbackNearest, tonga, "160130JDEC19"},
+		{FallbackNumeric, nepal, "151815+0545DEC19"},
+		{FallbackNumeric, tonga, "160130+1300DEC19"},
+		{FallbackNumeric, zulu, "151230ZDEC19"},
+		{FallbackError, nepal, "151815EDEC19"},
+	}
+	for _, test := range tests {
+		if s := (&Formatter{Fallback: test.fallback}).Format(test.dtg); s != test.expected {
+			t.Errorf("Expected \"%s\" with fallback %d, but got \"%s\"", test.expected, test.fallback, s)
+		}
+	}
+	if s, err := nepal.StringE(); err != ErrNotWholeHour || s != "" {
+		t.Errorf("Expected %v, but got \"%s\" (%v)", ErrNotWholeHour, s, err)
+	}
+	if _, err := tonga.StringE(); err != ErrNoZoneLetter {
+		t.Errorf("Expected %v, but got %v", ErrNoZoneLetter, err)
+	}
+	if s, err := zulu.StringE(); err != nil || s != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\" (%v)", s, err)
+	}
+	if s, err := (&Formatter{}).FormatE(nepal); err != nil || s != "151815EDEC19" {
+		t.Errorf("Expected \"151815EDEC19\", but got \"%s\" (%v)", s, err)
+	}
+}
@@ -0,0 +1,103 @@
+package dtg
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNoZoneLetter error = errors.New("no zone letter for the UTC offset")
+	ErrNotWholeHour error = errors.New("UTC offset is not a whole hour, no zone letter")
+)
+
+// LetterFor returns the zone letter of the UTC offset of t, e.g. B for
+// Eastern European Time, for stamping outgoing messages. Offsets that are
+// not a whole hour return ErrNotWholeHour and whole hours without a letter
+// (beyond ±12 hours) ErrNoZoneLetter. J is never returned, to stamp local
+// time use J as is.
+func LetterFor(t time.Time) (string, error) {
+	return (*Parser)(nil).LetterFor(t)
+}
+
+// LetterFor is like the package level LetterFor, but looks up the offset
+// in the Parser's zone table, e.g. D* for +0430 if defined.
+func (p *Parser) LetterFor(t time.Time) (string, error) {
+	_, offset := t.Zone()
+	return p.LetterForOffset(offset, PolicyExact)
+}
+
+// Policy selects how LetterForOffset handles offsets that are not a whole
+// hour.
+type Policy int
+
+const (
+	// PolicyExact returns ErrNotWholeHour.
+	PolicyExact Policy = iota
+	// PolicyNearest rounds to the nearest hour, half hours away from UTC
+	// (+0430 is E, -0930 is W).
+	PolicyNearest
+	// PolicyTruncate truncates towards UTC (+0545 is E), as String does.
+	PolicyTruncate
+)
+
+// LetterForOffset returns the zone letter of offset seconds east of UTC.
+// Offsets that are not a whole hour, e.g. Afghanistan (+0430) and Nepal
+// (+0545), are handled according to policy. Whole hours without a letter
+// return ErrNoZoneLetter.
+func LetterForOffset(offset int, policy Policy) (string, error) {
+	return (*Parser)(nil).LetterForOffset(offset, policy)
+}
+
+// LetterForOffset is like the package level LetterForOffset, but uses the
+// Parser's zone table. A designator in the table for the exact offset,
+// e.g. D* for +0430, is returned regardless of policy.
+func (p *Parser) LetterForOffset(offset int, policy Policy) (string, error) {
+	return p.zones().letterForOffset(offset, policy)
+}
+
+func (zones *ZoneTable) letterForOffset(offset int, policy Policy) (string, error) {
+	if letter, ok := zones.Designator(offset); ok {
+		return letter, nil
+	}
+	if offset%3600 != 0 {
+		switch policy {
+		case PolicyNearest:
+			if offset < 0 {
+				offset = -((-offset + 1800) / 3600 * 3600)
+			} else {
+				offset = (offset + 1800) / 3600 * 3600
+			}
+		case PolicyTruncate:
+			offset = offset / 3600 * 3600
+		default:
+			return "", ErrNotWholeHour
+		}
+		if letter, ok := zones.Designator(offset); ok {
+			return letter, nil
+		}
+	}
+	return "", ErrNoZoneLetter
+}
+
+// FromTime wraps t in a DTG after checking that its UTC offset has a zone
+// letter, so that String prints the letter of the actual offset. Offsets
+// that are not a whole hour, e.g. +0530, return ErrNotWholeHour and whole
+// hours without a letter, e.g. +1300, ErrNoZoneLetter (as LetterFor does),
+// as String would print a truncated offset or J (see Format). Convert such
+// times to UTC (or another zone) first, e.g. FromTime(t.UTC()).
+func FromTime(t time.Time) (DTG, error) {
+	return (*Parser)(nil).FromTime(t)
+}
+
+// FromTime is like the package level FromTime, but looks up the offset in
+// the Parser's zone table.
+func (p *Parser) FromTime(t time.Time) (DTG, error) {
+	_, offset := t.Zone()
+	if _, ok := p.zones().Designator(offset); !ok {
+		if offset%3600 != 0 {
+			return DTG{}, ErrNotWholeHour
+		}
+		return DTG{}, ErrNoZoneLetter
+	}
+	return DTG{Time: t}, nil
+}
@@ -0,0 +1,102 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLetterFor(t *testing.T) {
+	instant := time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		offset   int
+		expected string
+		err      error
+	}{
+		{0, "Z", nil},
+		{2 * 3600, "B", nil},
+		{-12 * 3600, "Y", nil},
+		{12 * 3600, "M", nil},
+		{4*3600 + 1800, "", ErrNotWholeHour},
+		{5*3600 + 2700, "", ErrNotWholeHour},
+		{14 * 3600, "", ErrNoZoneLetter},
+	}
+	for _, test := range tests {
+		letter, err := LetterFor(instant.In(time.FixedZone("", test.offset)))
+		if letter != test.expected || err != test.err {
+			t.Errorf("Expected \"%s\" (%v) for offset %d, but got \"%s\" (%v)", test.expected, test.err, test.offset, letter, err)
+		}
+	}
+	zones := DefaultZoneTable().Clone()
+	if err := zones.Set("D*", 4*3600+1800); err != nil {
+		t.Fatal(err)
+	}
+	if letter, err := (&Parser{Zones: zones}).LetterFor(instant.In(time.FixedZone("", 4*3600+1800))); letter != "D*" || err != nil {
+		t.Errorf("Expected \"D*\", but got \"%s\" (%v)", letter, err)
+	}
+}
+
+func TestLetterForOffset(t *testing.T) {
+	tests := []struct {
+		offset   int
+		policy   Policy
+		expected string
+		err      error
+	}{
+		{3600, PolicyExact, "A", nil},
+		{4*3600 + 1800, PolicyExact, "", ErrNotWholeHour},
+		{4*3600 + 1800, PolicyNearest, "E", nil},
+		{4*3600 + 1800, PolicyTruncate, "D", nil},
+		{5*3600 + 2700, PolicyNearest, "F", nil},
+		{5*3600 + 2700, PolicyTruncate, "E", nil},
+		{-(9*3600 + 1800), PolicyNearest, "W", nil},
+		{-(9*3600 + 1800), PolicyTruncate, "V", nil},
+		{-(3*3600 + 1200), PolicyNearest, "P", nil},
+		{12*3600 + 2700, PolicyTruncate, "M", nil},
+		{12*3600 + 2700, PolicyNearest, "", ErrNoZoneLetter},
+		{14 * 3600, PolicyNearest, "", ErrNoZoneLetter},
+	}
+	for _, test := range tests {
+		letter, err := LetterForOffset(test.offset, test.policy)
+		if letter != test.expected || err != test.err {
+			t.Errorf("Expected \"%s\" (%v) for offset %d with policy %d, but got \"%s\" (%v)", test.expected, test.err, test.offset, test.policy, letter, err)
+		}
+	}
+}
+
+func TestFromTime(t *testing.T) {
+	instant := time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		offset   int
+		expected string
+		err      error
+	}{
+		{0, "151230ZDEC19", nil},
+		{3600, "151330ADEC19", nil},
+		{-10 * 3600, "150230WDEC19", nil},
+		{12 * 3600, "160030MDEC19", nil},
+		{5*3600 + 1800, "", ErrNotWholeHour},
+		{13 * 3600, "", ErrNoZoneLetter},
+	}
+	for _, test := range tests {
+		d, err := FromTime(instant.In(time.FixedZone("", test.offset)))
+		if err != test.err {
+			t.Errorf("Expected %v for offset %d, but got %v", test.err, test.offset, err)
+			continue
+		}
+		if err == nil && (d.String() != test.expected || !d.Time.Equal(instant)) {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, d)
+		}
+	}
+	zones := DefaultZoneTable().Clone()
+	if err := zones.Set("E*", 5*3600+1800); err != nil {
+		t.Fatal(err)
+	}
+	p := &Parser{Zones: zones}
+	d, err := p.FromTime(instant.In(time.FixedZone("IST", 5*3600+1800)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := (&Formatter{Zones: zones}).Format(d); s != "151800E*DEC19" {
+		t.Errorf("Expected \"151800E*DEC19\", but got \"%s\"", s)
+	}
+}
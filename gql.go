@@ -0,0 +1,55 @@
+package dtg
+
+import (
+	"errors"
+	"io"
+	"strconv"
+)
+
+var ErrInvalidGQLValue error = errors.New("DTG scalar must be a string")
+
+// GQLMarshaler has the same method set as gqlgen's graphql.Marshaler, which
+// values returned by MarshalDTG can be assigned to.
+type GQLMarshaler interface {
+	MarshalGQL(w io.Writer)
+}
+
+// MarshalGQL writes the DTG as a GraphQL string, implementing gqlgen's
+// graphql.Marshaler. Together with UnmarshalGQL this allows mapping a DTG
+// scalar directly to this type in gqlgen.yml:
+//
+//	models:
+//	  DTG:
+//	    model: github.com/sa6mwa/dtg.DTG
+func (dtg DTG) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(dtg.String()))
+}
+
+// UnmarshalGQL parses a GraphQL input value into the DTG, implementing
+// gqlgen's graphql.Unmarshaler. Only strings are accepted.
+func (dtg *DTG) UnmarshalGQL(v interface{}) error {
+	d, err := UnmarshalDTG(v)
+	if err != nil {
+		return err
+	}
+	*dtg = d
+	return nil
+}
+
+// MarshalDTG is the marshal function of a gqlgen custom scalar bound with
+// functions instead of methods.
+func MarshalDTG(dtg DTG) GQLMarshaler {
+	return dtg
+}
+
+// UnmarshalDTG is the unmarshal function of a gqlgen custom scalar, it
+// validates and parses a GraphQL input value that must be a string.
+func UnmarshalDTG(v interface{}) (DTG, error) {
+	switch value := v.(type) {
+	case string:
+		return Parse(value)
+	case []byte:
+		return Parse(string(value))
+	}
+	return DTG{}, ErrInvalidGQLValue
+}
@@ -0,0 +1,35 @@
+package dtg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarshalDTG(t *testing.T) {
+	for _, v := range testVectors {
+		d, err := UnmarshalDTG(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b bytes.Buffer
+		MarshalDTG(d).MarshalGQL(&b)
+		if expected := `"` + v.Canonical + `"`; b.String() != expected {
+			t.Errorf("Expected %s, but got %s", expected, b.String())
+		}
+		var u DTG
+		if err := u.UnmarshalGQL(v.Canonical); err != nil {
+			t.Fatal(err)
+		}
+		if !u.Time.Equal(v.Instant) {
+			t.Errorf("Expected %s, but got %s", v.Instant, u.Time.UTC())
+		}
+	}
+	for _, invalid := range []interface{}{42, nil, true, `441200Z`, map[string]interface{}{}} {
+		if _, err := UnmarshalDTG(invalid); err == nil {
+			t.Errorf("Expected to fail on %#v, but succeeded", invalid)
+		}
+	}
+	if _, err := UnmarshalDTG(12); err != ErrInvalidGQLValue {
+		t.Errorf("Expected %v, but got %v", ErrInvalidGQLValue, err)
+	}
+}
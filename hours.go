@@ -0,0 +1,58 @@
+package dtg
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidClock error = errors.New("invalid time of day, expected HHMM")
+
+// InWorkingHours reports whether dtg falls within the working hours start
+// until end (HHMM, e.g. "0800" and "1700") when written with zone letter,
+// so that schedulers can avoid assigning tasks at 0300 local for a station.
+// The end is exclusive. An end before the start wraps past midnight, e.g.
+// "2200" until "0600" for a night shift, and equal start and end means the
+// whole day. J is the local time zone.
+func InWorkingHours(dtg DTG, letter, start, end string) (bool, error) {
+	from, err := parseClock(start)
+	if err != nil {
+		return false, err
+	}
+	until, err := parseClock(end)
+	if err != nil {
+		return false, err
+	}
+	t, err := timeIn(dtg, letter)
+	if err != nil {
+		return false, err
+	}
+	return inHours(t, from, until), nil
+}
+
+// inHours reports whether the time of day of t is from until (minutes past
+// midnight), wrapping past midnight when until is before from.
+func inHours(t time.Time, from, until int) bool {
+	minute := t.Hour()*60 + t.Minute()
+	switch {
+	case from == until:
+		return true
+	case from < until:
+		return minute >= from && minute < until
+	default:
+		return minute >= from || minute < until
+	}
+}
+
+// parseClock parses HHMM into minutes past midnight. 2400 is accepted as
+// the end of the day.
+func parseClock(s string) (int, error) {
+	if len(s) != 4 || !isDigits(s) {
+		return 0, ErrInvalidClock
+	}
+	hour := int(s[0]-'0')*10 + int(s[1]-'0')
+	minute := int(s[2]-'0')*10 + int(s[3]-'0')
+	if minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, ErrInvalidClock
+	}
+	return hour*60 + minute, nil
+}
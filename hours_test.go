@@ -0,0 +1,41 @@
+package dtg
+
+import "testing"
+
+func TestInWorkingHours(t *testing.T) {
+	tests := []struct {
+		dtg        string
+		letter     string
+		start, end string
+		expected   bool
+	}{
+		{"151230ZDEC19", "A", "0800", "1700", true},
+		{"151600ZDEC19", "A", "0800", "1700", false},
+		{"150700ZDEC19", "Z", "0700", "1600", true},
+		{"150300ZDEC19", "M", "0800", "1700", true},
+		{"150300ZDEC19", "w", "0800", "1700", false},
+		{"152300ZDEC19", "Z", "2200", "0600", true},
+		{"150530ZDEC19", "Z", "2200", "0600", true},
+		{"150600ZDEC19", "Z", "2200", "0600", false},
+		{"151200ZDEC19", "Z", "2200", "0600", false},
+		{"152359ZDEC19", "Z", "0800", "2400", true},
+		{"150300ZDEC19", "Z", "0000", "0000", true},
+	}
+	for _, test := range tests {
+		got, err := InWorkingHours(mustParse(t, test.dtg), test.letter, test.start, test.end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.expected {
+			t.Errorf("Expected %s in %s to be %t for %s-%s, but got %t", test.dtg, test.letter, test.expected, test.start, test.end, got)
+		}
+	}
+	for _, invalid := range [][2]string{{"800", "1700"}, {"0800", "2401"}, {"0860", "1700"}, {"08:0", "1700"}} {
+		if _, err := InWorkingHours(mustParse(t, "151230ZDEC19"), "Z", invalid[0], invalid[1]); err != ErrInvalidClock {
+			t.Errorf("Expected %v for %s-%s, but got %v", ErrInvalidClock, invalid[0], invalid[1], err)
+		}
+	}
+	if _, err := InWorkingHours(mustParse(t, "151230ZDEC19"), "Ö", "0800", "1700"); err != ErrInvalidTimeZoneLetter {
+		t.Errorf("Expected %v, but got %v", ErrInvalidTimeZoneLetter, err)
+	}
+}
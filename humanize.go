@@ -0,0 +1,124 @@
+package dtg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Unit is a unit of time in a humanized phrase.
+type Unit int
+
+const (
+	UnitMinute Unit = iota
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+	unitCount
+)
+
+// Catalogue holds the strings of Humanize for a language. Future and Past
+// are fmt formats for the amount, e.g. "in %s" and "%s ago". Units holds
+// the singular and plural of each Unit.
+type Catalogue struct {
+	Now    string
+	Future string
+	Past   string
+	Units  [unitCount][2]string
+}
+
+var (
+	cataloguesMu sync.RWMutex
+	catalogues   = map[string]*Catalogue{
+		"en": {
+			Now: "now", Future: "in %s", Past: "%s ago",
+			Units: [unitCount][2]string{
+				{"minute", "minutes"}, {"hour", "hours"}, {"day", "days"},
+				{"week", "weeks"}, {"month", "months"}, {"year", "years"},
+			},
+		},
+		"sv": {
+			Now: "nu", Future: "om %s", Past: "för %s sedan",
+			Units: [unitCount][2]string{
+				{"minut", "minuter"}, {"timme", "timmar"}, {"dag", "dagar"},
+				{"vecka", "veckor"}, {"månad", "månader"}, {"år", "år"},
+			},
+		},
+	}
+)
+
+// RegisterCatalogue adds or replaces the catalogue of a language, e.g. "de"
+// or "no", for Humanize.
+func RegisterCatalogue(language string, c *Catalogue) {
+	cataloguesMu.Lock()
+	defer cataloguesMu.Unlock()
+	catalogues[strings.ToLower(language)] = c
+}
+
+// catalogue returns the catalogue of locale ("sv", "sv-SE" or "sv_SE"),
+// English if there is none.
+func catalogue(locale string) *Catalogue {
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	cataloguesMu.RLock()
+	defer cataloguesMu.RUnlock()
+	if c, ok := catalogues[language]; ok {
+		return c
+	}
+	return catalogues["en"]
+}
+
+// Humanize returns a short phrase telling how far dtg is from now, e.g. "in
+// 2 hours" or "3 days ago" (or "om 2 timmar" and "för 3 dagar sedan" in
+// Swedish), to accompany the strict DTG in user interfaces. The amount is
+// rounded to the nearest unit; anything within half a minute is "now".
+// English and Swedish are built in, see RegisterCatalogue for others.
+// Unknown locales fall back to English.
+func Humanize(dtg DTG, now time.Time, locale string) string {
+	c := catalogue(locale)
+	d := dtg.Time.Sub(now)
+	format := c.Future
+	if d < 0 {
+		d, format = -d, c.Past
+	}
+	n, unit := humanAmount(d)
+	if n == 0 {
+		return c.Now
+	}
+	name := c.Units[unit][1]
+	if n == 1 {
+		name = c.Units[unit][0]
+	}
+	return fmt.Sprintf(format, fmt.Sprintf("%d %s", n, name))
+}
+
+// humanAmount returns d in the largest unit it makes sense in.
+func humanAmount(d time.Duration) (int, Unit) {
+	round := func(unit time.Duration) int {
+		return int(math.Round(float64(d) / float64(unit)))
+	}
+	const (
+		day   = 24 * time.Hour
+		year  = 31556952 * time.Second // 365.2425 days
+		month = year / 12
+	)
+	switch {
+	case round(time.Minute) < 60:
+		return round(time.Minute), UnitMinute
+	case round(time.Hour) < 24:
+		return round(time.Hour), UnitHour
+	case round(day) < 7:
+		return round(day), UnitDay
+	case round(day) < 30:
+		return round(7 * day), UnitWeek
+	case round(month) < 12:
+		return round(month), UnitMonth
+	}
+	return round(year), UnitYear
+}
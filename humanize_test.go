@@ -0,0 +1,40 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanize(t *testing.T) {
+	now := time.Date(2019, 12, 15, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		offset time.Duration
+		locale string
+		want   string
+	}{
+		{0, "en", "now"},
+		{20 * time.Second, "en", "now"},
+		{time.Minute, "en", "in 1 minute"},
+		{-45 * time.Minute, "en", "45 minutes ago"},
+		{2*time.Hour + 15*time.Minute, "en", "in 2 hours"},
+		{-3 * 24 * time.Hour, "en", "3 days ago"},
+		{10 * 24 * time.Hour, "en", "in 1 week"},
+		{-90 * 24 * time.Hour, "en", "3 months ago"},
+		{800 * 24 * time.Hour, "en", "in 2 years"},
+		{0, "sv", "nu"},
+		{2 * time.Hour, "sv-SE", "om 2 timmar"},
+		{-time.Hour, "sv_SE", "för 1 timme sedan"},
+		{-3 * 24 * time.Hour, "sv", "för 3 dagar sedan"},
+		{2 * time.Hour, "xx", "in 2 hours"},
+	}
+	for _, test := range tests {
+		got := Humanize(DTG{Time: now.Add(test.offset)}, now, test.locale)
+		if got != test.want {
+			t.Errorf("Expected \"%s\" for %s (%s), but got \"%s\"", test.want, test.offset, test.locale, got)
+		}
+	}
+	RegisterCatalogue("DE", &Catalogue{Now: "jetzt", Future: "in %s", Past: "vor %s", Units: [unitCount][2]string{UnitHour: {"Stunde", "Stunden"}}})
+	if got := Humanize(DTG{Time: now.Add(2 * time.Hour)}, now, "de-AT"); got != "in 2 Stunden" {
+		t.Errorf("Expected \"in 2 Stunden\", but got \"%s\"", got)
+	}
+}
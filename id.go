@@ -0,0 +1,76 @@
+package dtg
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+var ErrIDRange error = errors.New("DTG outside the UUIDv7 time range (1970 to 10889)")
+
+// ID is a time ordered identifier in UUIDv7 layout (RFC 9562): the first 48
+// bits are the Unix time in milliseconds of a DTG, followed by the version,
+// 74 bits of random or hashed data and the variant. IDs compare (as bytes
+// or as strings) in the order of their DTGs, so records keyed by ID keep
+// their DTG order.
+type ID [16]byte
+
+// NewIDAt returns a new ID stamped with the instant of dtg and random bits
+// from crypto/rand.
+func NewIDAt(dtg DTG) (ID, error) {
+	var id ID
+	if _, err := rand.Read(id[6:]); err != nil {
+		return ID{}, err
+	}
+	return id.stamp(dtg)
+}
+
+// HashIDAt returns an ID stamped with the instant of dtg whose other bits
+// are a SHA-256 hash of data, so the same record at the same DTG always
+// gets the same ID, e.g. when importing the same messages again.
+func HashIDAt(dtg DTG, data []byte) (ID, error) {
+	var id ID
+	sum := sha256.Sum256(data)
+	copy(id[6:], sum[:])
+	return id.stamp(dtg)
+}
+
+// stamp sets the timestamp, version and variant of id.
+func (id ID) stamp(dtg DTG) (ID, error) {
+	ms := dtg.Time.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return ID{}, ErrIDRange
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(ms))
+	copy(id[:6], b[2:])
+	id[6] = id[6]&0x0f | 0x70
+	id[8] = id[8]&0x3f | 0x80
+	return id, nil
+}
+
+// DTG returns the instant of the ID, in Zulu, truncated to milliseconds.
+func (id ID) DTG() DTG {
+	var b [8]byte
+	copy(b[2:], id[:6])
+	return DTG{Time: time.UnixMilli(int64(binary.BigEndian.Uint64(b[:]))).UTC()}
+}
+
+// String returns the ID in the canonical UUID form,
+// xxxxxxxx-xxxx-7xxx-xxxx-xxxxxxxxxxxx.
+func (id ID) String() string {
+	var s [36]byte
+	hex.Encode(s[0:8], id[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], id[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], id[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], id[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], id[10:])
+	return string(s[:])
+}
@@ -0,0 +1,50 @@
+package dtg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewIDAt(t *testing.T) {
+	earlier, err := NewIDAt(mustParse(t, "151230ZDEC19"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	later, err := NewIDAt(mustParse(t, "151431BDEC19"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(earlier[:], later[:]) >= 0 || earlier.String() >= later.String() {
+		t.Errorf("Expected %s to sort before %s", earlier, later)
+	}
+	if !strings.HasPrefix(earlier.String(), "016f0989-7140-7") {
+		t.Errorf("Expected %s to begin with the 151230ZDEC19 timestamp and version 7", earlier)
+	}
+	if v := earlier[8] >> 6; v != 2 {
+		t.Errorf("Expected the RFC variant, but got %b", v)
+	}
+	if d := later.DTG(); d.String() != "151231ZDEC19" {
+		t.Errorf("Expected \"151131ZDEC19\", but got \"%s\"", d)
+	}
+	other, _ := NewIDAt(mustParse(t, "151230ZDEC19"))
+	if other == earlier {
+		t.Errorf("Expected random IDs to differ, but got %s twice", other)
+	}
+	if _, err := NewIDAt(mustParse(t, "311200ZDEC69")); err != ErrIDRange {
+		t.Errorf("Expected ErrIDRange, but got %v", err)
+	}
+}
+
+func TestHashIDAt(t *testing.T) {
+	d := mustParse(t, "151230ZDEC19")
+	a, _ := HashIDAt(d, []byte("SITREP 1"))
+	b, _ := HashIDAt(d, []byte("SITREP 1"))
+	c, _ := HashIDAt(d, []byte("SITREP 2"))
+	if a != b || a == c {
+		t.Errorf("Expected equal IDs for equal data only, but got %s, %s and %s", a, b, c)
+	}
+	if a.DTG().String() != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\"", a.DTG())
+	}
+}
@@ -0,0 +1,126 @@
+// Package ntp is a minimal SNTP (RFC 4330) client used to measure the local
+// clock against a network time server. It is internal to the dtg module and
+// only implements what the command line tools need: a single unicast query
+// returning the server time, the local clock offset and the round-trip delay.
+package ntp
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"time"
+)
+
+var (
+	ErrShortPacket     error = errors.New("ntp: short packet")
+	ErrOriginMismatch  error = errors.New("ntp: originate timestamp mismatch")
+	ErrKissOfDeath     error = errors.New("ntp: server sent kiss-o'-death (stratum 0)")
+	ErrUnsynchronized  error = errors.New("ntp: server clock is unsynchronized")
+	ErrInvalidResponse error = errors.New("ntp: response is not a server reply")
+)
+
+const (
+	// DefaultServer is queried when no server is given.
+	DefaultServer string = "pool.ntp.org"
+	// DefaultTimeout bounds the whole query including name resolution.
+	DefaultTimeout time.Duration = 5 * time.Second
+
+	packetSize int = 48
+	// Seconds between the NTP epoch (1900-01-01) and the Unix epoch.
+	ntpEpochOffset int64 = 2208988800
+	// LI=0 (no warning), VN=4, Mode=3 (client).
+	clientHeader byte = 0<<6 | 4<<3 | 3
+	modeServer   byte = 4
+	liAlarm      byte = 3
+)
+
+// Response is the result of a single SNTP exchange.
+type Response struct {
+	// Time is the server's transmit time.
+	Time time.Time
+	// ClockOffset is how much the local clock must be adjusted to match the
+	// server (positive means the local clock is behind).
+	ClockOffset time.Duration
+	// RTT is the round-trip delay excluding the server's processing time.
+	RTT     time.Duration
+	Stratum uint8
+}
+
+// Query performs one SNTP request against server (host or host:port, port 123
+// is assumed when omitted). An empty server queries DefaultServer and a zero
+// timeout uses DefaultTimeout.
+func Query(server string, timeout time.Duration) (Response, error) {
+	if server == "" {
+		server = DefaultServer
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+	conn, err := net.DialTimeout("udp", server, timeout)
+	if err != nil {
+		return Response{}, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return Response{}, err
+	}
+	request := make([]byte, packetSize)
+	request[0] = clientHeader
+	t1 := time.Now()
+	binary.BigEndian.PutUint64(request[40:], toNtpTime(t1))
+	if _, err := conn.Write(request); err != nil {
+		return Response{}, err
+	}
+	response := make([]byte, packetSize)
+	n, err := conn.Read(response)
+	t4 := time.Now()
+	if err != nil {
+		return Response{}, err
+	}
+	return decode(response[:n], request[40:48], t1, t4)
+}
+
+// decode validates a server reply and computes offset and delay using the
+// usual four timestamps (t1 client transmit, t2 server receive, t3 server
+// transmit, t4 client receive).
+func decode(packet []byte, origin []byte, t1, t4 time.Time) (Response, error) {
+	if len(packet) < packetSize {
+		return Response{}, ErrShortPacket
+	}
+	if packet[0]&0x07 != modeServer {
+		return Response{}, ErrInvalidResponse
+	}
+	if string(packet[24:32]) != string(origin) {
+		return Response{}, ErrOriginMismatch
+	}
+	stratum := packet[1]
+	if stratum == 0 {
+		return Response{}, ErrKissOfDeath
+	}
+	if packet[0]>>6 == liAlarm {
+		return Response{}, ErrUnsynchronized
+	}
+	t2 := fromNtpTime(binary.BigEndian.Uint64(packet[32:]))
+	t3 := fromNtpTime(binary.BigEndian.Uint64(packet[40:]))
+	return Response{
+		Time:        t3,
+		ClockOffset: (t2.Sub(t1) + t3.Sub(t4)) / 2,
+		RTT:         t4.Sub(t1) - t3.Sub(t2),
+		Stratum:     stratum,
+	}, nil
+}
+
+func toNtpTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+func fromNtpTime(v uint64) time.Time {
+	seconds := int64(v>>32) - ntpEpochOffset
+	nanoseconds := int64(((v & 0xffffffff) * uint64(time.Second)) >> 32)
+	return time.Unix(seconds, nanoseconds)
+}
@@ -0,0 +1,66 @@
+package ntp
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeServer answers one SNTP request with a clock that is skew ahead of the
+// local clock.
+func fakeServer(t *testing.T, skew time.Duration, stratum byte) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	go func() {
+		buf := make([]byte, packetSize)
+		_, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		now := toNtpTime(time.Now().Add(skew))
+		reply := make([]byte, packetSize)
+		reply[0] = 4<<3 | modeServer
+		reply[1] = stratum
+		copy(reply[24:32], buf[40:48])
+		binary.BigEndian.PutUint64(reply[32:], now)
+		binary.BigEndian.PutUint64(reply[40:], now)
+		conn.WriteTo(reply, addr)
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestQuery(t *testing.T) {
+	skew := 3 * time.Second
+	resp, err := Query(fakeServer(t, skew, 2), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := resp.ClockOffset - skew; d > 100*time.Millisecond || d < -100*time.Millisecond {
+		t.Errorf("Expected clock offset close to %s, but got %s", skew, resp.ClockOffset)
+	}
+	if resp.Stratum != 2 {
+		t.Errorf("Expected stratum 2, but got %d", resp.Stratum)
+	}
+	_, err = Query(fakeServer(t, 0, 0), time.Second)
+	if err != ErrKissOfDeath {
+		t.Errorf("Expected %v, but got %v", ErrKissOfDeath, err)
+	}
+}
+
+func TestNtpTimeRoundTrip(t *testing.T) {
+	for _, ts := range []time.Time{
+		time.Unix(0, 0),
+		time.Date(2019, 12, 15, 23, 59, 0, 500000000, time.UTC),
+		time.Date(2031, 4, 15, 13, 37, 0, 0, time.UTC),
+	} {
+		got := fromNtpTime(toNtpTime(ts))
+		if d := got.Sub(ts); d > time.Microsecond || d < -time.Microsecond {
+			t.Errorf("Expected %s, but got %s", ts, got)
+		}
+	}
+}
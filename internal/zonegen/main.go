@@ -0,0 +1,171 @@
+// Command zonegen converts zones.csv, the single source of the ACP 121 zone
+// letter table, into the Go table and documentation (zones_gen.go) and a
+// JSON export (zones.json) for other languages. It is run by go generate in
+// the root of the module:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	sourceFile string = "zones.csv"
+	goFile     string = "zones_gen.go"
+	jsonFile   string = "zones.json"
+)
+
+// zone is one row of zones.csv.
+type zone struct {
+	Letter   string `json:"letter"`
+	Offset   int    `json:"offset"`
+	Numeric  string `json:"numeric"`
+	Phonetic string `json:"phonetic"`
+	Examples string `json:"examples"`
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("zonegen: ")
+	f, err := os.Open(sourceFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	zones, err := parse(f)
+	f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := goSource(zones)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(goFile, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	js, err := jsonExport(zones)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(jsonFile, js, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse reads the zone table, skipping #-comments.
+func parse(r io.Reader) ([]zone, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 4
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	zones := make([]zone, 0, len(records))
+	for _, record := range records {
+		z := zone{
+			Letter:   strings.TrimSpace(record[0]),
+			Numeric:  strings.TrimSpace(record[1]),
+			Phonetic: strings.TrimSpace(record[2]),
+			Examples: strings.TrimSpace(record[3]),
+		}
+		if !validLetter(z.Letter) {
+			return nil, fmt.Errorf("invalid letter %q", z.Letter)
+		}
+		if seen[z.Letter] {
+			return nil, fmt.Errorf("duplicate letter %q", z.Letter)
+		}
+		seen[z.Letter] = true
+		if z.Offset, err = parseNumeric(z.Numeric); err != nil {
+			return nil, fmt.Errorf("letter %q: %w", z.Letter, err)
+		}
+		zones = append(zones, z)
+	}
+	return zones, nil
+}
+
+// validLetter reports whether s is a letter A-Z, except J (local time),
+// optionally followed by an asterisk.
+func validLetter(s string) bool {
+	if len(s) < 1 || len(s) > 2 || s[0] < 'A' || s[0] > 'Z' || s[0] == 'J' {
+		return false
+	}
+	return len(s) == 1 || s[1] == '*'
+}
+
+// parseNumeric converts a -0700 style offset into seconds east of UTC.
+func parseNumeric(s string) (int, error) {
+	if len(s) != 5 || (s[0] != '+' && s[0] != '-') {
+		return 0, errors.New("offset must be in the form +hhmm or -hhmm")
+	}
+	hours, err := strconv.Atoi(s[1:3])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(s[3:5])
+	if err != nil {
+		return 0, err
+	}
+	offset := hours*3600 + minutes*60
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// docOffset formats an offset the way the documentation always has, e.g.
+// UTC-12, UTC+-0 or UTC+4:30.
+func docOffset(offset int) string {
+	if offset == 0 {
+		return "UTC+-0"
+	}
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	s := fmt.Sprintf("UTC%s%d", sign, offset/3600)
+	if m := offset % 3600 / 60; m != 0 {
+		s += fmt.Sprintf(":%02d", m)
+	}
+	return s
+}
+
+func goSource(zones []zone) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by zonegen from %s; DO NOT EDIT.\n\n", sourceFile)
+	fmt.Fprintf(&b, "package dtg\n\n")
+	fmt.Fprintf(&b, "// DefaultZoneTable returns the read-only ACP 121 zone letter table used by\n")
+	fmt.Fprintf(&b, "// Parse, GetNumericTimeZone and DTG.String(). J is not in the table as it\n")
+	fmt.Fprintf(&b, "// denotes the local time zone.\n//\n")
+	for _, z := range zones {
+		fmt.Fprintf(&b, "// %s: %s (%s)\n", docOffset(z.Offset), z.Letter, z.Examples)
+	}
+	fmt.Fprintf(&b, "func DefaultZoneTable() *ZoneTable {\n\treturn defaultZones\n}\n\n")
+	fmt.Fprintf(&b, "var defaultZoneData = []zoneData{\n")
+	for _, z := range zones {
+		fmt.Fprintf(&b, "\t{%q, %d, %q, %q},\n", z.Letter, z.Offset, z.Phonetic, z.Examples)
+	}
+	fmt.Fprintf(&b, "}\n")
+	return format.Source(b.Bytes())
+}
+
+func jsonExport(zones []zone) ([]byte, error) {
+	js, err := json.MarshalIndent(zones, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(js, '\n'), nil
+}
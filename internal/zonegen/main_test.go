@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate fails when zones.csv was edited without running
+// go generate, so the representations of the zone table cannot drift.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	f, err := os.Open(filepath.Join(root, sourceFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zones, err := parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zones) != 25 {
+		t.Errorf("Expected 25 zones in %s, but got %d", sourceFile, len(zones))
+	}
+	src, err := goSource(zones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := jsonExport(zones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string][]byte{goFile: src, jsonFile: js} {
+		actual, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(expected, actual) {
+			t.Errorf("Expected %s to match %s, run go generate", name, sourceFile)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	invalid := []string{
+		"J,+0100,Juliet,local",
+		"AB,+0100,Alpha,France",
+		"A,0100,Alpha,France",
+		"A,+0100,Alpha,France\nA,+0200,Alpha,France",
+		"A,+01x0,Alpha,France",
+	}
+	for _, input := range invalid {
+		if _, err := parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected to fail on \"%s\", but succeeded", input)
+		}
+	}
+	zones, err := parse(strings.NewReader("# comment\nD*,+0430,Delta,Afghanistan\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zones) != 1 || zones[0].Offset != 16200 {
+		t.Errorf("Expected one zone with offset 16200, but got %v", zones)
+	}
+	for offset, expected := range map[int]string{0: "UTC+-0", -43200: "UTC-12", 16200: "UTC+4:30", 3600: "UTC+1"} {
+		if s := docOffset(offset); s != expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected, s)
+		}
+	}
+}
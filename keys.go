@@ -0,0 +1,120 @@
+package dtg
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var ErrInvalidKey error = errors.New("invalid DTG key")
+
+// KeySize is the length of keys produced by EncodeKey.
+const KeySize int = 10
+
+const (
+	messageKeyLayout  string = `20060102T1504Z`
+	headerValueLayout string = `20060102T1504Z-0700`
+)
+
+// MessageKey returns a fixed-width key of the DTG normalized to Zulu time,
+// e.g. 20191215T2359Z, suitable as a Kafka or NATS message key. Keys sort
+// lexicographically in the same order as the instants they represent (for
+// years 0000 to 9999), so streams partitioned or compacted by key stay in
+// DTG order. The original zone is not preserved, see HeaderValue.
+func MessageKey(dtg DTG) string {
+	return dtg.Time.UTC().Format(messageKeyLayout)
+}
+
+// ParseMessageKey parses a key produced by MessageKey into a Zulu DTG.
+func ParseMessageKey(key string) (DTG, error) {
+	t, err := time.Parse(messageKeyLayout, key)
+	if err != nil || len(key) != len(messageKeyLayout) {
+		return DTG{}, ErrInvalidKey
+	}
+	return DTG{Time: t}, nil
+}
+
+// HeaderValue returns a fixed-width header value consisting of the
+// MessageKey followed by the numeric offset of the original zone, e.g.
+// 20191215T2159Z+0200. It sorts like MessageKey, but ParseHeaderValue
+// restores the DTG in its original zone so String() prints the original
+// zone letter.
+func HeaderValue(dtg DTG) string {
+	_, offset := dtg.Time.Zone()
+	return MessageKey(dtg) + numericTimeZone(offset)
+}
+
+// ParseHeaderValue parses a header value produced by HeaderValue.
+func ParseHeaderValue(value string) (DTG, error) {
+	if len(value) != len(headerValueLayout) {
+		return DTG{}, ErrInvalidKey
+	}
+	zulu, err := ParseMessageKey(value[:len(messageKeyLayout)])
+	if err != nil {
+		return DTG{}, err
+	}
+	zone, err := time.Parse(numericTimeZoneLayout, value[len(messageKeyLayout):])
+	if err != nil {
+		return DTG{}, ErrInvalidKey
+	}
+	_, offset := zone.Zone()
+	return DTG{Time: zulu.Time.In(time.FixedZone(numericTimeZone(offset), offset))}, nil
+}
+
+// SortKey returns a canonical string of the DTG beginning with the Zulu
+// normalized MessageKey followed by # and the zone letter String would
+// print, e.g. 20191215T2359Z#Z or 20191215T2159Z#B, for file names and text
+// columns of databases without a native timestamp type. Keys sort
+// lexicographically by instant. They are 16 characters, 17 for designators
+// with an asterisk. ParseSortKey restores the DTG in its zone.
+func (dtg DTG) SortKey() string {
+	return MessageKey(dtg) + "#" + (*Formatter)(nil).designator(dtg)
+}
+
+// ParseSortKey parses a key produced by DTG.SortKey. The zone letter is
+// resolved like in Parse, J being the local time zone.
+func ParseSortKey(key string) (DTG, error) {
+	if len(key) < len(messageKeyLayout)+2 || key[len(messageKeyLayout)] != '#' {
+		return DTG{}, ErrInvalidKey
+	}
+	zulu, err := ParseMessageKey(key[:len(messageKeyLayout)])
+	if err != nil {
+		return DTG{}, err
+	}
+	// Resolve J at the instant of the key rather than now, in case of DST.
+	t := zulu.Time.Local()
+	loc, err := defaultZones.location(key[len(messageKeyLayout)+1:], t.Format(dayLayout), t.Format(hourLayout), t.Format(minuteLayout), t.Format(monthLayout), t.Format(yearLayout))
+	if err != nil {
+		return DTG{}, ErrInvalidKey
+	}
+	return DTG{Time: zulu.Time.In(loc)}, nil
+}
+
+// EncodeKey returns a binary key for embedded key-value stores such as
+// BoltDB or Badger. The first 8 bytes are the Unix time in seconds with the
+// sign bit flipped, big-endian, so byte-wise comparison of keys orders them
+// by instant (also before 1970) and range scans between two encoded DTGs
+// work. The last 2 bytes hold the zone offset in minutes, so DecodeKey
+// restores the original zone. Keys are KeySize bytes long.
+func EncodeKey(dtg DTG) []byte {
+	key := make([]byte, KeySize)
+	binary.BigEndian.PutUint64(key, uint64(dtg.Time.Unix())^(1<<63))
+	_, offset := dtg.Time.Zone()
+	binary.BigEndian.PutUint16(key[8:], uint16(int16(offset/60))^(1<<15))
+	return key
+}
+
+// DecodeKey decodes a key produced by EncodeKey. Only the leading KeySize
+// bytes must be present, anything after (e.g. a record ID appended to make
+// keys unique) is ignored.
+func DecodeKey(key []byte) (DTG, error) {
+	if len(key) < KeySize {
+		return DTG{}, ErrInvalidKey
+	}
+	seconds := int64(binary.BigEndian.Uint64(key) ^ (1 << 63))
+	offset := int(int16(binary.BigEndian.Uint16(key[8:])^(1<<15))) * 60
+	if offset < -maxZoneOffset || offset > maxZoneOffset {
+		return DTG{}, ErrInvalidKey
+	}
+	return DTG{Time: time.Unix(seconds, 0).In(time.FixedZone(numericTimeZone(offset), offset))}, nil
+}
@@ -0,0 +1,154 @@
+package dtg
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMessageKey(t *testing.T) {
+	var keys, headers []string
+	for _, v := range testVectors {
+		d, err := Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := MessageKey(d)
+		if len(key) != 14 {
+			t.Errorf("Expected a 14 character key, but got \"%s\"", key)
+		}
+		k, err := ParseMessageKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !k.Time.Equal(v.Instant) || k.String()[6] != 'Z' {
+			t.Errorf("Expected key \"%s\" to be %s in Zulu, but got %s", key, v.Instant, k)
+		}
+		header := HeaderValue(d)
+		if len(header) != 19 {
+			t.Errorf("Expected a 19 character header value, but got \"%s\"", header)
+		}
+		h, err := ParseHeaderValue(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.String() != v.Canonical {
+			t.Errorf("Expected header value \"%s\" to give \"%s\", but got \"%s\"", header, v.Canonical, h)
+		}
+		keys = append(keys, key)
+		headers = append(headers, header)
+	}
+	sortedByInstant := make([]TestVector, len(testVectors))
+	copy(sortedByInstant, testVectors)
+	sort.SliceStable(sortedByInstant, func(i, j int) bool { return sortedByInstant[i].Instant.Before(sortedByInstant[j].Instant) })
+	sort.Strings(keys)
+	sort.Strings(headers)
+	for i, v := range sortedByInstant {
+		if k, _ := ParseMessageKey(keys[i]); !k.Time.Equal(v.Instant) {
+			t.Errorf("Expected key %d in sorted order to be %s, but got %s", i, v.Instant, k.Time)
+		}
+		if h, _ := ParseHeaderValue(headers[i]); !h.Time.Equal(v.Instant) {
+			t.Errorf("Expected header value %d in sorted order to be %s, but got %s", i, v.Instant, h.Time)
+		}
+	}
+	for _, invalid := range []string{``, `20191215T2359`, `20191215T2359Z+02`, `20191315T2359Z`, `20191215T2359ZZ0200`, `20191215T2359Z`} {
+		if _, err := ParseHeaderValue(invalid); err != ErrInvalidKey {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrInvalidKey, invalid, err)
+		}
+	}
+	if _, err := ParseMessageKey(`2019-12-15T2359Z`); err != ErrInvalidKey {
+		t.Errorf("Expected %v, but got %v", ErrInvalidKey, err)
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	var keys []string
+	for _, v := range testVectors {
+		d, err := Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := d.SortKey()
+		k, err := ParseSortKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k.String() != v.Canonical {
+			t.Errorf("Expected sort key \"%s\" to give \"%s\", but got \"%s\"", key, v.Canonical, k)
+		}
+		keys = append(keys, key)
+	}
+	if key := mustParse(t, "152359ZDEC19").SortKey(); key != "20191215T2359Z#Z" {
+		t.Errorf("Expected \"20191215T2359Z#Z\", but got \"%s\"", key)
+	}
+	if key := mustParse(t, "152359BDEC19").SortKey(); key != "20191215T2159Z#B" {
+		t.Errorf("Expected \"20191215T2159Z#B\", but got \"%s\"", key)
+	}
+	sort.Strings(keys)
+	for i := 1; i < len(keys); i++ {
+		a, _ := ParseSortKey(keys[i-1])
+		b, _ := ParseSortKey(keys[i])
+		if a.Time.After(b.Time) {
+			t.Errorf("Expected \"%s\" to sort after \"%s\"", keys[i-1], keys[i])
+		}
+	}
+	for _, invalid := range []string{``, `20191215T2359Z`, `20191215T2359Z#`, `20191215T2359ZZB`, `20191215T2359Z#Ö`, `20191315T2359Z#Z`} {
+		if _, err := ParseSortKey(invalid); err != ErrInvalidKey {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrInvalidKey, invalid, err)
+		}
+	}
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skip(err)
+	}
+	local := time.Local
+	time.Local = stockholm
+	defer func() { time.Local = local }()
+	for key, offset := range map[string]string{"20191215T1200Z#J": "+0100", "20190715T1200Z#J": "+0200"} {
+		if d, err := ParseSortKey(key); err != nil || d.Format("-0700") != offset {
+			t.Errorf("Expected \"%s\" at %s, but got %s (%v)", key, offset, d.Time, err)
+		}
+	}
+}
+
+func TestEncodeKey(t *testing.T) {
+	var keys [][]byte
+	for _, v := range testVectors {
+		d, err := Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := EncodeKey(d)
+		if len(key) != KeySize {
+			t.Errorf("Expected a %d byte key, but got %d bytes", KeySize, len(key))
+		}
+		k, err := DecodeKey(append(key, "suffix"...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k.String() != v.Canonical || !k.Time.Equal(v.Instant) {
+			t.Errorf("Expected decoded key to be \"%s\", but got \"%s\"", v.Canonical, k)
+		}
+		keys = append(keys, key)
+	}
+	before1970 := DTG{time.Date(1912, time.April, 15, 2, 20, 0, 0, time.UTC)}
+	keys = append(keys, EncodeKey(before1970))
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	for i := 1; i < len(keys); i++ {
+		a, _ := DecodeKey(keys[i-1])
+		b, _ := DecodeKey(keys[i])
+		if a.Time.After(b.Time) {
+			t.Errorf("Expected byte order to follow instants, but %s sorted before %s", a.Time.UTC(), b.Time.UTC())
+		}
+	}
+	if k, _ := DecodeKey(keys[0]); !k.Time.Equal(before1970.Time) {
+		t.Errorf("Expected %s to sort first, but got %s", before1970.Time, k.Time)
+	}
+	if _, err := DecodeKey([]byte{1, 2, 3}); err != ErrInvalidKey {
+		t.Errorf("Expected %v, but got %v", ErrInvalidKey, err)
+	}
+	if _, err := DecodeKey(make([]byte, KeySize)); err != ErrInvalidKey {
+		t.Errorf("Expected %v for an out of range offset, but got %v", ErrInvalidKey, err)
+	}
+}
@@ -0,0 +1,23 @@
+package dtg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// lenient returns s without spaces, dashes and other punctuation (except *)
+// in upper case, and an errorAt for the characters of s, see
+// Parser.Lenient.
+func lenient(s string) (string, errorAt) {
+	var b strings.Builder
+	at := errorAt{input: s, positions: []int{}}
+	i := 0
+	for _, r := range s {
+		if r == '*' || !(unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)) {
+			b.WriteRune(unicode.ToUpper(r))
+			at.positions = append(at.positions, i)
+		}
+		i++
+	}
+	return b.String(), at
+}
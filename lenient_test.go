@@ -0,0 +1,52 @@
+package dtg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	for _, s := range []string{`15 1200Z DEC 19`, `151200Z/DEC/19`, `151200z dec 19.`, `15-12-00 Z-DEC-19`, ` 151200Z, DEC 19;`} {
+		d, err := Parse(s, WithLenient())
+		if err != nil {
+			t.Errorf("Expected \"%s\" to parse, but got %v", s, err)
+			continue
+		}
+		if d.String() != "151200ZDEC19" {
+			t.Errorf("Expected \"151200ZDEC19\" for \"%s\", but got \"%s\"", s, d)
+		}
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Expected \"%s\" not to parse without WithLenient", s)
+		}
+	}
+	zones := DefaultZoneTable().Clone()
+	if err := zones.Set("D*", 4*3600+1800); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := Parse(`15 1200D* dec 19`, WithLenient(), WithZones(zones)); err != nil || !d.Equal(mustParse(t, "150730ZDEC19").Time) {
+		t.Errorf("Expected 150730ZDEC19, but got \"%s\" (%v)", d, err)
+	}
+	if _, err := Parse(`15 1200 dec 19`, WithLenient(), WithStrict()); !errors.Is(err, ErrNotStrict) {
+		t.Errorf("Expected %v, but got %v", ErrNotStrict, err)
+	}
+	if d, err := Parse(`15 1200z dec 19`, WithLenient(), WithStrict()); err != nil || d.String() != "151200ZDEC19" {
+		t.Errorf("Expected \"151200ZDEC19\", but got \"%s\" (%v)", d, err)
+	}
+	tests := []struct {
+		input    string
+		field    string
+		pos, end int
+	}{
+		{`15 1200Ö DEC 19`, "Letter", 7, 8},
+		{`15 2500Z / DEC / 19`, "Hour", 3, 5},
+		{`15 1200Z DEX 19`, "Month", 9, 12},
+		{`15 12`, "Minute", 5, 5},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.input, WithLenient())
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Field != test.field || parseErr.Pos != test.pos || parseErr.End != test.end {
+			t.Errorf("Expected %s %d-%d for \"%s\", but got %#v", test.field, test.pos, test.end, test.input, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package dtg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Severity is the severity of a Finding. The values are those of the
+// Language Server Protocol's DiagnosticSeverity.
+type Severity int
+
+const (
+	SeverityError       Severity = 1
+	SeverityWarning     Severity = 2
+	SeverityInformation Severity = 3
+	SeverityHint        Severity = 4
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInformation:
+		return "info"
+	case SeverityHint:
+		return "hint"
+	}
+	return "unknown"
+}
+
+// Finding is a problem with a DTG in a document, found by Lint.
+type Finding struct {
+	// Start and End are the byte offsets of the DTG in the document, Text
+	// the DTG as written.
+	Start, End int
+	Text       string
+	Severity   Severity
+	Message    string
+	// Fix is the suggested replacement of Text, empty if there is none.
+	Fix string
+}
+
+// Lint checks the DTGs of a document, e.g. a message being drafted, and
+// reports:
+//
+//   - DTG-like groups that are not valid DTGs (441200Z), as errors
+//   - DTGs not in upper case, as warnings
+//   - DTGs without month or year, which the reader has to infer, as
+//     information with the DTG completed as of now as the fix
+//   - local time (J) DTGs, whose offset depends on the reader, as hints
+//
+// Findings are in order of appearance, several may refer to the same DTG.
+func Lint(text string) []Finding {
+	return (*Parser)(nil).Lint(text)
+}
+
+// Lint is like the package level Lint, but uses the Parser's zone table.
+func (p *Parser) Lint(text string) []Finding {
+	var findings []Finding
+	for _, loc := range TextRegexp.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		for end > start && !isDtgByte(text[end-1]) {
+			end--
+		}
+		written := text[start:end]
+		finding := func(severity Severity, message, fix string) {
+			findings = append(findings, Finding{Start: start, End: end, Text: written, Severity: severity, Message: message, Fix: fix})
+		}
+		details, err := p.ParseDetailed(strings.ReplaceAll(written, " ", ""))
+		if err != nil {
+			finding(SeverityError, "invalid DTG: "+err.Error(), "")
+			continue
+		}
+		if strings.IndexFunc(written, unicode.IsLower) >= 0 {
+			finding(SeverityWarning, "DTG should be written in upper case", strings.ToUpper(written))
+		}
+		if !details.ExplicitMonth || !details.ExplicitYear {
+			finding(SeverityInformation, "DTG without month or year, the reader has to infer them", details.DTG.String())
+		}
+		if details.Designator == "J" {
+			finding(SeverityHint, "local time (J) DTG, the offset depends on where it is read", "")
+		}
+	}
+	return findings
+}
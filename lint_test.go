@@ -0,0 +1,36 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	text := "R 151230ZDEC19 FM HQ\nH-HOUR 441200Z, PL ALPHA 160730zdec19.\nNLT 171200ZDEC19 OR 181200JDEC19"
+	expected := []struct {
+		text     string
+		severity Severity
+		fix      string
+	}{
+		{"441200Z", SeverityError, ""},
+		{"160730zdec19", SeverityWarning, "160730ZDEC19"},
+		{"181200JDEC19", SeverityHint, ""},
+	}
+	findings := Lint(text)
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected %d findings, but got %d: %+v", len(expected), len(findings), findings)
+	}
+	for i, f := range findings {
+		if f.Text != expected[i].text || f.Severity != expected[i].severity || f.Fix != expected[i].fix {
+			t.Errorf("Expected %s %s (fix \"%s\"), but got %s %s (fix \"%s\"): %s", expected[i].severity, expected[i].text, expected[i].fix, f.Severity, f.Text, f.Fix, f.Message)
+		}
+		if text[f.Start:f.End] != f.Text {
+			t.Errorf("Expected offsets %d:%d to give \"%s\", but got \"%s\"", f.Start, f.End, f.Text, text[f.Start:f.End])
+		}
+	}
+	if findings := Lint("151230Z"); len(findings) != 1 || findings[0].Severity != SeverityInformation || len(findings[0].Fix) != 12 {
+		t.Errorf("Expected a finding about the missing month and year with a complete DTG as fix, but got %+v", findings)
+	}
+	if SeverityWarning.String() != "warning" {
+		t.Errorf("Expected \"warning\", but got \"%s\"", SeverityWarning)
+	}
+}
@@ -0,0 +1,85 @@
+package logformats
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// maxJournalField limits the size of binary fields read by ReadJournal.
+const maxJournalField uint64 = 64 << 20
+
+// Entry is an entry of journald export format (journalctl -o export).
+type Entry struct {
+	// DTG is the __REALTIME_TIMESTAMP of the entry in UTC.
+	DTG dtg.DTG
+	// Fields holds all fields of the entry, including the timestamp.
+	Fields map[string]string
+}
+
+// ParseJournalTimestamp converts a journald __REALTIME_TIMESTAMP value,
+// microseconds since the Unix epoch, into a DTG in UTC.
+func ParseJournalTimestamp(value string) (dtg.DTG, error) {
+	usec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return dtg.DTG{}, ErrNotJournal
+	}
+	return dtg.DTG{Time: time.UnixMicro(usec).UTC()}, nil
+}
+
+// ReadJournal reads journald export format from r and calls fn for every
+// entry. Both text fields (NAME=value) and binary fields (NAME, a 64-bit
+// little endian size and the data) are supported. Entries without a
+// __REALTIME_TIMESTAMP fail with ErrNotJournal. Returning an error from fn
+// stops reading.
+func ReadJournal(r io.Reader, fn func(Entry) error) error {
+	br := bufio.NewReader(r)
+	fields := map[string]string{}
+	flush := func() error {
+		if len(fields) == 0 {
+			return nil
+		}
+		d, err := ParseJournalTimestamp(fields["__REALTIME_TIMESTAMP"])
+		if err != nil {
+			return err
+		}
+		entry := Entry{DTG: d, Fields: fields}
+		fields = map[string]string{}
+		return fn(entry)
+	}
+	for {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return flush()
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		}
+		if name, value, ok := strings.Cut(line, "="); ok {
+			fields[name] = value
+			continue
+		}
+		// Binary field: the name is followed by the size and the data.
+		var size uint64
+		if err := binary.Read(br, binary.LittleEndian, &size); err != nil || size > maxJournalField {
+			return ErrInvalidJournal
+		}
+		data := make([]byte, size+1)
+		if _, err := io.ReadFull(br, data); err != nil || data[size] != '\n' {
+			return ErrInvalidJournal
+		}
+		fields[line] = string(data[:size])
+	}
+}
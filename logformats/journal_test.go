@@ -0,0 +1,35 @@
+package logformats
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestReadJournal(t *testing.T) {
+	var export bytes.Buffer
+	export.WriteString("__CURSOR=s=1\n__REALTIME_TIMESTAMP=1576413000000000\nMESSAGE=first\n\n")
+	export.WriteString("__REALTIME_TIMESTAMP=1576416600123456\nMESSAGE\n")
+	message := "two\nlines"
+	binary.Write(&export, binary.LittleEndian, uint64(len(message)))
+	export.WriteString(message + "\n\n")
+	var got []string
+	err := ReadJournal(&export, func(e Entry) error {
+		got = append(got, e.DTG.String()+" "+e.Fields["MESSAGE"])
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "151230ZDEC19 first|151330ZDEC19 two\nlines"
+	if strings.Join(got, "|") != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, strings.Join(got, "|"))
+	}
+	if err := ReadJournal(strings.NewReader("MESSAGE=no timestamp\n"), func(Entry) error { return nil }); err != ErrNotJournal {
+		t.Errorf("Expected ErrNotJournal, but got %v", err)
+	}
+	if err := ReadJournal(strings.NewReader("MESSAGE\n\x01"), func(Entry) error { return nil }); err != ErrInvalidJournal {
+		t.Errorf("Expected ErrInvalidJournal, but got %v", err)
+	}
+}
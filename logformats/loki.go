@@ -0,0 +1,92 @@
+package logformats
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// ParseLine returns the timestamp of a log line in any of the supported
+// formats: RFC 5424 syslog, a leading RFC 3339 timestamp (as written by
+// e.g. Docker and many applications) or RFC 3164 syslog, in that order. loc
+// and reference are used for RFC 3164 only, see ParseRFC3164.
+func ParseLine(line string, loc *time.Location, reference time.Time) (dtg.DTG, error) {
+	if d, err := ParseRFC5424(line); err != ErrNotSyslog {
+		return d, err
+	}
+	if first, _, _ := strings.Cut(line, " "); len(first) >= len("2006-01-02T15:04:05Z") {
+		if t, err := time.Parse(time.RFC3339Nano, first); err == nil {
+			return dtg.DTG{Time: t}, nil
+		}
+	}
+	return ParseRFC3164(line, loc, reference)
+}
+
+// Annotator adds the DTG of a log line's timestamp to the line for
+// ingestion into Grafana Loki, so logs can be searched with the DTG strings
+// operators already use, e.g. {job="radio"} |= "151230Z". Pipe logs through
+// `dtg loki` (or use Annotate in a custom shipper) in front of Promtail; a
+// logfmt stage can then extract the dtg field:
+//
+//	pipeline_stages:
+//	  - logfmt:
+//	      mapping:
+//	        dtg:
+//	  - labels:
+//	      dtg_letter:
+//
+// Keep the DTG itself out of the labels, a value per minute gives Loki far
+// too many streams. The labels returned by Labels have low cardinality.
+type Annotator struct {
+	// Letter is the zone letter the DTGs are written in, Z when empty.
+	// J is the local time zone.
+	Letter string
+	// Location is the zone of RFC 3164 timestamps, time.Local when nil.
+	Location *time.Location
+}
+
+// Annotate returns line prefixed with dtg=<DTG> in logfmt and true, or line
+// unchanged and false if it has no recognized timestamp.
+func (a *Annotator) Annotate(line string) (string, bool) {
+	d, err := ParseLine(line, a.Location, time.Now())
+	if err != nil {
+		return line, false
+	}
+	d, err = a.convert(d)
+	if err != nil {
+		return line, false
+	}
+	return "dtg=" + d.String() + " " + line, true
+}
+
+// Labels returns Loki labels for a DTG: dtg_letter, the zone letter it is
+// written in, and dtg_day, the day and month (e.g. 15DEC19), both of which
+// have low cardinality.
+func (a *Annotator) Labels(d dtg.DTG) (map[string]string, error) {
+	d, err := a.convert(d)
+	if err != nil {
+		return nil, err
+	}
+	s := d.String()
+	return map[string]string{
+		"dtg_letter": d.ZoneLetter(),
+		"dtg_day":    s[:2] + s[len(s)-5:],
+	}, nil
+}
+
+// convert returns d in the zone of the Annotator's letter.
+func (a *Annotator) convert(d dtg.DTG) (dtg.DTG, error) {
+	letter := strings.ToUpper(a.Letter)
+	switch letter {
+	case "":
+		return d.Zulu(), nil
+	case "J":
+		return dtg.DTG{Time: d.Time.Local()}, nil
+	}
+	loc, err := dtg.DefaultZoneTable().Location(letter)
+	if err != nil {
+		return dtg.DTG{}, err
+	}
+	return dtg.DTG{Time: d.Time.In(loc)}, nil
+}
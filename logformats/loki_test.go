@@ -0,0 +1,41 @@
+package logformats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		letter   string
+		line     string
+		expected string
+		ok       bool
+	}{
+		{"", "<34>1 2019-12-15T12:30:00+01:00 host app - - - msg", "dtg=151130ZDEC19 <34>1 2019-12-15T12:30:00+01:00 host app - - - msg", true},
+		{"b", "2019-12-15T12:30:00.123Z level=info msg=up", "dtg=151430BDEC19 2019-12-15T12:30:00.123Z level=info msg=up", true},
+		{"", "X Jan 2 10:00:00 nothing", "X Jan 2 10:00:00 nothing", false},
+		{"Ö", "2019-12-15T12:30:00Z x", "2019-12-15T12:30:00Z x", false},
+	}
+	for _, test := range tests {
+		a := &Annotator{Letter: test.letter, Location: time.UTC}
+		got, ok := a.Annotate(test.line)
+		if got != test.expected || ok != test.ok {
+			t.Errorf("Expected \"%s\" (%v), but got \"%s\" (%v)", test.expected, test.ok, got, ok)
+		}
+	}
+}
+
+func TestLabels(t *testing.T) {
+	d, err := ParseRFC5424("<34>1 2019-12-15T23:30:00Z host app - - - msg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels, err := (&Annotator{Letter: "A"}).Labels(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels["dtg_letter"] != "A" || labels["dtg_day"] != "16DEC19" {
+		t.Errorf("Expected dtg_letter A and dtg_day 16DEC19, but got %v", labels)
+	}
+}
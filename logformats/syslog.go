@@ -0,0 +1,115 @@
+// Package logformats reads the timestamps of common infrastructure log
+// formats, syslog (RFC 3164 and RFC 5424) and the journald export format,
+// and converts them to ACP 121 Date Time Groups, so that ops tooling can
+// annotate logs in the same terms as the message traffic.
+package logformats
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+var (
+	ErrNoTimestamp    error = errors.New("no timestamp in log line")
+	ErrNotSyslog      error = errors.New("not a syslog line")
+	ErrNilTimestamp   error = errors.New("syslog line has no timestamp (NILVALUE)")
+	ErrNotJournal     error = errors.New("not a journald export entry")
+	ErrInvalidJournal error = errors.New("invalid journald export format")
+)
+
+// rfc3164Layout is the classic BSD syslog timestamp, e.g. "Oct 11 22:14:15"
+// (the day is space padded).
+const rfc3164Layout string = "Jan _2 15:04:05"
+
+// ParseSyslog returns the timestamp of a syslog line in either RFC 5424 or
+// RFC 3164 format, see ParseRFC5424 and ParseRFC3164.
+func ParseSyslog(line string, loc *time.Location, reference time.Time) (dtg.DTG, error) {
+	if d, err := ParseRFC5424(line); err != ErrNotSyslog {
+		return d, err
+	}
+	return ParseRFC3164(line, loc, reference)
+}
+
+// ParseRFC5424 returns the timestamp of an RFC 5424 syslog line, e.g.
+// "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog ...". The
+// DTG keeps the offset of the timestamp.
+func ParseRFC5424(line string) (dtg.DTG, error) {
+	rest, ok := stripPriority(line)
+	if !ok {
+		return dtg.DTG{}, ErrNotSyslog
+	}
+	// VERSION SP TIMESTAMP SP ...
+	fields := strings.SplitN(rest, " ", 3)
+	if len(fields) < 2 || fields[0] == "" || !isDigits(fields[0]) {
+		return dtg.DTG{}, ErrNotSyslog
+	}
+	if fields[1] == "-" {
+		return dtg.DTG{}, ErrNilTimestamp
+	}
+	t, err := time.Parse(time.RFC3339Nano, fields[1])
+	if err != nil {
+		return dtg.DTG{}, err
+	}
+	return dtg.DTG{Time: t}, nil
+}
+
+// ParseRFC3164 returns the timestamp of a BSD (RFC 3164) syslog line, e.g.
+// "<34>Oct 11 22:14:15 mymachine su: ...". The priority is optional, as in
+// files written by syslog daemons. RFC 3164 timestamps have neither year nor
+// zone: the time is taken to be in loc (time.Local if nil) and the year is
+// the one that puts the timestamp closest to reference, so logs read just
+// after new year get the previous year.
+func ParseRFC3164(line string, loc *time.Location, reference time.Time) (dtg.DTG, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	rest, ok := stripPriority(line)
+	if !ok {
+		rest = line
+	}
+	if len(rest) < len(rfc3164Layout) {
+		return dtg.DTG{}, ErrNoTimestamp
+	}
+	t, err := time.ParseInLocation(rfc3164Layout, rest[:len(rfc3164Layout)], loc)
+	if err != nil {
+		return dtg.DTG{}, ErrNoTimestamp
+	}
+	t = t.AddDate(reference.In(loc).Year(), 0, 0)
+	for _, candidate := range []time.Time{t.AddDate(-1, 0, 0), t.AddDate(1, 0, 0)} {
+		if abs(candidate.Sub(reference)) < abs(t.Sub(reference)) {
+			t = candidate
+		}
+	}
+	return dtg.DTG{Time: t}, nil
+}
+
+// stripPriority removes the leading <PRI> of a syslog line.
+func stripPriority(line string) (string, bool) {
+	if !strings.HasPrefix(line, "<") {
+		return "", false
+	}
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 || !isDigits(line[1:end]) {
+		return "", false
+	}
+	return line[end+1:], true
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
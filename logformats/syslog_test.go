@@ -0,0 +1,33 @@
+package logformats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSyslog(t *testing.T) {
+	stockholm := time.FixedZone("CET", 3600)
+	reference := time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		line     string
+		expected string
+		err      error
+	}{
+		{"<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 - hello", "112214ZOCT03", nil},
+		{"<34>1 2019-12-15T12:30:00+01:00 host app - - - msg", "151230ADEC19", nil},
+		{"<34>1 - host app - - - msg", "", ErrNilTimestamp},
+		{"<34>Dec 31 23:59:00 mymachine su: 'su root' failed", "312359ADEC19", nil},
+		{"Jan  2 10:00:00 mymachine kernel: eth0 up", "021000AJAN20", nil},
+		{"mymachine kernel: eth0 up", "", ErrNoTimestamp},
+	}
+	for _, test := range tests {
+		d, err := ParseSyslog(test.line, stockholm, reference)
+		if err != test.err {
+			t.Errorf("Expected error %v for \"%s\", but got %v", test.err, test.line, err)
+			continue
+		}
+		if err == nil && d.String() != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, d)
+		}
+	}
+}
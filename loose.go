@@ -0,0 +1,202 @@
+package dtg
+
+import (
+	"strings"
+	"unicode"
+)
+
+var (
+	numberWords = map[string]int{
+		"zero": 0, "oh": 0, "o": 0, "nil": 0,
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+		"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+		"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18,
+		"nineteen": 19,
+	}
+	tensWords = map[string]int{
+		"twenty": 20, "thirty": 30, "forty": 40, "fourty": 40, "fifty": 50,
+		"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+	phoneticLetters = map[string]string{
+		"alpha": "A", "alfa": "A", "bravo": "B", "charlie": "C",
+		"delta": "D", "echo": "E", "foxtrot": "F", "golf": "G",
+		"hotel": "H", "india": "I", "juliet": "J", "juliett": "J",
+		"local": "J", "kilo": "K", "lima": "L", "mike": "M",
+		"november": "N", "oscar": "O", "papa": "P", "quebec": "Q",
+		"romeo": "R", "sierra": "S", "tango": "T", "uniform": "U",
+		"victor": "V", "whiskey": "W", "whisky": "W", "xray": "X",
+		"yankee": "Y", "zulu": "Z",
+	}
+	monthWords = map[string]string{
+		"january": "JAN", "february": "FEB", "march": "MAR", "april": "APR",
+		"may": "MAY", "june": "JUN", "july": "JUL", "august": "AUG",
+		"september": "SEP", "october": "OCT", "november": "NOV",
+		"december": "DEC", "jan": "JAN", "feb": "FEB", "mar": "MAR",
+		"apr": "APR", "jun": "JUN", "jul": "JUL", "aug": "AUG",
+		"sep": "SEP", "sept": "SEP", "oct": "OCT", "nov": "NOV",
+		"dec": "DEC",
+	}
+	fillerWords = map[string]bool{
+		"hours": true, "hrs": true, "hr": true, "time": true, "the": true,
+		"of": true, "on": true, "at": true, "and": true,
+	}
+)
+
+// ParseLoose parses Date Time Groups in spoken or transcribed form, e.g.
+// "fifteen twelve thirty zulu december nineteen", "one five one two three
+// zero zulu" or "151230 zulu dec 19". Number words, NATO phonetic letters
+// (and "local" for J), full or abbreviated month names and digits are
+// converted into a compact DTG which is then parsed strictly with Parse.
+// Words such as "hours" or "time" are ignored. A year may be spoken with the
+// century ("twenty nineteen"), only the last two digits are used. The input
+// is first passed through CorrectSpeech, so radiotelephony pronunciations
+// like "fife" and "niner" are understood as well.
+//
+// ParseLoose is deliberately separate from Parse which never guesses.
+func ParseLoose(s string) (DTG, error) {
+	return (*Parser)(nil).ParseLoose(s)
+}
+
+// ParseLoose is like the package level ParseLoose, but uses the Parser's
+// zone table.
+func (p *Parser) ParseLoose(s string) (DTG, error) {
+	compact, ok := looseToCompact(CorrectSpeech(s))
+	if !ok {
+		return DTG{}, ErrInvalidDTG
+	}
+	return p.Parse(compact)
+}
+
+// looseToCompact converts a spoken DTG into the compact ddHHMM[Z][mmm[YY]]
+// form.
+func looseToCompact(s string) (string, bool) {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "x-ray", "xray")
+	var words []string
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == ',' || r == '.' || r == '/'
+	}) {
+		words = append(words, splitDigits(field)...)
+	}
+	var digits, letter, month, year strings.Builder
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if fillerWords[w] {
+			continue
+		}
+		number, ok := spokenNumber(words, &i)
+		if !ok {
+			l, isLetter := phoneticLetters[w]
+			if !isLetter && len(w) == 1 && w[0] >= 'a' && w[0] <= 'z' {
+				l, isLetter = strings.ToUpper(w), true
+			}
+			m, isMonth := monthWords[w]
+			if isLetter && isMonth {
+				// November is both a letter and a month: it is the letter
+				// unless no other month follows.
+				isLetter = letter.Len() == 0 && month.Len() == 0 && monthFollows(words[i+1:])
+				isMonth = !isLetter
+			}
+			switch {
+			case isLetter && letter.Len() == 0 && month.Len() == 0 && digits.Len() == 6:
+				letter.WriteString(l)
+			case isMonth && month.Len() == 0 && digits.Len() == 6:
+				month.WriteString(m)
+			default:
+				return "", false
+			}
+			continue
+		}
+		switch {
+		case month.Len() > 0:
+			year.WriteString(number)
+		case letter.Len() > 0 || digits.Len()+len(number) > 6:
+			return "", false
+		default:
+			digits.WriteString(number)
+		}
+	}
+	y := year.String()
+	if len(y) == 4 {
+		y = y[2:]
+	}
+	if digits.Len() != 6 || (len(y) != 0 && len(y) != 2) {
+		return "", false
+	}
+	return digits.String() + letter.String() + month.String() + y, true
+}
+
+// spokenNumber converts the number starting at words[*i] into digits. Words
+// 0-9 are single digits, 10-19 and the tens (optionally followed by a unit
+// as in "twenty three") are two digits and "hundred" is 00. Digit strings
+// are passed through. *i is advanced past a consumed unit.
+func spokenNumber(words []string, i *int) (string, bool) {
+	w := words[*i]
+	if isDigits(w) {
+		return w, true
+	}
+	if w == "hundred" {
+		return "00", true
+	}
+	if n, ok := numberWords[w]; ok {
+		if n < 10 {
+			return string(rune('0' + n)), true
+		}
+		return twoDigits(n), true
+	}
+	if n, ok := tensWords[w]; ok {
+		if *i+1 < len(words) {
+			next := words[*i+1]
+			unit, ok := numberWords[next]
+			if len(next) == 1 && next[0] >= '1' && next[0] <= '9' {
+				unit, ok = int(next[0]-'0'), true
+			}
+			if ok && unit >= 1 && unit <= 9 && next != "o" && next != "oh" {
+				*i++
+				n += unit
+			}
+		}
+		return twoDigits(n), true
+	}
+	return "", false
+}
+
+// splitDigits splits a word where digits meet letters, so "151230z" becomes
+// "151230" and "z".
+func splitDigits(word string) []string {
+	var parts []string
+	start := 0
+	for i := 1; i < len(word); i++ {
+		if isDigits(word[i:i+1]) != isDigits(word[i-1:i]) {
+			parts = append(parts, word[start:i])
+			start = i
+		}
+	}
+	return append(parts, word[start:])
+}
+
+func monthFollows(words []string) bool {
+	for _, w := range words {
+		if _, ok := monthWords[w]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func twoDigits(n int) string {
+	return string([]rune{rune('0' + n/10), rune('0' + n%10)})
+}
@@ -0,0 +1,57 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestParseLoose(t *testing.T) {
+	testTable := []struct {
+		input    string
+		expected string
+	}{
+		{`fifteen twelve thirty zulu december nineteen`, `151230ZDEC19`},
+		{`151230 zulu dec 19`, `151230ZDEC19`},
+		{`151230Z DEC 19`, `151230ZDEC19`},
+		{`one five one two three zero zulu december one nine`, `151230ZDEC19`},
+		{`Fifteen, twelve hundred hours Bravo, June twenty twenty`, `151200BJUN20`},
+		{`oh one oh five hundred alpha january twenty-nineteen`, `010500AJAN19`},
+		{`zero one twenty three fifty nine x-ray november nineteen`, `012359XNOV19`},
+		{`thirty one twenty three fifty nine november december nineteen`, `312359NDEC19`},
+		{`ten ten ten mike`, `101010M`},
+	}
+	for _, v := range testTable {
+		compact, ok := looseToCompact(v.input)
+		if !ok || compact[:len(v.expected)] != v.expected {
+			t.Errorf("Expected \"%s\" to become \"%s\", but got \"%s\"", v.input, v.expected, compact)
+		}
+		if len(v.expected) < 12 {
+			continue
+		}
+		dtg, err := ParseLoose(v.input)
+		if err != nil {
+			t.Fatalf("Unexpected error for \"%s\": %v", v.input, err)
+		}
+		if dtg.String() != v.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", v.expected, dtg.String())
+		}
+	}
+	// November without another month is the month, not the letter.
+	if compact, _ := looseToCompact(`fifteen twelve thirty november nineteen`); compact != `151230NOV19` {
+		t.Errorf("Expected November to be the month, but got \"%s\"", compact)
+	}
+	invalid := []string{
+		``,
+		`fifteen twelve`,
+		`fifteen twelve thirty forty`,
+		`fifteen twelve thirty zulu zulu`,
+		`fifteen twelve thirty zulu december nineteen ninety nine nine`,
+		`fifteen twelve thirty zulu banana`,
+		`zulu fifteen twelve thirty`,
+		`fourty four twelve thirty zulu`,
+	}
+	for _, v := range invalid {
+		if _, err := ParseLoose(v); err == nil {
+			t.Errorf("Expected to fail on \"%s\", but succeeded", v)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package dtg
+
+// TextPosition is a zero-based line and character offset in a document as
+// in the Language Server Protocol, the character offset counting UTF-16
+// code units.
+type TextPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// TextRange is a span of a document, see TextPosition.
+type TextRange struct {
+	Start TextPosition `json:"start"`
+	End   TextPosition `json:"end"`
+}
+
+// Diagnostic is a Finding as a Language Server Protocol diagnostic. The
+// suggested fix, if any, is carried in Data for the code action request.
+type Diagnostic struct {
+	Range    TextRange       `json:"range"`
+	Severity Severity        `json:"severity"`
+	Source   string          `json:"source"`
+	Message  string          `json:"message"`
+	Data     *DiagnosticData `json:"data,omitempty"`
+}
+
+// DiagnosticData is the Data of a Diagnostic.
+type DiagnosticData struct {
+	// Fix replaces the text of the diagnostic's range.
+	Fix string `json:"fix"`
+}
+
+// Diagnostics converts the findings of Lint in text into LSP diagnostics,
+// so an editor extension can show DTG problems while a message is drafted.
+func Diagnostics(text string, findings []Finding) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0, len(findings))
+	var c positionCursor
+	for _, f := range findings {
+		d := Diagnostic{
+			Range:    TextRange{Start: c.position(text, f.Start), End: c.position(text, f.End)},
+			Severity: f.Severity,
+			Source:   "dtg",
+			Message:  f.Message,
+		}
+		if f.Fix != "" {
+			d.Data = &DiagnosticData{Fix: f.Fix}
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// positionCursor converts byte offsets into LSP Positions, continuing from
+// the previous offset when offsets increase as they do for findings.
+type positionCursor struct {
+	offset int
+	p      TextPosition
+}
+
+func (c *positionCursor) position(text string, offset int) TextPosition {
+	if offset < c.offset {
+		*c = positionCursor{}
+	}
+	for _, r := range text[c.offset:offset] {
+		switch {
+		case r == '\n':
+			c.p.Line++
+			c.p.Character = 0
+		case r >= 0x10000:
+			c.p.Character += 2
+		default:
+			c.p.Character++
+		}
+	}
+	c.offset = offset
+	return c.p
+}
@@ -0,0 +1,32 @@
+package dtg
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiagnostics(t *testing.T) {
+	text := "R 151230ZDEC19\n🙂 ÅÄÖ 441200Z AND 160730zdec19"
+	diagnostics := Diagnostics(text, Lint(text))
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics, but got %+v", diagnostics)
+	}
+	// The emoji is two UTF-16 code units, ÅÄÖ three.
+	expected := []TextRange{
+		{TextPosition{1, 7}, TextPosition{1, 14}},
+		{TextPosition{1, 19}, TextPosition{1, 31}},
+	}
+	for i, d := range diagnostics {
+		if d.Range != expected[i] {
+			t.Errorf("Expected range %+v, but got %+v", expected[i], d.Range)
+		}
+	}
+	js, err := json.Marshal(diagnostics[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"range":{"start":{"line":1,"character":19},"end":{"line":1,"character":31}},"severity":2,"source":"dtg","message":"DTG should be written in upper case","data":{"fix":"160730ZDEC19"}}`
+	if string(js) != want {
+		t.Errorf("Expected %s, but got %s", want, js)
+	}
+}
@@ -0,0 +1,144 @@
+package dtg
+
+import (
+	"container/heap"
+	"time"
+)
+
+// MergeStreams merges channels of records, each in DTG order, into one
+// channel in DTG order, e.g. to consolidate the logs of several stations
+// into one master log. It is a Merger without lateness window, see Merge.
+func MergeStreams(chans ...<-chan Record) <-chan Record {
+	return (&Merger{}).Merge(chans...)
+}
+
+// Merger merges channels of DTG stamped records chronologically, see
+// Merge. The zero value expects each channel to be in DTG order.
+type Merger struct {
+	// Lateness is how much out of DTG order records may arrive on a
+	// channel, e.g. radio relayed traffic arriving minutes late. Records
+	// are held back until every open channel has sent a record at least
+	// Lateness younger.
+	Lateness time.Duration
+	// OnLate is called (from the goroutine of Merge) with records arriving
+	// after younger records have been sent. When nil, late records are sent
+	// immediately, out of order, rather than dropped.
+	OnLate func(Record)
+}
+
+// Merge merges chans into one channel in DTG order. Records with the same
+// DTG are sent in the order of the channels. A record is sent only when
+// every open channel has sent a record at least Lateness younger, so a
+// silent station holds back the merge until it sends or closes its
+// channel. Channels are read one record at a time from the one furthest
+// behind, which bounds the buffering to the records within the window. The
+// returned channel is closed when all channels are closed.
+func (m *Merger) Merge(chans ...<-chan Record) <-chan Record {
+	out := make(chan Record)
+	go func() {
+		defer close(out)
+		n := len(chans)
+		latest := make([]time.Time, n)
+		seen := make([]bool, n)
+		open := make([]bool, n)
+		for i := range chans {
+			open[i] = true
+		}
+		pending := &mergeHeap{}
+		var last time.Time
+		sent := false
+		for {
+			// Read from the open channel furthest behind.
+			behind := -1
+			for i := range chans {
+				if !open[i] {
+					continue
+				}
+				if !seen[i] {
+					behind = i
+					break
+				}
+				if behind == -1 || latest[i].Before(latest[behind]) {
+					behind = i
+				}
+			}
+			if behind == -1 && pending.Len() == 0 {
+				return
+			}
+			if behind != -1 {
+				record, ok := <-chans[behind]
+				switch {
+				case !ok:
+					open[behind] = false
+				case sent && record.DTG.Time.Before(last):
+					if m.OnLate != nil {
+						m.OnLate(record)
+					} else {
+						out <- record
+					}
+					continue
+				default:
+					if !seen[behind] || record.DTG.Time.After(latest[behind]) {
+						latest[behind] = record.DTG.Time
+					}
+					seen[behind] = true
+					pending.seq++
+					heap.Push(pending, mergeItem{record: record, channel: behind, seq: pending.seq})
+				}
+			}
+			// Send what no open channel can precede any more.
+			for pending.Len() > 0 {
+				next := (*pending).items[0]
+				ready := true
+				for i := range chans {
+					if open[i] && (!seen[i] || latest[i].Add(-m.Lateness).Before(next.record.DTG.Time)) {
+						ready = false
+						break
+					}
+				}
+				if !ready {
+					break
+				}
+				heap.Pop(pending)
+				out <- next.record
+				last, sent = next.record.DTG.Time, true
+			}
+		}
+	}()
+	return out
+}
+
+type mergeItem struct {
+	record  Record
+	channel int
+	seq     int
+}
+
+// mergeHeap orders pending records by DTG, channel and arrival.
+type mergeHeap struct {
+	items []mergeItem
+	seq   int
+}
+
+func (h *mergeHeap) Len() int { return len(h.items) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if !a.record.DTG.Time.Equal(b.record.DTG.Time) {
+		return a.record.DTG.Time.Before(b.record.DTG.Time)
+	}
+	if a.channel != b.channel {
+		return a.channel < b.channel
+	}
+	return a.seq < b.seq
+}
+
+func (h *mergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *mergeHeap) Push(x interface{}) { h.items = append(h.items, x.(mergeItem)) }
+
+func (h *mergeHeap) Pop() interface{} {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
@@ -0,0 +1,79 @@
+package dtg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeStreams(t *testing.T) {
+	stream := func(records ...string) <-chan Record {
+		ch := make(chan Record)
+		go func() {
+			defer close(ch)
+			for _, r := range records {
+				d, _, err := ParsePrefix(r)
+				if err != nil {
+					panic(err)
+				}
+				ch <- Record{DTG: d, Text: r}
+			}
+		}()
+		return ch
+	}
+	merged := MergeStreams(
+		stream("151200ZDEC19 a1", "151400BDEC19 a2", "151300ZDEC19 a3"),
+		stream(),
+		stream("151100ZDEC19 b1", "151230ZDEC19 b2", "151500ZDEC19 b3", "151600ZDEC19 b4"),
+		stream("151400ADEC19 c1"),
+	)
+	var got []string
+	for r := range merged {
+		got = append(got, r.Text[len(r.Text)-2:])
+	}
+	if s := strings.Join(got, ","); s != "b1,a1,a2,b2,a3,c1,b3,b4" {
+		t.Errorf("Expected \"b1,a1,a2,b2,a3,c1,b3,b4\", but got \"%s\"", s)
+	}
+	if _, ok := <-MergeStreams(); ok {
+		t.Error("Expected the merge of no streams to be closed")
+	}
+}
+
+func TestMergerLateness(t *testing.T) {
+	stream := func(records ...string) <-chan Record {
+		ch := make(chan Record, len(records))
+		for _, r := range records {
+			d, _, err := ParsePrefix(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ch <- Record{DTG: d, Text: r}
+		}
+		close(ch)
+		return ch
+	}
+	var late []string
+	m := &Merger{Lateness: 10 * time.Minute, OnLate: func(r Record) { late = append(late, r.Text[len(r.Text)-2:]) }}
+	merged := m.Merge(
+		stream("151200ZDEC19 a1", "151208ZDEC19 a2", "151205ZDEC19 a3", "151230ZDEC19 a4", "151201ZDEC19 a5"),
+		stream("151203ZDEC19 b1", "151202ZDEC19 b2", "151240ZDEC19 b3"),
+	)
+	var got []string
+	for r := range merged {
+		got = append(got, r.Text[len(r.Text)-2:])
+	}
+	if s := strings.Join(got, ","); s != "a1,b2,b1,a3,a2,a4,b3" {
+		t.Errorf("Expected \"a1,b2,b1,a3,a2,a4,b3\", but got \"%s\"", s)
+	}
+	if s := strings.Join(late, ","); s != "a5" {
+		t.Errorf("Expected a5 to be late, but got \"%s\"", s)
+	}
+
+	got = nil
+	for r := range (&Merger{}).Merge(stream("151200ZDEC19 a1", "151210ZDEC19 a2", "151205ZDEC19 a3"), stream("151207ZDEC19 b1")) {
+		got = append(got, r.Text[len(r.Text)-2:])
+	}
+	if s := strings.Join(got, ","); s != "a1,b1,a2,a3" {
+		t.Errorf("Expected the late a3 to be sent out of order without OnLate, but got \"%s\"", s)
+	}
+}
@@ -0,0 +1,79 @@
+package dtg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotNMEA error = errors.New("not an NMEA 0183 RMC or ZDA sentence with a valid checksum")
+
+// ParseNMEATime returns the UTC time and date of an NMEA 0183 RMC or ZDA
+// sentence from any talker (GP, GN, ...), e.g.
+// $GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A or
+// $GPZDA,201530.00,04,07,2002,00,00*60, as a Zulu DTG including the
+// seconds of the sentence. An RMC sentence without a valid fix (status V) is
+// ErrNoGPSFix, anything else that is not a sentence with a time and date
+// ErrNotNMEA. The checksum is mandatory.
+func ParseNMEATime(sentence string) (DTG, error) {
+	sentence = strings.TrimSpace(sentence)
+	star := strings.LastIndexByte(sentence, '*')
+	if len(sentence) < 7 || sentence[0] != '$' || star < 0 {
+		return DTG{}, ErrNotNMEA
+	}
+	var sum byte
+	for i := 1; i < star; i++ {
+		sum ^= sentence[i]
+	}
+	if checksum, err := strconv.ParseUint(sentence[star+1:], 16, 8); err != nil || byte(checksum) != sum {
+		return DTG{}, ErrNotNMEA
+	}
+	fields := strings.Split(sentence[1:star], ",")
+	if len(fields[0]) != 5 {
+		return DTG{}, ErrNotNMEA
+	}
+	var clock, date string
+	switch fields[0][2:] {
+	case "RMC":
+		if len(fields) < 10 {
+			return DTG{}, ErrNotNMEA
+		}
+		if fields[2] != "A" {
+			return DTG{}, ErrNoGPSFix
+		}
+		clock, date = fields[1], fields[9]
+		if len(date) != 6 {
+			return DTG{}, ErrNotNMEA
+		}
+		// ddmmyy, the year of an RMC sentence is two digits.
+		year, _ := strconv.Atoi(date[4:])
+		year += 1900
+		if year < DefaultCenturyPivot {
+			year += 100
+		}
+		date = date[:4] + strconv.Itoa(year)
+	case "ZDA":
+		if len(fields) < 5 || len(fields[2]) != 2 || len(fields[3]) != 2 || len(fields[4]) != 4 {
+			return DTG{}, ErrNotNMEA
+		}
+		clock, date = fields[1], fields[2]+fields[3]+fields[4]
+	default:
+		return DTG{}, ErrNotNMEA
+	}
+	if len(clock) < 6 {
+		return DTG{}, ErrNotNMEA
+	}
+	t, err := time.Parse("02012006150405", date+clock[:6])
+	if err != nil {
+		return DTG{}, ErrNotNMEA
+	}
+	if len(clock) > 6 {
+		fraction, err := strconv.ParseFloat("0"+clock[6:], 64)
+		if err != nil || clock[6] != '.' {
+			return DTG{}, ErrNotNMEA
+		}
+		t = t.Add(time.Duration(fraction * float64(time.Second)))
+	}
+	return DTG{Time: t}, nil
+}
@@ -0,0 +1,44 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNMEATime(t *testing.T) {
+	tests := []struct {
+		sentence string
+		expected time.Time
+	}{
+		{"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A", time.Date(1994, time.March, 23, 12, 35, 19, 0, time.UTC)},
+		{"$GPRMC,123519.50,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*41\r\n", time.Date(1994, time.March, 23, 12, 35, 19, 500000000, time.UTC)},
+		{"$GPRMC,000000,A,4807.038,N,01131.000,E,022.4,084.4,010169,003.1,W*67", time.Date(1969, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"$GPZDA,201530.00,04,07,2002,00,00*60", time.Date(2002, time.July, 4, 20, 15, 30, 0, time.UTC)},
+		{"$GNZDA,235959.5,31,12,1999,,*45", time.Date(1999, time.December, 31, 23, 59, 59, 500000000, time.UTC)},
+	}
+	for _, test := range tests {
+		d, err := ParseNMEATime(test.sentence)
+		if err != nil {
+			t.Errorf("Expected \"%s\" to parse, but got %v", test.sentence, err)
+			continue
+		}
+		if !d.Time.Equal(test.expected) || d.ZoneLetter() != "Z" {
+			t.Errorf("Expected %s for \"%s\", but got %s", test.expected, test.sentence, d.Time)
+		}
+	}
+	if _, err := ParseNMEATime("$GPRMC,123519,V,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*7D"); err != ErrNoGPSFix {
+		t.Errorf("Expected %v, but got %v", ErrNoGPSFix, err)
+	}
+	for _, invalid := range []string{
+		"",
+		"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6B",
+		"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W",
+		"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+		"$GPZDA,201530.00,32,07,2002,00,00*65",
+		"151230ZDEC19",
+	} {
+		if _, err := ParseNMEATime(invalid); err != ErrNotNMEA {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrNotNMEA, invalid, err)
+		}
+	}
+}
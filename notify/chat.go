@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// Markup is the text formatting of a chat platform.
+type Markup int
+
+const (
+	// Plain text, no formatting.
+	Plain Markup = iota
+	// Markdown as used by Matrix and Mattermost, **bold**.
+	Markdown
+	// Slack mrkdwn, *bold*.
+	Slack
+)
+
+// ChatFormatter formats DTGs for bots reminding teams of upcoming DTGs,
+// e.g. "⏰ 151230Z DEC 19 (in 2h 15m, 14:30 your time)". The zero value
+// writes plain text for a reader in time.Local.
+type ChatFormatter struct {
+	// Location is the reader's time zone, time.Local when nil.
+	Location *time.Location
+	// Markup sets the DTG in bold for the chat platform.
+	Markup Markup
+	// Now returns the current time, time.Now when nil.
+	Now func() time.Time
+}
+
+// Format returns the chat message of d: the DTG in ACP 121 header form, how
+// far away it is and the time (and date, unless it is today) in the
+// reader's zone.
+func (f *ChatFormatter) Format(d dtg.DTG) string {
+	now := time.Now()
+	if f.Now != nil {
+		now = f.Now()
+	}
+	loc := f.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	s := dtg.FormatACP121(d)
+	switch f.Markup {
+	case Markdown:
+		s = "**" + s + "**"
+	case Slack:
+		s = "*" + s + "*"
+	}
+	local := d.Time.In(loc)
+	layout := "15:04"
+	if y, m, day := now.In(loc).Date(); local.Day() != day || local.Month() != m || local.Year() != y {
+		layout = "Mon 2 Jan 15:04"
+	}
+	return fmt.Sprintf("⏰ %s (%s, %s your time)", s, relative(d.Time.Sub(now)), local.Format(layout))
+}
+
+// relative returns d as "in 2h 15m", "3d 4h ago" or "now", in whole
+// minutes with the two most significant units.
+func relative(d time.Duration) string {
+	ago := d < 0
+	if ago {
+		d = -d
+	}
+	minutes := int((d + 30*time.Second) / time.Minute)
+	if minutes == 0 {
+		return "now"
+	}
+	days, hours, minutes := minutes/(24*60), minutes/60%24, minutes%60
+	var s string
+	switch {
+	case days > 0 && hours > 0:
+		s = fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		s = fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		s = fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		s = fmt.Sprintf("%dh", hours)
+	default:
+		s = fmt.Sprintf("%dm", minutes)
+	}
+	if ago {
+		return s + " ago"
+	}
+	return "in " + s
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+func TestChatFormatter(t *testing.T) {
+	stockholm := time.FixedZone("CET", 3600)
+	now := time.Date(2019, 12, 15, 10, 15, 0, 0, time.UTC)
+	tests := []struct {
+		dtg      string
+		markup   Markup
+		expected string
+	}{
+		{"151230ZDEC19", Plain, "⏰ 151230Z DEC 19 (in 2h 15m, 13:30 your time)"},
+		{"151230ZDEC19", Slack, "⏰ *151230Z DEC 19* (in 2h 15m, 13:30 your time)"},
+		{"151015ZDEC19", Markdown, "⏰ **151015Z DEC 19** (now, 11:15 your time)"},
+		{"150800ADEC19", Plain, "⏰ 150800A DEC 19 (3h 15m ago, 08:00 your time)"},
+		{"171200ZDEC19", Plain, "⏰ 171200Z DEC 19 (in 2d 1h, Tue 17 Dec 13:00 your time)"},
+		{"152330ZDEC19", Plain, "⏰ 152330Z DEC 19 (in 13h 15m, Mon 16 Dec 00:30 your time)"},
+	}
+	for _, test := range tests {
+		d, err := dtg.Parse(test.dtg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := &ChatFormatter{Location: stockholm, Markup: test.markup, Now: func() time.Time { return now }}
+		if got := f.Format(d); got != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package notify posts time announcements for a schedule of ACP 121 Date
+// Time Groups to webhooks, e.g. chat-ops channels, at the right instants.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+const (
+	// DefaultRetries is the number of retries of a failed post.
+	DefaultRetries int = 3
+	// DefaultBackoff is the delay before the first retry, doubled for each
+	// subsequent retry.
+	DefaultBackoff time.Duration = time.Second
+)
+
+// Notification is an announcement due at its DTG.
+type Notification struct {
+	DTG   dtg.DTG
+	Title string
+}
+
+// Payload is the JSON body posted to the webhooks. Text makes the payload
+// directly usable with Slack and Mattermost incoming webhooks.
+type Payload struct {
+	DTG     string `json:"dtg"`
+	RFC3339 string `json:"rfc3339"`
+	Title   string `json:"title"`
+	Text    string `json:"text"`
+}
+
+// NewPayload returns the payload of a notification.
+func NewPayload(n Notification) Payload {
+	text := n.DTG.String()
+	if n.Title != "" {
+		text += " " + n.Title
+	}
+	return Payload{DTG: n.DTG.String(), RFC3339: n.DTG.RFC3339(), Title: n.Title, Text: text}
+}
+
+// StatusError is a webhook response other than 2xx.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook %s responded %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// temporary reports whether the request may succeed if retried.
+func (e *StatusError) temporary() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Notifier posts notifications to webhooks. The zero value is not usable,
+// at least one webhook is needed.
+type Notifier struct {
+	// Webhooks are the URLs every notification is posted to.
+	Webhooks []string
+	// Client is the HTTP client, http.DefaultClient when nil.
+	Client *http.Client
+	// Retries is the number of retries of a failed post, DefaultRetries
+	// when 0 and none when negative. Only network errors, 5xx and 429
+	// responses are retried.
+	Retries int
+	// Backoff is the delay before the first retry, DefaultBackoff when 0.
+	Backoff time.Duration
+	// OnError, if set, is called when posting a notification to a webhook
+	// failed after all retries. Run carries on with the schedule.
+	OnError func(n Notification, url string, err error)
+}
+
+// Run posts each notification of schedule at its DTG until all are posted
+// or ctx is done, in which case ctx.Err() is returned. Notifications whose
+// DTG has already passed when Run is called are skipped.
+func (nt *Notifier) Run(ctx context.Context, schedule []Notification) error {
+	pending := make([]Notification, 0, len(schedule))
+	now := time.Now()
+	for _, n := range schedule {
+		if !n.DTG.Time.Before(now) {
+			pending = append(pending, n)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].DTG.Time.Before(pending[j].DTG.Time) })
+	for _, n := range pending {
+		if err := sleep(ctx, time.Until(n.DTG.Time)); err != nil {
+			return err
+		}
+		if err := nt.Post(ctx, n); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Post posts a notification to all webhooks now, retrying failures, and
+// returns the errors of the webhooks that failed.
+func (nt *Notifier) Post(ctx context.Context, n Notification) error {
+	if len(nt.Webhooks) == 0 {
+		return errors.New("no webhooks configured")
+	}
+	body, err := json.Marshal(NewPayload(n))
+	if err != nil {
+		return err
+	}
+	var failures []string
+	for _, url := range nt.Webhooks {
+		if err := nt.post(ctx, url, body); err != nil {
+			failures = append(failures, err.Error())
+			if nt.OnError != nil {
+				nt.OnError(n, url, err)
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (nt *Notifier) post(ctx context.Context, url string, body []byte) error {
+	retries := nt.Retries
+	switch {
+	case retries == 0:
+		retries = DefaultRetries
+	case retries < 0:
+		retries = 0
+	}
+	backoff := nt.Backoff
+	if backoff == 0 {
+		backoff = DefaultBackoff
+	}
+	client := nt.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, backoff); err != nil {
+				return err
+			}
+			backoff *= 2
+		}
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		var resp *http.Response
+		resp, err = client.Do(req)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		statusErr := &StatusError{URL: url, StatusCode: resp.StatusCode}
+		err = statusErr
+		if !statusErr.temporary() {
+			return err
+		}
+	}
+	return err
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sa6mwa/dtg"
+)
+
+func TestNewPayload(t *testing.T) {
+	d, err := dtg.Parse("151230ADEC19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPayload(Notification{DTG: d, Title: "H-hour"})
+	if p.DTG != "151230ADEC19" || p.RFC3339 != "2019-12-15T12:30:00+01:00" || p.Text != "151230ADEC19 H-hour" {
+		t.Errorf("Unexpected payload %+v", p)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	var received []Payload
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 2 {
+			// The first attempt of the second notification fails.
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var p Payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Error(err)
+		}
+		received = append(received, p)
+	}))
+	defer server.Close()
+	now := time.Now()
+	schedule := []Notification{
+		{DTG: dtg.DTG{Time: now.Add(60 * time.Millisecond)}, Title: "second"},
+		{DTG: dtg.DTG{Time: now.Add(-time.Hour)}, Title: "past"},
+		{DTG: dtg.DTG{Time: now.Add(20 * time.Millisecond)}, Title: "first"},
+	}
+	nt := &Notifier{Webhooks: []string{server.URL}, Backoff: time.Millisecond}
+	if err := nt.Run(context.Background(), schedule); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(now) < 60*time.Millisecond {
+		t.Errorf("Expected Run to wait for the last notification")
+	}
+	if len(received) != 2 || received[0].Title != "first" || received[1].Title != "second" || calls != 3 {
+		t.Errorf("Expected first and second (retried once), but got %+v after %d calls", received, calls)
+	}
+}
+
+func TestPostErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	var failed string
+	nt := &Notifier{Webhooks: []string{server.URL}, Backoff: time.Millisecond, OnError: func(n Notification, url string, err error) {
+		failed = url
+	}}
+	err := nt.Post(context.Background(), Notification{DTG: dtg.DTG{Time: time.Now()}})
+	if err == nil || calls != 1 || failed != server.URL {
+		t.Errorf("Expected a 404 without retries, but got %v after %d calls", err, calls)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = nt.Run(ctx, []Notification{{DTG: dtg.DTG{Time: time.Now().Add(time.Hour)}}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
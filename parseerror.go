@@ -0,0 +1,298 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The field errors of a ParseError, which errors.Is reports in addition to
+// its Err (unless Err is ErrNotStrict), so that callers can tell the failure
+// classes apart without matching error text.
+var (
+	ErrInvalidDay    error = errors.New("invalid day of month")
+	ErrInvalidHour   error = errors.New("invalid hour")
+	ErrInvalidMinute error = errors.New("invalid minute")
+	ErrInvalidMonth  error = errors.New("invalid month")
+	ErrInvalidYear   error = errors.New("invalid year")
+	// ErrInvalidZoneLetter is ErrInvalidTimeZoneLetter.
+	ErrInvalidZoneLetter error = ErrInvalidTimeZoneLetter
+)
+
+// ParseError describes why a DTG did not parse, so that UIs can highlight
+// the characters that are wrong, e.g. the Ö of 121212ÖFEB02. It wraps one of
+// the sentinel errors (ErrInvalidDTG, ErrNotStrict, ErrNoSuchDay or
+// ErrInvalidTimeZoneLetter) and is the field error of Field, e.g.
+// ErrInvalidZoneLetter, use errors.Is to test for them.
+type ParseError struct {
+	// Input is the string as passed to Parse.
+	Input string
+	// Field is the part of the DTG that is wrong, named as in Fields (Day,
+	// Hour, Minute, Letter, Month or Year), or empty for the DTG as a
+	// whole.
+	Field string
+	// Pos and End are the characters (not bytes) of Input that are wrong,
+	// End being exclusive. Pos equals End if something is missing at Pos.
+	Pos, End int
+	// Reason explains the error, e.g. `"Ö" is not a zone letter A-Z`, or is
+	// empty when Err says it all.
+	Reason string
+	// Suggestion is the DTG Input was most likely meant to be, e.g.
+	// 121212AFEB01 for 121212AFXB01, or empty.
+	Suggestion string
+	Err        error
+}
+
+func (e *ParseError) Error() string {
+	s := e.Err.Error()
+	if e.Reason != "" {
+		s += ": " + e.Reason
+	}
+	if e.Suggestion != "" {
+		s += ` (did you mean "` + e.Suggestion + `"?)`
+	}
+	return s
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the field error of e, e.g. ErrInvalidMonth
+// for `121212ZFOO02`.
+func (e *ParseError) Is(target error) bool {
+	if e.Err == ErrNotStrict {
+		return false
+	}
+	switch e.Field {
+	case "Day":
+		return target == ErrInvalidDay
+	case "Hour":
+		return target == ErrInvalidHour
+	case "Minute":
+		return target == ErrInvalidMinute
+	case "Letter":
+		return target == ErrInvalidZoneLetter
+	case "Month":
+		return target == ErrInvalidMonth
+	case "Year":
+		return target == ErrInvalidYear
+	}
+	return false
+}
+
+// errorAt makes ParseErrors of input at positions of the DTG Parse works
+// on, which is input with surrounding space trimmed.
+type errorAt struct {
+	input string
+	lead  int
+	// positions are the characters of input of those Parse works on when
+	// it is not only trimmed, see Parser.Lenient.
+	positions []int
+}
+
+func newErrorAt(input string) errorAt {
+	return errorAt{input: input, lead: utf8.RuneCountInString(input) - utf8.RuneCountInString(strings.TrimLeftFunc(input, unicode.IsSpace))}
+}
+
+func (a errorAt) err(field string, pos, end int, err error, reason string) *ParseError {
+	if a.positions == nil {
+		return &ParseError{Input: a.input, Field: field, Pos: a.lead + pos, End: a.lead + end, Reason: reason, Err: err}
+	}
+	return &ParseError{Input: a.input, Field: field, Pos: a.position(pos), End: a.position(end-1) + 1, Reason: reason, Err: err}
+}
+
+// position returns the character of input of character i that Parse works
+// on, or the one after the last.
+func (a errorAt) position(i int) int {
+	switch {
+	case i < 0:
+		return a.position(0) - 1
+	case i < len(a.positions):
+		return a.positions[i]
+	case len(a.positions) > 0:
+		return a.positions[len(a.positions)-1] + 1
+	}
+	return 0
+}
+
+// dtgFields names the sub matches of DtgRegexp as in Fields.
+var dtgFields = [...]string{
+	dtgSubMatchDay:      "Day",
+	dtgSubMatchHour:     "Hour",
+	dtgSubMatchMinute:   "Minute",
+	dtgSubMatchTimeZone: "Letter",
+	dtgSubMatchMonth:    "Month",
+	dtgSubMatchYear:     "Year",
+}
+
+// problem is a part of a DTG that is wrong.
+type problem struct {
+	field    string
+	pos, end int
+	reason   string
+	err      error
+}
+
+// diagnose returns the first part of s, a DTG that DtgRegexp does not match
+// in upper case, that is wrong and why.
+func diagnose(s string) (field string, pos, end int, reason string) {
+	if problems := diagnoseAll(s, nil); len(problems) > 0 {
+		return problems[0].field, problems[0].pos, problems[0].end, problems[0].reason
+	}
+	return "", 0, utf8.RuneCountInString(s), ""
+}
+
+// diagnoseAll returns every part of s that is wrong (as far as the parts
+// can be told apart) in order. With a zone table it also checks the ranges
+// of the day, hour and minute and that the designator is in zt, otherwise
+// only what DtgRegexp does.
+func diagnoseAll(s string, zt *ZoneTable) (problems []problem) {
+	o := []rune(s)
+	r := []rune(strings.ToUpper(s))
+	if len(o) != len(r) {
+		o = r
+	}
+	add := func(field string, pos, end int, reason string) {
+		problems = append(problems, problem{field: field, pos: pos, end: end, reason: reason, err: ErrInvalidDTG})
+	}
+	for group := dtgSubMatchDay; group <= dtgSubMatchMinute; group++ {
+		field, i := dtgFields[group], 2*(group-dtgSubMatchDay)
+		digits := true
+		for j := i; j < i+2 && digits; j++ {
+			switch {
+			case j >= len(r):
+				add(field, j, j, "missing "+strings.ToLower(field))
+				return problems
+			case r[j] < '0' || r[j] > '9':
+				add(field, j, j+1, fmt.Sprintf("%q is not a digit", o[j]))
+				digits = false
+			}
+		}
+		if !digits || zt == nil {
+			continue
+		}
+		value := string(r[i : i+2])
+		switch {
+		case group == dtgSubMatchDay && (value == "00" || value > "31"):
+			add(field, i, i+2, fmt.Sprintf("day %s is not 01-31", value))
+		case group == dtgSubMatchHour && value > "23":
+			add(field, i, i+2, fmt.Sprintf("hour %s is not 00-23", value))
+		case group == dtgSubMatchMinute && value > "59":
+			add(field, i, i+2, fmt.Sprintf("minute %s is not 00-59", value))
+		}
+	}
+	// letters returns the end of the letters (anything but digits) at i.
+	letters := func(i int) int {
+		for i < len(r) && (r[i] < '0' || r[i] > '9') {
+			i++
+		}
+		return i
+	}
+	// month returns the length of the month name or abbreviation at i (up
+	// to a digit), or 0.
+	month := func(i int) int {
+		word := string(r[i:letters(i)])
+		for m := time.January; m <= time.December; m++ {
+			if word == strings.ToUpper(m.String()) {
+				return len(word)
+			}
+		}
+		for _, abbreviation := range monthAbbreviations {
+			if word == abbreviation {
+				return 3
+			}
+		}
+		if word == "MAJ" || word == "OKT" {
+			return 3
+		}
+		return 0
+	}
+	i := 6
+	if i < len(r) && month(i) == 0 && (r[i] < '0' || r[i] > '9') {
+		start := i
+		letter := r[i] >= 'A' && r[i] <= 'Z'
+		if !letter {
+			add("Letter", i, i+1, fmt.Sprintf("%q is not a zone letter A-Z", o[i]))
+		}
+		i++
+		if i < len(r) && r[i] == '*' {
+			i++
+		}
+		if designator := string(r[start:i]); letter && zt != nil && designator != "J" {
+			if _, ok := zt.Offset(designator); !ok {
+				problems = append(problems, problem{field: "Letter", pos: start, end: i, reason: fmt.Sprintf("%q is not in the zone table", designator), err: ErrInvalidTimeZoneLetter})
+			}
+		}
+	}
+	if i < len(r) && (r[i] < '0' || r[i] > '9') {
+		if n := month(i); n > 0 {
+			i += n
+		} else {
+			end := letters(i)
+			add("Month", i, end, fmt.Sprintf("%q is not a month", string(o[i:end])))
+			i = end
+		}
+	}
+	if i < len(r) {
+		end := i
+		for end < len(r) && r[end] >= '0' && r[end] <= '9' {
+			end++
+		}
+		if digits := end - i; digits != 2 && digits != 4 {
+			if digits == 0 {
+				end = i + 2
+				if end > len(r) {
+					end = len(r)
+				}
+			}
+			add("Year", i, end, fmt.Sprintf("%q is not a two or four digit year", string(o[i:end])))
+			return problems
+		}
+		i = end
+	}
+	if i < len(r) {
+		add("", i, len(r), fmt.Sprintf("unexpected %q", string(o[i:])))
+	}
+	return problems
+}
+
+// ValidateAll is like Validate, but returns every problem of dtgString (a
+// *ParseError each, in order) instead of the first, e.g. both the day and
+// the letter of 321212ÖFEB02, so that form entry UIs can show all of them
+// at once. It returns nil for a valid DTG.
+func ValidateAll(dtgString string) []error {
+	return (*Parser)(nil).ValidateAll(dtgString)
+}
+
+// ValidateAll is like the package level ValidateAll, but validates with p:
+// the DTG is diagnosed as p reads it, e.g. without the separators that
+// Lenient removes, against the zone table of p. Whether the day exists in
+// the month (which depends on the CenturyPivot of p) is only reported when
+// it is the one problem.
+func (p *Parser) ValidateAll(dtgString string) []error {
+	err := p.Validate(dtgString)
+	if err == nil {
+		return nil
+	}
+	at := newErrorAt(dtgString)
+	trimmed := strings.TrimSpace(dtgString)
+	if p != nil && p.Lenient {
+		trimmed, at = lenient(dtgString)
+	}
+	problems := diagnoseAll(trimmed, p.zones())
+	if len(problems) < 2 {
+		return []error{err}
+	}
+	var errs []error
+	if errors.Is(err, ErrNotStrict) {
+		errs = append(errs, err)
+	}
+	for _, problem := range problems {
+		errs = append(errs, at.err(problem.field, problem.pos, problem.end, problem.err, problem.reason))
+	}
+	return errs
+}
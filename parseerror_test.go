@@ -0,0 +1,168 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseError(t *testing.T) {
+	reference := time.Date(2021, time.April, 15, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		input    string
+		strict   bool
+		field    string
+		pos, end int
+		err      error
+		reason   string
+	}{
+		{`121212ÖFEB02`, false, "Letter", 6, 7, ErrInvalidDTG, `'Ö' is not a zone letter A-Z`},
+		{`  121212ÖFEB02`, false, "Letter", 8, 9, ErrInvalidDTG, `'Ö' is not a zone letter A-Z`},
+		{`12x212ZFEB02`, false, "Hour", 2, 3, ErrInvalidDTG, `'x' is not a digit`},
+		{`1212`, false, "Minute", 4, 4, ErrInvalidDTG, `missing minute`},
+		{`121212ZFoo02`, false, "Month", 7, 10, ErrInvalidDTG, `"Foo" is not a month`},
+		{`121212ZFEB2`, false, "Year", 10, 11, ErrInvalidDTG, `"2" is not a two or four digit year`},
+		{`121212ZFEB02 ZULU`, false, "", 12, 17, ErrInvalidDTG, `unexpected " ZULU"`},
+		{`001212ZFEB02`, false, "Day", 0, 2, ErrInvalidDTG, `day 00`},
+		{`122512ZFEB02`, false, "Hour", 2, 4, ErrInvalidDTG, `hour 25 is not 00-23`},
+		{`121260Z`, false, "Minute", 4, 6, ErrInvalidDTG, `minute 60 is not 00-59`},
+		{`121212ZMAJ02`, false, "Month", 7, 10, ErrInvalidDTG, `"MAJ" is not an English month abbreviation`},
+		{`301212ZFEB02`, false, "Day", 0, 2, ErrNoSuchDay, `FEB 2002 has 28 days`},
+		{`121212D*FEB02`, false, "Letter", 6, 8, ErrInvalidTimeZoneLetter, `"D*" is not in the zone table`},
+		{`121212zFEB02`, true, "Letter", 6, 7, ErrNotStrict, `lower case`},
+		{`121212FEB02`, true, "Letter", 6, 6, ErrNotStrict, `missing zone letter`},
+		{`121212JFEB02`, true, "Letter", 6, 7, ErrNotStrict, `J (local time) is not allowed`},
+		{`121212Z`, true, "Month", 7, 7, ErrNotStrict, `missing month`},
+		{`121212ZFEB`, true, "Year", 10, 10, ErrNotStrict, `missing year`},
+	} {
+		_, err := Parse(test.input, WithReferenceTime(reference), func(p *Parser) { p.Strict = test.strict })
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected a *ParseError for \"%s\", but got %v", test.input, err)
+			continue
+		}
+		if parseErr.Input != test.input || parseErr.Field != test.field || parseErr.Pos != test.pos || parseErr.End != test.end || parseErr.Reason != test.reason || !errors.Is(err, test.err) {
+			t.Errorf("Expected %s %d-%d %v (%s) for \"%s\", but got %s %d-%d %v (%s)", test.field, test.pos, test.end, test.err, test.reason, test.input,
+				parseErr.Field, parseErr.Pos, parseErr.End, parseErr.Err, parseErr.Reason)
+		}
+		if expected := test.err.Error() + ": " + test.reason; parseErr.Suggestion == "" && err.Error() != expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected, err)
+		}
+	}
+	if err := Validate(`121212ÖFEB02`); !errors.Is(err, ErrInvalidDTG) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidDTG, err)
+	}
+	var parseErr *ParseError
+	if _, err := GetNumericTimeZone(" ÖÄ"); !errors.As(err, &parseErr) || parseErr.Field != "Letter" || parseErr.Pos != 1 || parseErr.End != 3 || err.Error() != ErrInvalidTimeZoneLetter.Error() {
+		t.Errorf("Expected a *ParseError of Letter 1-3, but got %#v", err)
+	}
+}
+
+func TestParseErrorSuggestion(t *testing.T) {
+	for _, test := range []struct {
+		input, suggestion string
+	}{
+		{`121212AFXB01`, `121212AFEB01`},
+		{`121212ÖFEB02`, `121212OFEB02`},
+		{`121212ZFBE02`, `121212ZFEB02`},
+		{`121212ZDCE02`, `121212ZDEC02`},
+		{`121212ZOC02`, `121212ZOCT02`},
+		{`121212ZАPR02`, `121212ZAPR02`},
+		{`121212ÖFXB02`, `121212OFEB02`},
+		{`121212ZJUX02`, ``},
+		{`121212ZDECEMBR02`, `121212ZDEC02`},
+		{`121212ZFOO02`, ``},
+		{`121212ØFEB02`, ``},
+		{`12x212ZFEB02`, ``},
+	} {
+		_, err := Parse(test.input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected a *ParseError for \"%s\", but got %v", test.input, err)
+		}
+		if parseErr.Suggestion != test.suggestion {
+			t.Errorf("Expected the suggestion \"%s\" for \"%s\", but got \"%s\"", test.suggestion, test.input, parseErr.Suggestion)
+		}
+	}
+	if _, err := Parse(`121212AFXB01`); err == nil || !strings.HasSuffix(err.Error(), `: "FXB" is not a month (did you mean "121212AFEB01"?)`) {
+		t.Errorf("Expected the suggestion in the message, but got %v", err)
+	}
+}
+
+func TestParseErrorFields(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		err   error
+	}{
+		{`321212ZFEB02`, ErrInvalidDay},
+		{`301212ZFEB02`, ErrInvalidDay},
+		{`122512ZFEB02`, ErrInvalidHour},
+		{`12126`, ErrInvalidMinute},
+		{`121212ÖFEB02`, ErrInvalidZoneLetter},
+		{`121212D*FEB02`, ErrInvalidZoneLetter},
+		{`121212ZFOO02`, ErrInvalidMonth},
+		{`121212ZFEB2X`, ErrInvalidYear},
+	} {
+		err := Validate(test.input)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Expected %v for \"%s\", but got %v", test.err, test.input, err)
+		}
+		for _, other := range []error{ErrInvalidDay, ErrInvalidWeekday, ErrInvalidHour, ErrInvalidMinute, ErrInvalidZoneLetter, ErrInvalidMonth, ErrInvalidYear} {
+			if other != test.err && errors.Is(err, other) {
+				t.Errorf("Expected \"%s\" not to be %v", test.input, other)
+			}
+		}
+	}
+	if err := Validate(`121212ZFEB02 ZULU`); err == nil || errors.Is(err, ErrInvalidYear) {
+		t.Errorf("Expected an error of the DTG as a whole, but got %v", err)
+	}
+	if err := ValidateStrictNATO(`121212Z`); !errors.Is(err, ErrNotStrict) || errors.Is(err, ErrInvalidMonth) {
+		t.Errorf("Expected only %v, but got %v", ErrNotStrict, err)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	if errs := ValidateAll(`151230ZDEC19`); errs != nil {
+		t.Errorf("Expected no errors, but got %v", errs)
+	}
+	tests := []struct {
+		input  string
+		fields []string
+	}{
+		{`321212ÖFOB02`, []string{"Day", "Letter", "Month"}},
+		{`1x2561D*DEC1`, []string{"Day", "Hour", "Minute", "Letter", "Year"}},
+		{`122512W`, []string{"Hour"}},
+		{`311200ZFEB20`, []string{"Day"}},
+		{`151230ZDEC19 ZULU`, []string{""}},
+	}
+	for _, test := range tests {
+		errs := ValidateAll(test.input)
+		fields := make([]string, 0, len(errs))
+		for _, err := range errs {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected a *ParseError for \"%s\", but got %v", test.input, err)
+			}
+			fields = append(fields, parseErr.Field)
+		}
+		if strings.Join(fields, ",") != strings.Join(test.fields, ",") {
+			t.Errorf("Expected %v for \"%s\", but got %v (%v)", test.fields, test.input, fields, errs)
+		}
+	}
+	errs := ValidateAll(`321212ÖFOB02`)
+	if !errors.Is(errs[0], ErrInvalidDay) || !errors.Is(errs[1], ErrInvalidZoneLetter) || !errors.Is(errs[2], ErrInvalidMonth) {
+		t.Errorf("Expected the field errors of the day, letter and month, but got %v", errs)
+	}
+	if errs[1].Error() != ErrInvalidDTG.Error()+`: 'Ö' is not a zone letter A-Z` {
+		t.Errorf("Expected the reason of the letter, but got \"%s\"", errs[1])
+	}
+	if errs := (&Parser{Strict: true}).ValidateAll(`32x212ZFEB`); len(errs) != 3 || !errors.Is(errs[0], ErrNotStrict) {
+		t.Errorf("Expected %v and two problems, but got %v", ErrNotStrict, errs)
+	}
+	errs = (&Parser{Lenient: true}).ValidateAll(`32-12-12 Ö fob 02`)
+	var letter *ParseError
+	if len(errs) != 3 || !errors.Is(errs[0], ErrInvalidDay) || !errors.As(errs[1], &letter) || letter.Pos != 9 || !errors.Is(errs[2], ErrInvalidMonth) {
+		t.Errorf("Expected the day, the letter at 9 and the month, but got %v", errs)
+	}
+}
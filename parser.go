@@ -0,0 +1,543 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrNotStrict error = errors.New("not a fully qualified upper case DTG (ddHHMMZMMMYY, J not allowed)")
+	ErrNoSuchDay error = errors.New("no such day in the month")
+)
+
+// Parser parses Date Time Groups using a configurable zone table. The zero
+// value (and a nil *Parser) behaves exactly like the package level Parse.
+type Parser struct {
+	// Zones is the zone designator table, DefaultZoneTable() when nil.
+	Zones *ZoneTable
+	// Charset is the encoding of files read by FindAllFile and FindAllFS.
+	// Empty means the text is used as is, see Charset.
+	Charset Charset
+	// Strict accepts only fully qualified upper case DTGs
+	// (ddHHMMZMMMYY) with a designator other than J and the English month
+	// abbreviations of ACP 121 (not MAJ and OKT).
+	Strict bool
+	// Lenient removes spaces, dashes and other punctuation (but not the *
+	// of a designator) and upper cases the DTG before parsing, e.g. for
+	// 15 1200Z DEC 19, 151200Z/DEC/19 or 151200z dec 19. in real traffic.
+	// With Strict, the lenient DTG must be fully qualified.
+	Lenient bool
+	// CenturyPivot is the first year of the hundred years two digit years
+	// are resolved to, DefaultCenturyPivot when zero, e.g. 1929 for
+	// archived traffic where 29 is 1929 rather than 2029.
+	CenturyPivot int
+	// Now is the reference clock omitted months and years (and the offset
+	// of J) are inferred from, time.Now when nil.
+	Now func() time.Time
+	// Location is the local time zone of J, time.Local when nil.
+	Location *time.Location
+	// Resolution selects the month and year of DTGs without them, the
+	// current ones of the reference clock when zero, see Resolution.
+	Resolution Resolution
+
+	// locations memoizes the locations of designators other than J during
+	// ParseBulk.
+	locations map[string]*time.Location
+}
+
+// Resolution selects the month (and year) of a DTG without them, relative
+// to the reference time, see Parser.Resolution.
+type Resolution int
+
+const (
+	// ResolveCurrent uses the month and year of the reference time, or
+	// only the year when the month is explicit. This is the zero value.
+	ResolveCurrent Resolution = iota
+	// ResolveNearest picks the occurrence nearest the reference time, e.g.
+	// the previous month for 302350Z received just after midnight on the
+	// 1st.
+	ResolveNearest
+	// ResolvePast picks the latest occurrence not after the reference time,
+	// e.g. for received traffic.
+	ResolvePast
+	// ResolveFuture picks the earliest occurrence not before the reference
+	// time, e.g. for orders and schedules.
+	ResolveFuture
+)
+
+// Interface is the DTG handling of a Parser, for applications that mock it
+// in unit tests or wrap it in decorators, e.g. caching or telemetry. A nil
+// *Parser is the package level behaviour.
+type Interface interface {
+	Parse(dtgString string, opts ...Option) (DTG, error)
+	Validate(dtgString string) error
+	Format(dtg DTG) string
+}
+
+var _ Interface = (*Parser)(nil)
+
+// Option sets an implicit input of Parse, see WithReferenceTime.
+type Option func(*Parser)
+
+// WithReferenceTime infers omitted months and years (and the offset of J)
+// from t instead of the current time, for deterministic tests and batch
+// reprocessing.
+func WithReferenceTime(t time.Time) Option {
+	return func(p *Parser) {
+		p.Now = func() time.Time { return t }
+	}
+}
+
+// WithLocation makes loc the local time zone of J instead of time.Local.
+func WithLocation(loc *time.Location) Option {
+	return func(p *Parser) {
+		p.Location = loc
+	}
+}
+
+// WithZones resolves designators using zones, see Parser.Zones.
+func WithZones(zones *ZoneTable) Option {
+	return func(p *Parser) {
+		p.Zones = zones
+	}
+}
+
+// WithStrict accepts only fully qualified DTGs, see Parser.Strict.
+func WithStrict() Option {
+	return func(p *Parser) {
+		p.Strict = true
+	}
+}
+
+// WithLenient tolerates separators and lower case, see Parser.Lenient.
+func WithLenient() Option {
+	return func(p *Parser) {
+		p.Lenient = true
+	}
+}
+
+// WithCenturyPivot resolves two digit years to the hundred years starting
+// with pivot, e.g. 1930 for 30-99 as 1930-1999 and 00-29 as 2000-2029, see
+// Parser.CenturyPivot.
+func WithCenturyPivot(pivot int) Option {
+	return func(p *Parser) {
+		p.CenturyPivot = pivot
+	}
+}
+
+// WithCentury resolves two digit years within a century, e.g. WithCentury(19)
+// for 1900-1999 when processing archived traffic.
+func WithCentury(century int) Option {
+	return WithCenturyPivot(century * 100)
+}
+
+// WithResolution selects the month and year of DTGs without them, see
+// Resolution.
+func WithResolution(r Resolution) Option {
+	return func(p *Parser) {
+		p.Resolution = r
+	}
+}
+
+// with returns a copy of p with opts applied, or p itself without opts.
+func (p *Parser) with(opts []Option) *Parser {
+	if len(opts) == 0 {
+		return p
+	}
+	var c Parser
+	if p != nil {
+		c = *p
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &c
+}
+
+// reference returns the reference time in the local time zone of J.
+func (p *Parser) reference() time.Time {
+	now := time.Now
+	if p != nil && p.Now != nil {
+		now = p.Now
+	}
+	if p != nil && p.Location != nil {
+		return now().In(p.Location)
+	}
+	return now()
+}
+
+func (p *Parser) resolution() Resolution {
+	if p == nil {
+		return ResolveCurrent
+	}
+	return p.Resolution
+}
+
+func (p *Parser) strict() bool {
+	return p != nil && p.Strict
+}
+
+func (p *Parser) centuryPivot() int {
+	if p == nil || p.CenturyPivot == 0 {
+		return DefaultCenturyPivot
+	}
+	return p.CenturyPivot
+}
+
+// century returns the year of the two digit year yy in the Parser's
+// century window.
+func (p *Parser) century(yy int) int {
+	pivot := p.centuryPivot()
+	year := pivot - pivot%100 + yy
+	if year < pivot {
+		year += 100
+	}
+	return year
+}
+
+func (p *Parser) charset() Charset {
+	if p == nil {
+		return ""
+	}
+	return p.Charset
+}
+
+func (p *Parser) zones() *ZoneTable {
+	if p == nil || p.Zones == nil {
+		return defaultZones
+	}
+	return p.Zones
+}
+
+// Parse transforms a Date Time Group into a DTG the same way as the package
+// level Parse, but resolves the time zone designator using the Parser's
+// zone table. Options override the fields of the Parser for this call.
+func (p *Parser) Parse(dtgString string, opts ...Option) (dtg DTG, err error) {
+	details, err := p.ParseDetailed(dtgString, opts...)
+	return details.DTG, err
+}
+
+// ParseInLocation is like the package level ParseInLocation, but uses the
+// Parser's zone table.
+func (p *Parser) ParseInLocation(dtgString string, loc *time.Location) (DTG, error) {
+	return p.Parse(dtgString, WithLocation(loc))
+}
+
+// Details describes how a Date Time Group was resolved by ParseDetailed.
+type Details struct {
+	DTG DTG
+	// Designator is the zone designator, J if it was omitted.
+	Designator string
+	// Offset is the resolved offset of the designator in seconds east of
+	// UTC.
+	Offset int
+	// ExplicitDesignator, ExplicitMonth and ExplicitYear tell whether the
+	// designator, month and year were part of the input or inferred.
+	ExplicitDesignator bool
+	ExplicitMonth      bool
+	ExplicitYear       bool
+	// Reference is the time used to infer the month, year and (for J) the
+	// local offset that were not part of the input.
+	Reference time.Time
+}
+
+// ParseDetailed is like Parse, but also returns how the DTG was resolved.
+func ParseDetailed(dtgString string, opts ...Option) (Details, error) {
+	return (*Parser)(nil).ParseDetailed(dtgString, opts...)
+}
+
+// ParseDetailed is like the package level ParseDetailed, but uses the
+// Parser's zone table.
+func (p *Parser) ParseDetailed(dtgString string, opts ...Option) (details Details, err error) {
+	p = p.with(opts)
+	details.Reference = p.reference()
+	at := newErrorAt(dtgString)
+	trimmed := strings.TrimSpace(dtgString)
+	if p != nil && p.Lenient {
+		trimmed, at = lenient(dtgString)
+	}
+	upper := strings.ToUpper(trimmed)
+	index := DtgRegexp.FindStringSubmatchIndex(upper)
+	if p.strict() && trimmed != upper {
+		pos := 0
+		for _, r := range trimmed {
+			if unicode.ToUpper(r) != r {
+				break
+			}
+			pos++
+		}
+		field := ""
+		if index != nil {
+			field = fieldAt(index, pos)
+		}
+		return details, at.err(field, pos, pos+1, ErrNotStrict, "lower case")
+	}
+	if index == nil {
+		field, pos, end, reason := diagnose(trimmed)
+		err := at.err(field, pos, end, ErrInvalidDTG, reason)
+		err.Suggestion = p.suggest(trimmed)
+		return details, err
+	}
+	// The match is ASCII, byte offsets are character offsets.
+	match := make([]string, len(index)/2)
+	for i := range match {
+		if index[2*i] >= 0 {
+			match[i] = upper[index[2*i]:index[2*i+1]]
+		}
+	}
+	fail := func(group int, err error, reason string) error {
+		pos, end := span(index, group)
+		return at.err(dtgFields[group], pos, end, err, reason)
+	}
+	details.Designator = match[dtgSubMatchTimeZone]
+	details.ExplicitDesignator = details.Designator != ""
+	if !details.ExplicitDesignator {
+		details.Designator = "J"
+	}
+	details.ExplicitMonth = match[dtgSubMatchMonth] != ""
+	fullMonth := len(match[dtgSubMatchMonth]) > 3
+	details.ExplicitYear = match[dtgSubMatchYear] != ""
+	fourDigitYear := len(match[dtgSubMatchYear]) == 4
+	nonEnglishMonth := match[dtgSubMatchMonth] == "MAJ" || match[dtgSubMatchMonth] == "OKT"
+	if p.strict() {
+		switch {
+		case !details.ExplicitDesignator:
+			return details, fail(dtgSubMatchTimeZone, ErrNotStrict, "missing zone letter")
+		case details.Designator == "J":
+			return details, fail(dtgSubMatchTimeZone, ErrNotStrict, "J (local time) is not allowed")
+		case !details.ExplicitMonth:
+			return details, fail(dtgSubMatchMonth, ErrNotStrict, "missing month")
+		case !details.ExplicitYear:
+			return details, fail(dtgSubMatchYear, ErrNotStrict, "missing year")
+		case fourDigitYear:
+			return details, fail(dtgSubMatchYear, ErrNotStrict, "four digit year")
+		case fullMonth:
+			return details, fail(dtgSubMatchMonth, ErrNotStrict, "full month name")
+		case nonEnglishMonth:
+			return details, fail(dtgSubMatchMonth, ErrNotStrict, fmt.Sprintf("%q is not an English month abbreviation", match[dtgSubMatchMonth]))
+		}
+	}
+	switch {
+	case match[dtgSubMatchDay] == "00":
+		return details, fail(dtgSubMatchDay, ErrInvalidDTG, "day 00")
+	case match[dtgSubMatchHour] > "23":
+		return details, fail(dtgSubMatchHour, ErrInvalidDTG, fmt.Sprintf("hour %s is not 00-23", match[dtgSubMatchHour]))
+	case match[dtgSubMatchMinute] > "59":
+		return details, fail(dtgSubMatchMinute, ErrInvalidDTG, fmt.Sprintf("minute %s is not 00-59", match[dtgSubMatchMinute]))
+	case nonEnglishMonth:
+		return details, fail(dtgSubMatchMonth, ErrInvalidDTG, fmt.Sprintf("%q is not an English month abbreviation", match[dtgSubMatchMonth]))
+	}
+	if fullMonth {
+		// The first three letters of a month name are its abbreviation.
+		match[dtgSubMatchMonth] = match[dtgSubMatchMonth][:3]
+	}
+	year := 0
+	if fourDigitYear {
+		year, _ = strconv.Atoi(match[dtgSubMatchYear])
+		// The zone and layouts work on two digits, the year is set last.
+		match[dtgSubMatchYear] = match[dtgSubMatchYear][2:]
+	} else if details.ExplicitYear {
+		year = p.century(int(match[dtgSubMatchYear][0]-'0')*10 + int(match[dtgSubMatchYear][1]-'0'))
+	}
+	if p.resolution() != ResolveCurrent && !details.ExplicitYear {
+		details.DTG.Time, err = p.resolve(details, match)
+		switch {
+		case errors.Is(err, ErrInvalidTimeZoneLetter):
+			return details, fail(dtgSubMatchTimeZone, ErrInvalidTimeZoneLetter, fmt.Sprintf("%q is not in the zone table", match[dtgSubMatchTimeZone]))
+		case err != nil:
+			return details, fail(dtgSubMatchDay, ErrNoSuchDay, "not in the months around the reference time")
+		}
+		_, details.Offset = details.DTG.Time.Zone()
+		return details, nil
+	}
+	if reason := p.checkDay(details, match, year); reason != "" {
+		return details, fail(dtgSubMatchDay, ErrNoSuchDay, reason)
+	}
+	var numericTimeZone *time.Location
+	numericTimeZone, err = p.location(details.Reference, match[dtgSubMatchTimeZone], match[dtgSubMatchDay], match[dtgSubMatchHour], match[dtgSubMatchMinute], match[dtgSubMatchMonth], match[dtgSubMatchYear])
+	switch {
+	case errors.Is(err, ErrInvalidTimeZoneLetter):
+		return details, fail(dtgSubMatchTimeZone, ErrInvalidTimeZoneLetter, fmt.Sprintf("%q is not in the zone table", match[dtgSubMatchTimeZone]))
+	case err != nil:
+		return details, at.err("", 0, len(upper), ErrInvalidDTG, err.Error())
+	}
+	if !details.ExplicitMonth {
+		match[dtgSubMatchMonth] = strings.ToUpper(details.Reference.In(numericTimeZone).Format(monthLayout))
+	}
+	if !details.ExplicitYear {
+		match[dtgSubMatchYear] = details.Reference.In(numericTimeZone).Format(yearLayout)
+	}
+	expandedDtg := match[dtgSubMatchDay] + match[dtgSubMatchHour] +
+		match[dtgSubMatchMinute] + numericTimeZone.String() +
+		match[dtgSubMatchMonth] + match[dtgSubMatchYear]
+
+	details.DTG.Time, err = time.ParseInLocation(expandedDtgLayout, expandedDtg, numericTimeZone)
+	if err != nil {
+		return details, at.err("", 0, len(upper), ErrInvalidDTG, err.Error())
+	}
+	if t := details.DTG.Time; details.ExplicitYear && year != t.Year() {
+		// checkDay rejected 29 February in a year that is not a leap year.
+		details.DTG.Time = time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	}
+	_, details.Offset = details.DTG.Time.Zone()
+	return details, nil
+}
+
+// span returns the offsets of sub match group of a DtgRegexp match index,
+// or where it would be if it is omitted.
+func span(index []int, group int) (int, int) {
+	for ; group > 0; group-- {
+		if index[2*group] >= 0 {
+			return index[2*group], index[2*group+1]
+		}
+		if index[2*group-1] >= 0 {
+			// The end of the preceding group.
+			return index[2*group-1], index[2*group-1]
+		}
+	}
+	return 0, 0
+}
+
+// fieldAt returns the name of the sub match of a DtgRegexp match index at
+// offset pos.
+func fieldAt(index []int, pos int) string {
+	for group := dtgSubMatchDay; group <= dtgSubMatchYear; group++ {
+		if index[2*group] <= pos && pos < index[2*group+1] {
+			return dtgFields[group]
+		}
+	}
+	return ""
+}
+
+// checkDay returns why the day of match does not exist in its month (of
+// explicitYear when the year is explicit), e.g. 311200ZFEB20 and
+// 310000ZAPR21, or in the current month of the reference time if the month
+// is omitted, or an empty string if it does.
+func (p *Parser) checkDay(details Details, match []string, explicitYear int) string {
+	day := int(match[dtgSubMatchDay][0]-'0')*10 + int(match[dtgSubMatchDay][1]-'0')
+	if day < 29 {
+		return ""
+	}
+	month, year := details.Reference.Month(), details.Reference.Year()
+	if details.ExplicitMonth {
+		for i, abbreviation := range monthAbbreviations {
+			if match[dtgSubMatchMonth] == abbreviation {
+				month = time.Month(i + 1)
+			}
+		}
+	}
+	if details.ExplicitYear {
+		year = explicitYear
+	}
+	if days := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > days {
+		return fmt.Sprintf("%s %d has %d days", monthAbbreviations[month-1], year, days)
+	}
+	return ""
+}
+
+// resolve returns the DTG of match without a year (and possibly month) in
+// the month or year around the reference time selected by the Parser's
+// Resolution. Months (or years) where the day does not exist are skipped.
+func (p *Parser) resolve(details Details, match []string) (time.Time, error) {
+	day, _ := strconv.Atoi(match[dtgSubMatchDay])
+	hour, _ := strconv.Atoi(match[dtgSubMatchHour])
+	minute, _ := strconv.Atoi(match[dtgSubMatchMinute])
+	if day < 1 || day > 31 || hour > 23 || minute > 59 {
+		return time.Time{}, ErrInvalidDTG
+	}
+	var explicitMonth time.Month
+	if details.ExplicitMonth {
+		m, err := time.Parse(monthLayout, match[dtgSubMatchMonth])
+		if err != nil {
+			return time.Time{}, err
+		}
+		explicitMonth = m.Month()
+	}
+	reference := details.Reference
+	loc, err := p.location(reference, match[dtgSubMatchTimeZone])
+	if err != nil {
+		return time.Time{}, err
+	}
+	base := reference.In(loc)
+	// Far enough to find a 31st or (for years) a 29 February on both sides.
+	span := 2
+	if details.ExplicitMonth {
+		span = 4
+	}
+	var best time.Time
+	found := false
+	for delta := -span; delta <= span; delta++ {
+		year, month := base.Year()+delta, explicitMonth
+		if !details.ExplicitMonth {
+			first := time.Date(base.Year(), base.Month()+time.Month(delta), 1, 0, 0, 0, 0, time.UTC)
+			year, month = first.Year(), first.Month()
+		}
+		// J is resolved at each candidate in case of DST.
+		loc, err := p.location(reference, match[dtgSubMatchTimeZone], match[dtgSubMatchDay], match[dtgSubMatchHour], match[dtgSubMatchMinute], strings.ToUpper(month.String()[:3]), fmt.Sprintf("%02d", year%100))
+		if err != nil {
+			continue
+		}
+		t := time.Date(year, month, day, hour, minute, 0, 0, loc)
+		if t.Day() != day {
+			continue
+		}
+		var better bool
+		switch p.resolution() {
+		case ResolvePast:
+			better = !t.After(reference) && (!found || t.After(best))
+		case ResolveFuture:
+			better = !t.Before(reference) && (!found || t.Before(best))
+		default:
+			better = !found || absDuration(t.Sub(reference)) < absDuration(best.Sub(reference))
+		}
+		if better {
+			best, found = t, true
+		}
+	}
+	if !found {
+		return time.Time{}, ErrInvalidDTG
+	}
+	return best, nil
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Validate attempts to parse the DTG string using the Parser's zone table
+// and returns error if parsing failed (invalid DTG) or nil (valid DTG).
+func (p *Parser) Validate(dtgString string) error {
+	if p.validFast(dtgString) {
+		return nil
+	}
+	_, err := p.Parse(dtgString)
+	return err
+}
+
+// ValidateAndNormalize is like the package level ValidateAndNormalize, but
+// uses the Parser's zone table.
+func (p *Parser) ValidateAndNormalize(dtgString string) (string, error) {
+	// Designators sharing an offset in another table are formatted as the
+	// first of them, only the default table is canonical as written.
+	if p.zones() == defaultZones && p.validFast(dtgString) {
+		return dtgString, nil
+	}
+	dtg, err := p.Parse(dtgString)
+	if err != nil {
+		return "", err
+	}
+	return p.Format(dtg), nil
+}
+
+// Format returns the DTG as String does, but with the Parser's zone table,
+// see Formatter.
+func (p *Parser) Format(dtg DTG) string {
+	return (&Formatter{Zones: p.zones()}).Format(dtg)
+}
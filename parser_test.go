@@ -0,0 +1,409 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParser_Parse(t *testing.T) {
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	zones.Set("E*", 5*3600+1800)
+	zones.Set("J", 2*3600)
+	zones.Set("R", -4*3600)
+	p := &Parser{Zones: zones}
+
+	testTable := []struct {
+		input    string
+		expected string
+	}{
+		{`151200D*DEC19`, `2019-12-15T12:00:00+04:30`},
+		{`151200e*DEC19`, `2019-12-15T12:00:00+05:30`},
+		{`151200JDEC19`, `2019-12-15T12:00:00+02:00`},
+		{`151200DEC19`, `2019-12-15T12:00:00+02:00`},
+		{`151200RDEC19`, `2019-12-15T12:00:00-04:00`},
+		{`151200ZDEC19`, `2019-12-15T12:00:00Z`},
+	}
+	for _, v := range testTable {
+		dtg, err := p.Parse(v.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := dtg.Time.Format("2006-01-02T15:04:05Z07:00"); s != v.expected {
+			t.Errorf("Expected \"%s\" to parse as %s, but got %s", v.input, v.expected, s)
+		}
+	}
+	if err := Validate(`151200D*DEC19`); err == nil {
+		t.Error("Expected D* to be invalid with the default table, but succeeded")
+	}
+	var nilParser *Parser
+	for _, v := range testVectors {
+		dtg, err := nilParser.Parse(v.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !dtg.Time.Equal(v.Instant) {
+			t.Errorf("Expected \"%s\" to be %s, but got %s", v.Input, v.Instant, dtg.Time.UTC())
+		}
+	}
+}
+
+func TestParseDetailed(t *testing.T) {
+	testTable := []struct {
+		input              string
+		designator         string
+		offset             int
+		explicitDesignator bool
+		explicitMonth      bool
+		explicitYear       bool
+	}{
+		{`151200ZDEC19`, `Z`, 0, true, true, true},
+		{`151200bdec`, `B`, 7200, true, true, false},
+		{`151200R`, `R`, -5 * 3600, true, false, false},
+		{`151200`, `J`, -1, false, false, false},
+		{`151200JJAN20`, `J`, -1, true, true, true},
+	}
+	for _, v := range testTable {
+		before := time.Now()
+		d, err := ParseDetailed(v.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Designator != v.designator || d.ExplicitDesignator != v.explicitDesignator || d.ExplicitMonth != v.explicitMonth || d.ExplicitYear != v.explicitYear {
+			t.Errorf("Expected \"%s\" to give designator %s (explicit %t), explicit month %t and year %t, but got %+v", v.input, v.designator, v.explicitDesignator, v.explicitMonth, v.explicitYear, d)
+		}
+		if _, offset := d.DTG.Time.Zone(); (v.offset != -1 && d.Offset != v.offset) || d.Offset != offset {
+			t.Errorf("Expected offset %d for \"%s\", but got %d", v.offset, v.input, d.Offset)
+		}
+		if d.Reference.Before(before) || d.Reference.After(time.Now()) {
+			t.Errorf("Expected the reference time to be now, but got %s", d.Reference)
+		}
+	}
+	if _, err := ParseDetailed(`441200Z`); err == nil {
+		t.Error("Expected to fail on \"441200Z\", but succeeded")
+	}
+}
+
+func TestParserStrict(t *testing.T) {
+	p := &Parser{Strict: true}
+	if d, err := p.Parse(" 151230ZDEC19 "); err != nil || d.String() != "151230ZDEC19" {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\" (%v)", d, err)
+	}
+	for _, invalid := range []string{`151230zDEC19`, `151230ZDec19`, `151230JDEC19`, `151230DEC19`, `151230Z`, `151230ZDEC`, `151230ZOKT19`} {
+		if _, err := p.Parse(invalid); !errors.Is(err, ErrNotStrict) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrNotStrict, invalid, err)
+		}
+		if err := ValidateStrictNATO(invalid); !errors.Is(err, ErrNotStrict) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrNotStrict, invalid, err)
+		}
+	}
+	if d, err := ParseStrictNATO(`151230BDEC19`); err != nil || d.String() != `151230BDEC19` {
+		t.Errorf("Expected \"151230BDEC19\", but got \"%s\" (%v)", d, err)
+	}
+	if err := ValidateStrictNATO(`151260ZDEC19`); err == nil || errors.Is(err, ErrNotStrict) {
+		t.Errorf("Expected a parse error, but got %v", err)
+	}
+}
+
+func TestParserCenturyPivot(t *testing.T) {
+	tests := []struct {
+		pivot    int
+		input    string
+		expected int
+	}{
+		{0, `271337ZJAN29`, 2029},
+		{0, `271337ZJAN69`, 1969},
+		{1929, `271337ZJAN29`, 1929},
+		{1929, `271337ZJAN28`, 2028},
+		{1900, `271337ZJAN99`, 1999},
+		{1900, `271337ZJAN00`, 1900},
+		{2000, `271337ZJAN99`, 2099},
+	}
+	for _, test := range tests {
+		d, err := (&Parser{CenturyPivot: test.pivot}).Parse(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Year() != test.expected || d.Day() != 27 || d.Hour() != 13 {
+			t.Errorf("Expected \"%s\" with pivot %d in %d, but got %s", test.input, test.pivot, test.expected, d.Time)
+		}
+	}
+	if _, err := (&Parser{CenturyPivot: 1900}).Parse(`290000ZFEB00`); !errors.Is(err, ErrNoSuchDay) {
+		t.Errorf("Expected %v for 29 February 1900, but got %v", ErrNoSuchDay, err)
+	}
+}
+
+func TestParseFourDigitYears(t *testing.T) {
+	tests := []struct {
+		pivot    int
+		input    string
+		expected int
+	}{
+		{0, `271337ZJAN2029`, 2029},
+		{0, `271337ZJAN1929`, 1929},
+		{0, `271337ZJAN2100`, 2100},
+		{1929, `271337ZJAN2029`, 2029},
+		{0, `2713372029`, 2029},
+		{0, `290000ZFEB2000`, 2000},
+	}
+	for _, test := range tests {
+		d, err := (&Parser{CenturyPivot: test.pivot}).Parse(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Year() != test.expected {
+			t.Errorf("Expected \"%s\" with pivot %d in %d, but got %s", test.input, test.pivot, test.expected, d.Time)
+		}
+	}
+	for _, invalid := range []string{`290000ZFEB1900`, `290000ZFEB2100`} {
+		if _, err := Parse(invalid); !errors.Is(err, ErrNoSuchDay) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrNoSuchDay, invalid, err)
+		}
+	}
+	for _, invalid := range []string{`271337ZJAN202`, `271337ZJAN20290`} {
+		if err := Validate(invalid); !errors.Is(err, ErrInvalidYear) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrInvalidYear, invalid, err)
+		}
+	}
+	if _, err := Parse(`271337ZJAN2029`, WithStrict()); !errors.Is(err, ErrNotStrict) {
+		t.Errorf("Expected %v, but got %v", ErrNotStrict, err)
+	}
+}
+
+func TestParseFullMonthNames(t *testing.T) {
+	reference := time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC)
+	for i, month := range monthAbbreviations {
+		name := strings.ToUpper(time.Month(i + 1).String())
+		for _, input := range []string{`151200Z` + name + `19`, `151200` + strings.ToLower(name) + `19`, `151200Z` + name} {
+			d, err := Parse(input, WithReferenceTime(reference))
+			if err != nil {
+				t.Errorf("Expected \"%s\" to parse, but got %v", input, err)
+				continue
+			}
+			if d.Month() != time.Month(i+1) || d.String()[6:] != "" && !strings.Contains(d.String(), month) {
+				t.Errorf("Expected \"%s\" in %s, but got \"%s\"", input, month, d)
+			}
+		}
+	}
+	if d, err := Parse(`151200Z DECEMBER 19`, WithLenient()); err != nil || d.String() != "151200ZDEC19" {
+		t.Errorf("Expected \"151200ZDEC19\", but got \"%s\" (%v)", d, err)
+	}
+	if d, err := Parse(`151200ZDECEMBER2019`); err != nil || d.String() != "151200ZDEC19" {
+		t.Errorf("Expected \"151200ZDEC19\", but got \"%s\" (%v)", d, err)
+	}
+	if _, err := Parse(`311200ZSEPTEMBER19`); !errors.Is(err, ErrNoSuchDay) {
+		t.Errorf("Expected %v, but got %v", ErrNoSuchDay, err)
+	}
+	for _, invalid := range []string{`151200ZDECEMB19`, `151200ZSEPT19`, `151200ZDECEMBERS19`} {
+		if err := Validate(invalid); !errors.Is(err, ErrInvalidDTG) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrInvalidDTG, invalid, err)
+		}
+	}
+	if _, err := Parse(`151200ZDECEMBER19`, WithStrict()); !errors.Is(err, ErrNotStrict) {
+		t.Errorf("Expected %v, but got %v", ErrNotStrict, err)
+	}
+}
+
+func TestParseLeapDay(t *testing.T) {
+	if err := Validate(`291200ZFEB23`); !errors.Is(err, ErrNoSuchDay) {
+		t.Errorf("Expected %v, but got %v", ErrNoSuchDay, err)
+	}
+	if err := Validate(`291200ZFEB24`); err != nil {
+		t.Errorf("Expected 291200ZFEB24 to be valid, but got %v", err)
+	}
+	leap := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	common := time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC)
+	if d, err := Parse(`291200ZFEB`, WithReferenceTime(leap)); err != nil || d.String() != `291200ZFEB24` {
+		t.Errorf("Expected \"291200ZFEB24\", but got \"%s\" (%v)", d, err)
+	}
+	if _, err := Parse(`291200ZFEB`, WithReferenceTime(common)); !errors.Is(err, ErrNoSuchDay) {
+		t.Errorf("Expected %v, but got %v", ErrNoSuchDay, err)
+	}
+	for _, test := range []struct {
+		resolution Resolution
+		expected   string
+	}{
+		{ResolveNearest, `291200ZFEB24`},
+		{ResolvePast, `291200ZFEB20`},
+		{ResolveFuture, `291200ZFEB24`},
+	} {
+		if d, err := Parse(`291200ZFEB`, WithReferenceTime(common), WithResolution(test.resolution)); err != nil || d.String() != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\" (%v)", test.expected, d, err)
+		}
+	}
+	if _, err := Parse(`291200ZFEB`, WithReferenceTime(leap), WithStrict()); !errors.Is(err, ErrNotStrict) {
+		t.Errorf("Expected %v, but got %v", ErrNotStrict, err)
+	}
+}
+
+func TestParseCalendarDays(t *testing.T) {
+	reference := time.Date(2021, time.April, 15, 12, 0, 0, 0, time.UTC)
+	for _, invalid := range []string{`311200ZFEB20`, `300000ZFEB20`, `290000ZFEB21`, `310000ZAPR21`, `310000ZJUN`, `310000Z`, `310000`, `311200JNOV21`} {
+		_, err := Parse(invalid, WithReferenceTime(reference))
+		if !errors.Is(err, ErrNoSuchDay) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrNoSuchDay, invalid, err)
+		}
+		if err := (&Parser{Now: func() time.Time { return reference }}).Validate(invalid); !errors.Is(err, ErrNoSuchDay) {
+			t.Errorf("Expected %v for \"%s\", but got %v", ErrNoSuchDay, invalid, err)
+		}
+	}
+	if _, err := Parse(`311200ZFEB20`); err == nil || err.Error() != "no such day in the month: FEB 2020 has 29 days" {
+		t.Errorf("Expected \"no such day in the month: FEB 2020 has 29 days\", but got %v", err)
+	}
+	for _, valid := range []string{`290000ZFEB20`, `310000ZMAR21`, `300000Z`, `301200ZAPR`, `311200ZDEC21`} {
+		if _, err := Parse(valid, WithReferenceTime(reference)); err != nil {
+			t.Errorf("Expected \"%s\" to be valid, but got %v", valid, err)
+		}
+	}
+	if _, err := Parse(`001200ZDEC19`); !errors.Is(err, ErrInvalidDTG) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidDTG, err)
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	reference := time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC)
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		input    string
+		opts     []Option
+		expected string
+	}{
+		{`151230Z`, []Option{WithReferenceTime(reference)}, `151230ZDEC19`},
+		{`011230ZMAR`, []Option{WithReferenceTime(reference)}, `011230ZMAR19`},
+		{`151230`, []Option{WithReferenceTime(reference), WithLocation(stockholm)}, `151230ADEC19`},
+		{`151230J`, []Option{WithReferenceTime(reference.AddDate(0, 6, 0)), WithLocation(stockholm)}, `151230BJUN20`},
+		{`151230JDEC19`, []Option{WithLocation(time.FixedZone("", -5*3600))}, `151230RDEC19`},
+	}
+	for _, test := range tests {
+		d, err := Parse(test.input, test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("Expected \"%s\" to give \"%s\", but got \"%s\"", test.input, test.expected, d)
+		}
+	}
+	details, err := ParseDetailed(`151230Z`, WithReferenceTime(reference))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !details.Reference.Equal(reference) {
+		t.Errorf("Expected reference %s, but got %s", reference, details.Reference)
+	}
+	if _, err := Parse(`151230Z`, WithStrict()); !errors.Is(err, ErrNotStrict) {
+		t.Errorf("Expected %v, but got %v", ErrNotStrict, err)
+	}
+	years := []struct {
+		input    string
+		opts     []Option
+		expected int
+	}{
+		{`271337ZJAN29`, nil, 2029},
+		{`271337ZJAN29`, []Option{WithCenturyPivot(1929)}, 1929},
+		{`271337ZJAN29`, []Option{WithCenturyPivot(1930)}, 2029},
+		{`271337ZJAN99`, []Option{WithCenturyPivot(1930)}, 1999},
+		{`271337ZJAN05`, []Option{WithCentury(19)}, 1905},
+		{`271337ZJAN69`, []Option{WithCentury(20)}, 2069},
+	}
+	for _, test := range years {
+		d, err := Parse(test.input, test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Year() != test.expected {
+			t.Errorf("Expected \"%s\" to be in %d, but got %d", test.input, test.expected, d.Year())
+		}
+	}
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	p := &Parser{}
+	if d, err := p.Parse(`151230D*DEC19`, WithZones(zones)); err != nil || d.Time.UTC().Hour() != 8 {
+		t.Errorf("Expected 0800Z, but got %s (%v)", d.Time.UTC(), err)
+	}
+	if p.Zones != nil {
+		t.Error("Expected options not to modify the Parser")
+	}
+}
+
+// countingParser is a decorator of Interface as applications would write.
+type countingParser struct {
+	Interface
+	calls int
+}
+
+func (c *countingParser) Parse(dtgString string, opts ...Option) (DTG, error) {
+	c.calls++
+	return c.Interface.Parse(dtgString, opts...)
+}
+
+func TestInterface(t *testing.T) {
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	c := &countingParser{Interface: &Parser{Zones: zones}}
+	var p Interface = c
+	d, err := p.Parse(`151200D*DEC19`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Format(d); got != `151200D*DEC19` {
+		t.Errorf("Expected \"151200D*DEC19\", but got \"%s\"", got)
+	}
+	if err := p.Validate(`151260ZDEC19`); err == nil {
+		t.Error("Expected error, but got nil")
+	}
+	if c.calls != 1 {
+		t.Errorf("Expected 1 call, but got %d", c.calls)
+	}
+	if got := (*Parser)(nil).Format(d); got != d.String() {
+		t.Errorf("Expected \"%s\", but got \"%s\"", d.String(), got)
+	}
+}
+
+func TestParseResolution(t *testing.T) {
+	// Just after midnight on the 1st.
+	reference := time.Date(2019, time.December, 1, 0, 10, 0, 0, time.UTC)
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		input      string
+		resolution Resolution
+		expected   string
+	}{
+		{`302350Z`, ResolveCurrent, `302350ZDEC19`},
+		{`302350Z`, ResolveNearest, `302350ZNOV19`},
+		{`302350Z`, ResolvePast, `302350ZNOV19`},
+		{`302350Z`, ResolveFuture, `302350ZDEC19`},
+		{`010005Z`, ResolveNearest, `010005ZDEC19`},
+		{`010015Z`, ResolvePast, `010015ZNOV19`},
+		{`010015Z`, ResolveFuture, `010015ZDEC19`},
+		{`310000Z`, ResolvePast, `310000ZOCT19`},
+		{`310000Z`, ResolveNearest, `310000ZDEC19`},
+		{`150000ZJAN`, ResolveCurrent, `150000ZJAN19`},
+		{`150000ZJAN`, ResolveNearest, `150000ZJAN20`},
+		{`150000ZJAN`, ResolvePast, `150000ZJAN19`},
+		{`290000ZFEB`, ResolvePast, `290000ZFEB16`},
+		{`290000ZFEB`, ResolveFuture, `290000ZFEB20`},
+		{`151230`, ResolveFuture, `151230ADEC19`},
+		{`151230ZDEC18`, ResolveNearest, `151230ZDEC18`},
+	}
+	for _, test := range tests {
+		d, err := Parse(test.input, WithReferenceTime(reference), WithLocation(stockholm), WithResolution(test.resolution))
+		if err != nil {
+			t.Errorf("Expected \"%s\" to give \"%s\", but got error %v", test.input, test.expected, err)
+			continue
+		}
+		if d.String() != test.expected {
+			t.Errorf("Expected \"%s\" to give \"%s\", but got \"%s\"", test.input, test.expected, d)
+		}
+	}
+	if _, err := Parse(`151260Z`, WithResolution(ResolveNearest)); !errors.Is(err, ErrInvalidDTG) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidDTG, err)
+	}
+}
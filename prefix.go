@@ -0,0 +1,71 @@
+package dtg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrTrailingText error = errors.New("unexpected text after DTG")
+
+// prefixRegexp matches a DTG at the start of a string in the compact or
+// spaced form of TextRegexp, followed by a character other than a letter or
+// digit or the end of the string.
+var prefixRegexp *regexp.Regexp = regexp.MustCompile(`(?i)^([0-9]{2})([0-9]{2})([0-9]{2})([A-Z]\*?)?(?: ?` + MonthPattern + `(?: ?([0-9]{4}|[0-9]{2}))?)?(?:[^0-9A-Za-z*]|$)`)
+
+// signalRegexp matches an operating signal from the Z series, e.g. ZUI or
+// ZFG, see ACP 131.
+var signalRegexp *regexp.Regexp = regexp.MustCompile(`^Z[A-Z]{2}$`)
+
+// ParsePrefix parses the DTG at the start of s, compact (151230ZDEC19) or
+// spaced (151230Z DEC 19), and returns the rest of s after the DTG with
+// leading white space removed. Leading white space of s is ignored.
+func ParsePrefix(s string) (DTG, string, error) {
+	return (*Parser)(nil).ParsePrefix(s)
+}
+
+// ParsePrefix is like the package level ParsePrefix, but uses the Parser's
+// zone table.
+func (p *Parser) ParsePrefix(s string) (DTG, string, error) {
+	s = strings.TrimLeft(s, " \t")
+	loc := prefixRegexp.FindStringIndex(s)
+	if loc == nil {
+		return DTG{}, s, ErrInvalidDTG
+	}
+	end := loc[1]
+	// Drop the terminating character (if any) from the match.
+	for end > 0 && !isDtgByte(s[end-1]) {
+		end--
+	}
+	dtg, err := p.Parse(strings.ReplaceAll(s[:end], " ", ""))
+	if err != nil {
+		return DTG{}, s, err
+	}
+	return dtg, strings.TrimLeft(s[end:], " \t"), nil
+}
+
+// ParseSignals parses a header DTG followed by operating signals, e.g.
+// "151230Z DEC 19 ZUI ZFG", and returns the signals in upper case. Any
+// trailing text that is not a three letter Z-signal fails with
+// ErrTrailingText rather than being silently ignored.
+func ParseSignals(s string) (DTG, []string, error) {
+	return (*Parser)(nil).ParseSignals(s)
+}
+
+// ParseSignals is like the package level ParseSignals, but uses the
+// Parser's zone table.
+func (p *Parser) ParseSignals(s string) (DTG, []string, error) {
+	dtg, rest, err := p.ParsePrefix(s)
+	if err != nil {
+		return DTG{}, nil, err
+	}
+	var signals []string
+	for _, field := range strings.Fields(rest) {
+		field = strings.ToUpper(field)
+		if !signalRegexp.MatchString(field) {
+			return DTG{}, nil, ErrTrailingText
+		}
+		signals = append(signals, field)
+	}
+	return dtg, signals, nil
+}
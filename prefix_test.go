@@ -0,0 +1,58 @@
+package dtg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		input     string
+		canonical string
+		rest      string
+	}{
+		{"151230Z DEC 19 ZUI", "151230ZDEC19", "ZUI"},
+		{"  151230ZDEC19", "151230ZDEC19", ""},
+		{"151230BDEC19/FLASH", "151230BDEC19", "/FLASH"},
+		{"010000Z JAN 20 FM 2BN", "010000ZJAN20", "FM 2BN"},
+		{"151200Z DECEMBER 19 ZUI", "151200ZDEC19", "ZUI"},
+		{"151230ZDEC2019 ZUI", "151230ZDEC19", "ZUI"},
+		{"151230Z DEC 2019", "151230ZDEC19", ""},
+	}
+	for _, test := range tests {
+		d, rest, err := ParsePrefix(test.input)
+		if err != nil {
+			t.Fatalf("Expected \"%s\" to parse, but got %v", test.input, err)
+		}
+		if d.String() != test.canonical || rest != test.rest {
+			t.Errorf("Expected \"%s\" and rest \"%s\", but got \"%s\" and \"%s\"", test.canonical, test.rest, d, rest)
+		}
+	}
+	if _, rest, err := ParsePrefix("151230 ZUI"); err != nil || rest != "ZUI" {
+		t.Errorf("Expected a DTG without designator and rest \"ZUI\", but got \"%s\" (%v)", rest, err)
+	}
+	for _, invalid := range []string{"", "FM 151230Z", "1512301Z", "151230ZDECEMBERS"} {
+		if _, _, err := ParsePrefix(invalid); err == nil {
+			t.Errorf("Expected an error for \"%s\"", invalid)
+		}
+	}
+}
+
+func TestParseSignals(t *testing.T) {
+	d, signals, err := ParseSignals("151230Z DEC 19 ZUI zfg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.String() != "151230ZDEC19" || strings.Join(signals, " ") != "ZUI ZFG" {
+		t.Errorf("Expected 151230ZDEC19 with ZUI and ZFG, but got %s with %v", d, signals)
+	}
+	if d, signals, err := ParseSignals("151230ZDEC2019 ZUI"); err != nil || d.Year() != 2019 || len(signals) != 1 {
+		t.Errorf("Expected 151230ZDEC19 with ZUI, but got %s with %v (%v)", d, signals, err)
+	}
+	if _, signals, err := ParseSignals("151230ZDEC19"); err != nil || signals != nil {
+		t.Errorf("Expected no signals, but got %v (%v)", signals, err)
+	}
+	if _, _, err := ParseSignals("151230Z DEC 19 ZUI FM HQ"); err != ErrTrailingText {
+		t.Errorf("Expected ErrTrailingText, but got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package dtg
+
+import (
+	"strings"
+	"time"
+)
+
+// monthAbbreviations are the months in the order and form String writes
+// them.
+var monthAbbreviations = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+// Prompt provides inline validation and tab completion for collecting
+// DTGs from operators in terminal applications. It does not depend on any
+// prompt library; wire Validate, ValidatePrefix and Complete into the
+// validator and completer hooks of the library in use, or use PromptDTG for
+// the common pair. The zero value uses the default zone table and the
+// current time.
+type Prompt struct {
+	// Parser resolves the DTGs, the package level Parse when nil.
+	Parser *Parser
+	// Now returns the time used to suggest years, time.Now when nil.
+	Now func() time.Time
+}
+
+// PromptDTG returns the validation and completion functions of a zero
+// Prompt, for prompt libraries taking a func(string) error validator and a
+// func(string) []string completer.
+func PromptDTG() (validate func(string) error, complete func(string) []string) {
+	p := &Prompt{}
+	return p.Validate, p.Complete
+}
+
+// Validate returns nil if input is a valid DTG, otherwise the parse error
+// to show next to the input.
+func (p *Prompt) Validate(input string) error {
+	return p.Parser.Validate(input)
+}
+
+// ValidatePrefix returns nil if input is a valid DTG or could become one by
+// typing more, so that a prompt can flag a mistake at the keystroke where
+// it happens rather than on submit.
+func (p *Prompt) ValidatePrefix(input string) error {
+	s := strings.ToUpper(strings.TrimSpace(input))
+	digits := len(s)
+	if digits > 6 {
+		digits = 6
+	}
+	for i := 0; i < digits; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return ErrInvalidDTG
+		}
+	}
+	limits := []int{31, 23, 59}
+	for group := 0; group < 3 && 2*group+2 <= digits; group++ {
+		n := int(s[2*group]-'0')*10 + int(s[2*group+1]-'0')
+		if n > limits[group] || (group == 0 && n == 0) {
+			return ErrInvalidDTG
+		}
+	}
+	if len(s) <= 6 || p.suffixPrefix(s[6:]) {
+		return nil
+	}
+	return ErrInvalidDTG
+}
+
+// suffixPrefix reports whether s is the beginning of a designator, month
+// and year following ddHHMM.
+func (p *Prompt) suffixPrefix(s string) bool {
+	for _, designator := range append(p.designators(), "") {
+		if designator != "" && strings.HasPrefix(designator, s) {
+			return true
+		}
+		if !strings.HasPrefix(s, designator) {
+			continue
+		}
+		rest := s[len(designator):]
+		for _, month := range monthAbbreviations {
+			if strings.HasPrefix(month, rest) {
+				return true
+			}
+			if strings.HasPrefix(rest, month) {
+				year := rest[len(month):]
+				if len(year) <= 2 && (year == "" || isDigits(year)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Complete returns the completions of the group being typed, see
+// Completions, using the Prompt's zone table and time.
+func (p *Prompt) Complete(input string) []string {
+	s := strings.ToUpper(strings.TrimSpace(input))
+	if p.ValidatePrefix(s) != nil {
+		return nil
+	}
+	if len(s) < 6 {
+		return digitCompletions(s)
+	}
+	head, rest := s[:6], s[6:]
+	var completions []string
+	if rest == "" {
+		for _, designator := range p.designators() {
+			completions = append(completions, head+designator)
+		}
+		return completions
+	}
+	designator := ""
+	for _, d := range p.designators() {
+		if strings.HasPrefix(rest, d) && len(d) > len(designator) {
+			designator = d
+		}
+	}
+	if designator == "" {
+		return nil
+	}
+	head, rest = head+designator, rest[len(designator):]
+	for _, month := range monthAbbreviations {
+		if strings.HasPrefix(month, rest) && month != rest {
+			completions = append(completions, head+month)
+		}
+	}
+	if len(completions) > 0 || len(rest) != 3 {
+		return completions
+	}
+	now := time.Now()
+	if p.Now != nil {
+		now = p.Now()
+	}
+	for _, year := range []int{now.Year(), now.Year() + 1} {
+		completions = append(completions, head+rest+twoDigits(year%100))
+	}
+	return completions
+}
+
+// Completions returns the valid continuations of a partial DTG, one group
+// at a time, for editor plugins and interactive prompts: the days 01-31,
+// hours 00-23 and minutes 00-59 while typing ddHHMM (matching a digit
+// already typed of the group), the zone letters after ddHHMM (Z and J
+// first), the months after the letter and this and next year after the
+// month. Completions are whole inputs in upper case, nil if prefix cannot
+// become a valid DTG.
+func Completions(prefix string) []string {
+	return (&Prompt{}).Complete(prefix)
+}
+
+// digitCompletions completes the day, hour or minute group of a prefix of
+// at most 5 digits.
+func digitCompletions(s string) []string {
+	group := len(s) / 2
+	low, high := []int{1, 0, 0}[group], []int{31, 23, 59}[group]
+	head, typed := s[:group*2], s[group*2:]
+	var completions []string
+	for n := low; n <= high; n++ {
+		if digits := twoDigits(n); strings.HasPrefix(digits, typed) {
+			completions = append(completions, head+digits)
+		}
+	}
+	return completions
+}
+
+// designators returns the designators of the zone table, plus J unless the
+// table defines it, with J and Z first as the most common.
+func (p *Prompt) designators() []string {
+	designators := []string{"Z", "J"}
+	for _, d := range p.Parser.zones().Designators() {
+		if d != "Z" && d != "J" {
+			designators = append(designators, d)
+		}
+	}
+	return designators
+}
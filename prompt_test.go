@@ -0,0 +1,61 @@
+package dtg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromptValidatePrefix(t *testing.T) {
+	p := &Prompt{}
+	for _, s := range []string{"", "1", "15", "1512", "151230", "151230Z", "151230ZD", "151230ZDEC", "151230ZDEC1", "151230zdec19", "151230D", "151230DEC19", "151230J"} {
+		if err := p.ValidatePrefix(s); err != nil {
+			t.Errorf("Expected \"%s\" to be a valid prefix, but got %v", s, err)
+		}
+	}
+	for _, s := range []string{"x", "32", "00", "1524", "151260", "151230Z1", "151230ZDX", "151230ZDEC1X", "151230ZDEC199", "151230ÖDEC"} {
+		if err := p.ValidatePrefix(s); err == nil {
+			t.Errorf("Expected \"%s\" to be an invalid prefix", s)
+		}
+	}
+}
+
+func TestPromptComplete(t *testing.T) {
+	p := &Prompt{Now: func() time.Time { return time.Date(2019, 12, 15, 0, 0, 0, 0, time.UTC) }}
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"15123", "151230 151231 151232 151233 151234 151235 151236 151237 151238 151239"},
+		{"156", ""},
+		{"151230zd", "151230ZDEC"},
+		{"151230ZJ", "151230ZJAN 151230ZJUN 151230ZJUL"},
+		{"151230ZDEC", "151230ZDEC19 151230ZDEC20"},
+		{"151230ZDEC19", ""},
+		{"151230ÖDEC", ""},
+	}
+	for _, test := range tests {
+		if got := strings.Join(p.Complete(test.input), " "); got != test.expected {
+			t.Errorf("Expected completions \"%s\" of \"%s\", but got \"%s\"", test.expected, test.input, got)
+		}
+	}
+	if got := p.Complete("151230"); len(got) != 26 || got[0] != "151230Z" || got[1] != "151230J" {
+		t.Errorf("Expected Z, J and the other 24 letters, but got %v", got)
+	}
+	if got := Completions("1512"); len(got) != 60 || got[0] != "151200" || got[59] != "151259" {
+		t.Errorf("Expected the 60 minutes, but got %v", got)
+	}
+	if got := Completions("3"); strings.Join(got, " ") != "30 31" {
+		t.Errorf("Expected days 30 and 31, but got %v", got)
+	}
+	if got := Completions(""); len(got) != 31 || got[0] != "01" {
+		t.Errorf("Expected the 31 days, but got %v", got)
+	}
+	if got := Completions("151230ZN"); strings.Join(got, " ") != "151230ZNOV" {
+		t.Errorf("Expected 151230ZNOV, but got %v", got)
+	}
+	validate, complete := PromptDTG()
+	if validate("151230ZDEC19") != nil || validate("151230ZDEC1") == nil || complete("151230ZNO")[0] != "151230ZNOV" {
+		t.Errorf("Expected PromptDTG to validate and complete like a zero Prompt")
+	}
+}
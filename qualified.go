@@ -0,0 +1,169 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidQualifier error = errors.New("invalid DTG qualifier")
+
+// Qualifier tells how a reported DTG is to be understood, e.g. "ABT 151200Z"
+// or "151200Z EST.".
+type Qualifier int
+
+const (
+	// Exact is an unqualified DTG.
+	Exact Qualifier = iota
+	// About is an approximate DTG (ABT, APPROX, CA, CIRCA).
+	About
+	// Estimated is an estimated DTG (EST, ESTD, ESTIMATED).
+	Estimated
+	// NotBefore is a lower bound (NET, NBT, NOT BEFORE, NO EARLIER THAN).
+	NotBefore
+	// NotAfter is an upper bound (NLT, NAT, NOT AFTER, NO LATER THAN).
+	NotAfter
+)
+
+// qualifierWords maps prefixes and suffixes (in upper case, one word per
+// element) to qualifiers. Longer phrases must come first.
+var qualifierWords = []struct {
+	words     []string
+	qualifier Qualifier
+}{
+	{[]string{"NO", "EARLIER", "THAN"}, NotBefore},
+	{[]string{"NOT", "EARLIER", "THAN"}, NotBefore},
+	{[]string{"NO", "LATER", "THAN"}, NotAfter},
+	{[]string{"NOT", "LATER", "THAN"}, NotAfter},
+	{[]string{"NOT", "BEFORE"}, NotBefore},
+	{[]string{"NOT", "AFTER"}, NotAfter},
+	{[]string{"ABT"}, About},
+	{[]string{"ABOUT"}, About},
+	{[]string{"APPROX"}, About},
+	{[]string{"APPROXIMATELY"}, About},
+	{[]string{"CA"}, About},
+	{[]string{"CIRCA"}, About},
+	{[]string{"EST"}, Estimated},
+	{[]string{"ESTD"}, Estimated},
+	{[]string{"ESTIMATED"}, Estimated},
+	{[]string{"NET"}, NotBefore},
+	{[]string{"NBT"}, NotBefore},
+	{[]string{"NLT"}, NotAfter},
+	{[]string{"NAT"}, NotAfter},
+}
+
+// String returns the canonical abbreviation of the qualifier, or an empty
+// string for Exact.
+func (q Qualifier) String() string {
+	switch q {
+	case About:
+		return "ABT"
+	case Estimated:
+		return "EST"
+	case NotBefore:
+		return "NET"
+	case NotAfter:
+		return "NLT"
+	}
+	return ""
+}
+
+// MarshalText implements encoding.TextMarshaler, see String.
+func (q Qualifier) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for the qualifiers
+// ParseQualified accepts, e.g. "ABT" or "not before", an empty text being
+// Exact.
+func (q *Qualifier) UnmarshalText(text []byte) error {
+	words := strings.Fields(strings.ToUpper(string(text)))
+	if len(words) == 0 {
+		*q = Exact
+		return nil
+	}
+	qualifier, n := matchQualifier(words)
+	if n != len(words) {
+		return ErrInvalidQualifier
+	}
+	*q = qualifier
+	return nil
+}
+
+// Qualified is a DTG with the qualifier it was reported with, so the
+// information is not thrown away during extraction. The DTG is not
+// embedded, so that marshalling a Qualified (with the methods of time.Time)
+// keeps the qualifier.
+type Qualified struct {
+	DTG       DTG
+	Qualifier Qualifier
+}
+
+// String returns the qualified DTG with the qualifier abbreviation as a
+// prefix, e.g. "ABT 151200ZDEC19", or just the DTG if Exact.
+func (q Qualified) String() string {
+	if q.Qualifier == Exact {
+		return q.DTG.String()
+	}
+	return q.Qualifier.String() + " " + q.DTG.String()
+}
+
+// ParseQualified parses a DTG optionally preceded or followed by a
+// qualifier, e.g. "ABT 151200Z", "NLT 151200ZDEC19" or "151200Z EST.". Case
+// and punctuation around the qualifier (a trailing period, parentheses) are
+// ignored. The DTG may be spaced as in ParsePrefix, e.g. "151230Z DEC 19
+// EST". A DTG with both a prefix and a suffix qualifier is invalid.
+func ParseQualified(s string) (Qualified, error) {
+	return (*Parser)(nil).ParseQualified(s)
+}
+
+// ParseQualified is like the package level ParseQualified, but uses the
+// Parser's zone table.
+func (p *Parser) ParseQualified(s string) (q Qualified, err error) {
+	words := strings.Fields(strings.ToUpper(s))
+	for i := range words {
+		words[i] = strings.Trim(words[i], ".,()")
+	}
+	prefix, n := matchQualifier(words)
+	words = words[n:]
+	suffix := Exact
+	for i := len(words) - 1; i > 0 && suffix == Exact; i-- {
+		if qualifier, n := matchQualifier(words[i:]); n == len(words)-i {
+			suffix = qualifier
+			words = words[:i]
+		}
+	}
+	if len(words) == 0 || (prefix != Exact && suffix != Exact) {
+		return q, ErrInvalidDTG
+	}
+	var rest string
+	q.DTG, rest, err = p.ParsePrefix(strings.Join(words, " "))
+	if err != nil {
+		return q, err
+	}
+	if rest != "" {
+		return q, ErrInvalidDTG
+	}
+	q.Qualifier = prefix
+	if suffix != Exact {
+		q.Qualifier = suffix
+	}
+	return q, nil
+}
+
+// matchQualifier returns the qualifier starting at words[0] and the number
+// of words it consists of, or Exact and 0.
+func matchQualifier(words []string) (Qualifier, int) {
+next:
+	for _, qw := range qualifierWords {
+		if len(qw.words) > len(words) {
+			continue
+		}
+		for i, w := range qw.words {
+			if words[i] != w {
+				continue next
+			}
+		}
+		return qw.qualifier, len(qw.words)
+	}
+	return Exact, 0
+}
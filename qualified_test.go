@@ -0,0 +1,78 @@
+package dtg
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseQualified(t *testing.T) {
+	testTable := []struct {
+		input     string
+		qualifier Qualifier
+		expected  string
+	}{
+		{`151200ZDEC19`, Exact, `151200ZDEC19`},
+		{`ABT 151200ZDEC19`, About, `ABT 151200ZDEC19`},
+		{`abt. 151200zdec19`, About, `ABT 151200ZDEC19`},
+		{`151200ZDEC19 EST.`, Estimated, `EST 151200ZDEC19`},
+		{`151200ZDEC19 (est)`, Estimated, `EST 151200ZDEC19`},
+		{`circa 151200ZDEC19`, About, `ABT 151200ZDEC19`},
+		{`151200ZDEC19 approx`, About, `ABT 151200ZDEC19`},
+		{`NET 151200ZDEC19`, NotBefore, `NET 151200ZDEC19`},
+		{`not before 151200ZDEC19`, NotBefore, `NET 151200ZDEC19`},
+		{`No earlier than 151200ZDEC19`, NotBefore, `NET 151200ZDEC19`},
+		{`NLT 151200ZDEC19`, NotAfter, `NLT 151200ZDEC19`},
+		{`151200ZDEC19 not after`, NotAfter, `NLT 151200ZDEC19`},
+		{`NO LATER THAN 151200ZDEC19`, NotAfter, `NLT 151200ZDEC19`},
+		{`151200Z DEC 19 EST`, Estimated, `EST 151200ZDEC19`},
+		{`abt 151200Z DEC19`, About, `ABT 151200ZDEC19`},
+	}
+	for _, v := range testTable {
+		q, err := ParseQualified(v.input)
+		if err != nil {
+			t.Fatalf("Unexpected error for \"%s\": %v", v.input, err)
+		}
+		if q.Qualifier != v.qualifier {
+			t.Errorf("Expected qualifier %d for \"%s\", but got %d", v.qualifier, v.input, q.Qualifier)
+		}
+		if q.String() != v.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", v.expected, q.String())
+		}
+		if q.DTG.String() != `151200ZDEC19` {
+			t.Errorf("Expected DTG 151200ZDEC19, but got %s", q.DTG)
+		}
+	}
+	invalid := []string{``, `ABT`, `ABT EST`, `ABT 151200ZDEC19 EST`, `151200Z DEC 19 ZUI`, `ABT 441200ZDEC19`, `SOON 151200ZDEC19`, `NOT 151200ZDEC19`}
+	for _, v := range invalid {
+		if _, err := ParseQualified(v); err == nil {
+			t.Errorf("Expected to fail on \"%s\", but succeeded", v)
+		}
+	}
+}
+
+func TestQualifiedJSON(t *testing.T) {
+	q, err := ParseQualified(`NLT 151200BDEC19`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"DTG":"2019-12-15T12:00:00+02:00","Qualifier":"NLT"}`; string(b) != expected {
+		t.Errorf("Expected %s, but got %s", expected, b)
+	}
+	var u Qualified
+	if err := json.Unmarshal(b, &u); err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != `NLT 151200BDEC19` {
+		t.Errorf("Expected \"NLT 151200BDEC19\", but got \"%s\"", u)
+	}
+	if err := json.Unmarshal([]byte(`{"DTG":"2019-12-15T12:00:00Z","Qualifier":"not before"}`), &u); err != nil || u.Qualifier != NotBefore {
+		t.Errorf("Expected %v, but got %v (%v)", NotBefore, u.Qualifier, err)
+	}
+	if err := json.Unmarshal([]byte(`{"Qualifier":"SOON"}`), &u); err == nil {
+		t.Error("Expected an invalid qualifier to fail")
+	}
+}
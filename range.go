@@ -0,0 +1,95 @@
+package dtg
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRange error = errors.New("invalid DTG range (must be start/end with end not before start)")
+
+// Range is the span of time between two DTGs, e.g. a scheduled activity.
+// End is not before Start. A Range with End equal to Start is a point in
+// time.
+type Range struct {
+	Start DTG
+	End   DTG
+}
+
+// NewRange returns the Range from start to end, or ErrInvalidRange if end
+// is before start.
+func NewRange(start, end DTG) (Range, error) {
+	if end.Time.Before(start.Time) {
+		return Range{}, ErrInvalidRange
+	}
+	return Range{Start: start, End: end}, nil
+}
+
+// Duration returns the length of the Range.
+func (r Range) Duration() time.Duration {
+	return r.End.Time.Sub(r.Start.Time)
+}
+
+// Contains reports whether dtg is within the Range, start and end
+// included.
+func (r Range) Contains(dtg DTG) bool {
+	return !dtg.Time.Before(r.Start.Time) && !dtg.Time.After(r.End.Time)
+}
+
+// String returns the Range as start/end, e.g. 150800ZDEC19/151000ZDEC19, in
+// the style of ISO 8601 intervals. A point in time is only the start DTG.
+func (r Range) String() string {
+	if r.End.Time.Equal(r.Start.Time) {
+		return r.Start.String()
+	}
+	return r.Start.String() + "/" + r.End.String()
+}
+
+// ParseRange parses a Range in the form returned by String.
+func ParseRange(s string) (Range, error) {
+	return (*Parser)(nil).ParseRange(s)
+}
+
+// ParseRange is like the package level ParseRange, but uses the Parser's
+// zone table.
+func (p *Parser) ParseRange(s string) (Range, error) {
+	startString, endString, found := strings.Cut(s, "/")
+	start, err := p.Parse(startString)
+	if err != nil {
+		return Range{}, err
+	}
+	if !found {
+		return Range{Start: start, End: start}, nil
+	}
+	end, err := p.Parse(endString)
+	if err != nil {
+		return Range{}, err
+	}
+	return NewRange(start, end)
+}
+
+// RandomIn returns a DTG uniformly distributed over the whole minutes (the
+// resolution of a DTG) of r, start and end included, in the zone of the
+// start DTG. It is meant for exercise inject generators and load tests, rng
+// is the source of randomness (the global math/rand source when nil). A
+// Range without a whole minute returns r.Start.
+func RandomIn(r Range, rng *rand.Rand) DTG {
+	loc := r.Start.Time.Location()
+	first := r.Start.Time.Truncate(time.Minute)
+	if first.Before(r.Start.Time) {
+		first = first.Add(time.Minute)
+	}
+	last := r.End.Time.Truncate(time.Minute)
+	if last.Before(first) {
+		return r.Start
+	}
+	n := int64(last.Sub(first)/time.Minute) + 1
+	var i int64
+	if rng == nil {
+		i = rand.Int63n(n)
+	} else {
+		i = rng.Int63n(n)
+	}
+	return DTG{Time: first.Add(time.Duration(i) * time.Minute).In(loc)}
+}
@@ -0,0 +1,77 @@
+package dtg
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRange(t *testing.T) {
+	r, err := ParseRange("150800ZDEC19/151100BDEC19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Duration() != time.Hour {
+		t.Errorf("Expected a duration of 1h, but got %s", r.Duration())
+	}
+	if r.String() != "150800ZDEC19/151100BDEC19" {
+		t.Errorf("Expected \"150800ZDEC19/151100BDEC19\", but got \"%s\"", r)
+	}
+	for _, test := range []struct {
+		dtg      string
+		expected bool
+	}{
+		{"150759ZDEC19", false},
+		{"150800ZDEC19", true},
+		{"150830ZDEC19", true},
+		{"150900ZDEC19", true},
+		{"150901ZDEC19", false},
+	} {
+		if r.Contains(mustParse(t, test.dtg)) != test.expected {
+			t.Errorf("Expected %s in %s to be %v", test.dtg, r, test.expected)
+		}
+	}
+	point, err := ParseRange("150800ZDEC19")
+	if err != nil || point.Duration() != 0 || point.String() != "150800ZDEC19" {
+		t.Errorf("Expected a point in time, but got %s (%v)", point, err)
+	}
+	if _, err := ParseRange("151000ZDEC19/150800ZDEC19"); err != ErrInvalidRange {
+		t.Errorf("Expected ErrInvalidRange, but got %v", err)
+	}
+	if _, err := ParseRange("150800ZDEC19/"); err == nil {
+		t.Errorf("Expected an error for a missing end")
+	}
+}
+
+func TestRandomIn(t *testing.T) {
+	r, err := ParseRange("150800BDEC19/150809BDEC19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	seen := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		d := RandomIn(r, rng)
+		if !r.Contains(d) || d.Time.Second() != 0 {
+			t.Fatalf("Expected a whole minute within %s, but got %s", r, d.Time)
+		}
+		seen[d.String()]++
+	}
+	if len(seen) != 10 {
+		t.Errorf("Expected all 10 minutes of %s, but got %v", r, seen)
+	}
+	for s := range seen {
+		if s[6] != 'B' {
+			t.Errorf("Expected the zone of the start DTG, but got \"%s\"", s)
+		}
+	}
+	start := mustParse(t, "150800ZDEC19")
+	point := Range{Start: start, End: start}
+	if d := RandomIn(point, nil); !d.Time.Equal(start.Time) {
+		t.Errorf("Expected %s, but got %s", start, d)
+	}
+	within := Range{Start: DTG{Time: start.Time.Add(10 * time.Second)}, End: DTG{Time: start.Time.Add(50 * time.Second)}}
+	if d := RandomIn(within, rng); !d.Time.Equal(within.Start.Time) {
+		t.Errorf("Expected %s, but got %s", within.Start.Time, d.Time)
+	}
+}
@@ -0,0 +1,128 @@
+package dtg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Redactor rewrites all DTGs in a document for releasable material, e.g.
+// training packages produced from real traffic. Either every DTG is shifted
+// by the same (secret) offset, preserving the relative timing of events, or
+// coarsened to day precision, or both.
+type Redactor struct {
+	// Shift is added to every DTG, see ShiftFromSecret.
+	Shift time.Duration
+	// Coarsen replaces the hour and minute of every DTG with XXXX, e.g.
+	// 15XXXXZDEC19, after shifting.
+	Coarsen bool
+	// Parser finds and parses the DTGs, the default Parser when nil.
+	Parser *Parser
+}
+
+// ShiftFromSecret derives a consistent offset in whole minutes between -max
+// and +max (excluding zero) from a secret, so the same secret always
+// redacts documents the same way without the offset itself being written
+// down. A max below one minute yields zero.
+func ShiftFromSecret(secret []byte, max time.Duration) time.Duration {
+	minutes := int64(max / time.Minute)
+	if minutes < 1 {
+		return 0
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("dtg redactor shift"))
+	n := binary.BigEndian.Uint64(mac.Sum(nil)) % uint64(2*minutes)
+	shift := int64(n) - minutes
+	if shift >= 0 {
+		shift++
+	}
+	return time.Duration(shift) * time.Minute
+}
+
+// RedactDTG returns the redacted form of a single DTG.
+func (r *Redactor) RedactDTG(dtg DTG) string {
+	shifted := DTG{Time: dtg.Time.Add(r.Shift)}
+	s := shifted.String()
+	if r.Coarsen {
+		s = s[:2] + "XXXX" + s[6:]
+	}
+	return s
+}
+
+// redactRegexp matches the DTG-like groups Redact rewrites: like
+// TextRegexp, but the zone designator is optional as in Parse, and the
+// separators before the month and year are captured to be kept.
+var redactRegexp *regexp.Regexp = regexp.MustCompile(`(?i)\b([0-9]{2})([0-9]{2})([0-9]{2})([A-Z]\*?)?(?:( ?)` + MonthPattern + `(?:( ?)([0-9]{4}|[0-9]{2}))?)?(?:[^0-9A-Za-z*]|$)`)
+
+// Redact returns text with every DTG replaced by its redacted form. Only
+// the groups that were written are written, in the same form, e.g.
+// 160730Z DEC 19 stays spaced and 160730Z without month and year, so that
+// no month or year is made up. Redact fails closed: a DTG-like group of six
+// digits (with or without zone designator, month and year) that does not
+// parse, e.g. 441200Z, is masked with X.
+func (r *Redactor) Redact(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range redactRegexp.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		// Drop the terminating character (if any) from the match.
+		for end > start && !isDtgByte(text[end-1]) {
+			end--
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(r.redactMatch(text, loc, end))
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// redactMatch returns the redacted form of the redactRegexp match loc of
+// text ending at end, see Redact.
+func (r *Redactor) redactMatch(text string, loc []int, end int) string {
+	group := func(i int) string {
+		if loc[2*i] < 0 {
+			return ""
+		}
+		return text[loc[2*i]:loc[2*i+1]]
+	}
+	written := text[loc[0]:end]
+	details, err := r.Parser.ParseDetailed(strings.ReplaceAll(written, " ", ""))
+	if err != nil {
+		return strings.Map(func(c rune) rune {
+			if c == ' ' {
+				return c
+			}
+			return 'X'
+		}, written)
+	}
+	shifted := details.DTG.Time.Add(r.Shift)
+	s := shifted.Format(dayLayout)
+	if r.Coarsen {
+		s += "XXXX"
+	} else {
+		s += shifted.Format(hourLayout + minuteLayout)
+	}
+	s += group(4)
+	if month := group(6); month != "" {
+		name := monthAbbreviations[shifted.Month()-1]
+		if len(month) > 3 {
+			name = strings.ToUpper(shifted.Month().String())
+		}
+		if month == strings.ToLower(month) {
+			name = strings.ToLower(name)
+		}
+		s += group(5) + name
+	}
+	if year := group(8); year != "" {
+		if len(year) == 4 {
+			s += group(7) + shifted.Format("2006")
+		} else {
+			s += group(7) + shifted.Format(yearLayout)
+		}
+	}
+	return s
+}
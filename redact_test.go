@@ -0,0 +1,64 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRedactor(t *testing.T) {
+	text := "H-HOUR 160600ZDEC19, PL ALPHA NLT 160730Z DEC 19. ENDEX 312300ZDEC19"
+	testTable := []struct {
+		redactor Redactor
+		expected string
+	}{
+		{Redactor{Shift: 36 * time.Hour}, "H-HOUR 171800ZDEC19, PL ALPHA NLT 171930Z DEC 19. ENDEX 021100ZJAN20"},
+		{Redactor{Shift: -6 * time.Hour, Coarsen: true}, "H-HOUR 16XXXXZDEC19, PL ALPHA NLT 16XXXXZ DEC 19. ENDEX 31XXXXZDEC19"},
+		{Redactor{Coarsen: true}, "H-HOUR 16XXXXZDEC19, PL ALPHA NLT 16XXXXZ DEC 19. ENDEX 31XXXXZDEC19"},
+		{Redactor{}, "H-HOUR 160600ZDEC19, PL ALPHA NLT 160730Z DEC 19. ENDEX 312300ZDEC19"},
+	}
+	for _, v := range testTable {
+		if s := v.redactor.Redact(text); s != v.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", v.expected, s)
+		}
+	}
+	if s := (&Redactor{Shift: 36 * time.Hour}).Redact("ORDERS 151200Z DECEMBER 19 REF"); s != "ORDERS 170000Z DECEMBER 19 REF" {
+		t.Errorf("Expected \"ORDERS 170000Z DECEMBER 19 REF\", but got \"%s\"", s)
+	}
+	shape := Redactor{Shift: 36 * time.Hour, Parser: &Parser{Now: func() time.Time { return time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC) }}}
+	for input, expected := range map[string]string{
+		"AT 151200Z.":             "AT 170000Z.",
+		"AT 151200ZDEC.":          "AT 170000ZDEC.",
+		"AT 151200zdec2019":       "AT 170000zdec2019",
+		"AT 301200ZDEC19":         "AT 010000ZJAN20",
+		"AT 151200B, 151200":      "AT 170000B, 170000",
+		"AT 441200Z OR 151299Z X": "AT XXXXXXX OR XXXXXXX X",
+		"AT 441200Z DEC 19":       "AT XXXXXXX XXX XX",
+	} {
+		if s := shape.Redact(input); s != expected {
+			t.Errorf("Expected \"%s\" to become \"%s\", but got \"%s\"", input, expected, s)
+		}
+	}
+	if s := (&Redactor{Shift: time.Hour}).Redact("no DTGs here"); s != "no DTGs here" {
+		t.Errorf("Expected text without DTGs to be unchanged, but got \"%s\"", s)
+	}
+}
+
+func TestShiftFromSecret(t *testing.T) {
+	max := 30 * 24 * time.Hour
+	a := ShiftFromSecret([]byte("secret"), max)
+	if a != ShiftFromSecret([]byte("secret"), max) {
+		t.Error("Expected the same secret to give the same shift")
+	}
+	if a == ShiftFromSecret([]byte("another secret"), max) {
+		t.Error("Expected different secrets to give different shifts")
+	}
+	for _, secret := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		shift := ShiftFromSecret([]byte(secret), max)
+		if shift == 0 || shift > max || shift < -max || shift%time.Minute != 0 {
+			t.Errorf("Expected a non-zero shift in whole minutes within ±%s, but got %s", max, shift)
+		}
+	}
+	if shift := ShiftFromSecret([]byte("secret"), time.Second); shift != 0 {
+		t.Errorf("Expected no shift for a max below a minute, but got %s", shift)
+	}
+}
@@ -0,0 +1,108 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page layout in points, with 9 point Courier (5.4 points per
+// character) for the tables.
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 40
+	fontSize     = 9
+	titleSize    = 12
+	lineHeight   = 11
+	charsPerLine = (pageWidth - 2*margin) * 10 / (fontSize * 6)
+	linesPerPage = (pageHeight - 2*margin - 2*titleSize) / lineHeight
+)
+
+// PDF writes the tables as a PDF document on A4 pages, each table starting
+// on a new page headed by its title. Long tables continue on the following
+// pages and lines too long for the page are cut. Text is in Courier without
+// embedded fonts, characters outside Latin-1 are printed as ?.
+func PDF(w io.Writer, tables ...Table) error {
+	var pages [][]string
+	for _, t := range tables {
+		lines := t.lines()
+		for first := true; first || len(lines) > 0; first = false {
+			n := linesPerPage
+			if n > len(lines) {
+				n = len(lines)
+			}
+			page := append([]string{t.Title}, lines[:n]...)
+			pages = append(pages, page)
+			lines = lines[n:]
+		}
+	}
+	if len(pages) == 0 {
+		pages = append(pages, []string{""})
+	}
+
+	var b bytes.Buffer
+	var offsets []int
+	object := func(format string, args ...interface{}) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n", len(offsets))
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\nendobj\n")
+	}
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-4 are the catalog, the page tree and the fonts, followed by
+	// a page and its content stream per page.
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F2 %d Tf\n%d %d Td\n(%s) Tj\n", titleSize, margin, pageHeight-margin-titleSize, pdfString(page[0]))
+		fmt.Fprintf(&content, "/F1 %d Tf\n%d TL\n0 %d Td\n", fontSize, lineHeight, -2*titleSize)
+		for _, line := range page[1:] {
+			fmt.Fprintf(&content, "(%s) '\n", pdfString(line))
+		}
+		content.WriteString("ET")
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+2*i)
+		object("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes())
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// pdfString escapes s for a PDF literal string in WinAnsiEncoding, cut to
+// the width of a line.
+func pdfString(s string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		if n == charsPerLine {
+			break
+		}
+		n++
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > 0xff || (r >= 0x7f && r < 0xa0):
+			b.WriteByte('?')
+		case r > 0x7f:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,479 @@
+package render
+
+import (
+	"errors"
+	"image"
+	"image/color"
+
+	"github.com/sa6mwa/dtg"
+)
+
+var ErrTooLong error = errors.New("data too long for a QR code (max 213 bytes)")
+
+// qrBlocks is the error correction block structure of QR code versions
+// 1-10 at error correction level M: the number of error correction
+// codewords per block, the number of blocks and their data codewords in
+// the first group and in the second group (one more data codeword each).
+var qrBlocks = [...]struct {
+	ec, blocks1, data1, blocks2 int
+}{
+	{10, 1, 16, 0},
+	{16, 1, 28, 0},
+	{26, 1, 44, 0},
+	{18, 2, 32, 0},
+	{24, 2, 43, 0},
+	{16, 4, 27, 0},
+	{18, 4, 31, 0},
+	{22, 2, 38, 2},
+	{22, 3, 36, 2},
+	{26, 4, 43, 1},
+}
+
+// qrAlignment are the alignment pattern centre coordinates of versions 2-10.
+var qrAlignment = [...][]int{
+	nil,
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
+
+// QR is a QR code symbol in byte mode with error correction level M (15%
+// of the symbol may be damaged), the smallest of versions 1-10 (21x21 to
+// 57x57 modules) holding the data.
+type QR struct {
+	// Version is the QR code version, 1-10.
+	Version int
+	// Modules are the rows of the symbol, true for dark modules, without
+	// the quiet zone.
+	Modules [][]bool
+}
+
+// NewQR encodes data as a QR code.
+func NewQR(data []byte) (*QR, error) {
+	version := 0
+	for v := 1; v <= len(qrBlocks); v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= 8*qrDataCodewords(v) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+	q := newQRMatrix(version)
+	q.drawCodewords(qrCodewords(version, data))
+	best, penalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormat(mask)
+		if p := q.penalty(); penalty < 0 || p < penalty {
+			best, penalty = mask, p
+		}
+		q.applyMask(mask)
+	}
+	q.applyMask(best)
+	q.drawFormat(best)
+	return &QR{Version: version, Modules: q.modules}, nil
+}
+
+// Rendezvous returns a QR code of the compact calendar entry of a DTG and a
+// short text (see dtg.Event.QRPayload), for printed orders where a scan
+// should add the rendezvous to the recipient's calendar.
+func Rendezvous(d dtg.DTG, text string) (*QR, error) {
+	return NewQR([]byte(dtg.EventAt(d, text).QRPayload()))
+}
+
+// Image returns the QR code with scale pixels per module and the 4 module
+// quiet zone required around it.
+func (q *QR) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	size := (len(q.Modules) + 8) * scale
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y, row := range q.Modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex((x+4)*scale+dx, (y+4)*scale+dy, 1)
+				}
+			}
+		}
+	}
+	return img
+}
+
+func qrDataCodewords(version int) int {
+	b := qrBlocks[version-1]
+	return b.blocks1*b.data1 + b.blocks2*(b.data1+1)
+}
+
+// qrCodewords returns the data of version in byte mode, padded, split into
+// blocks with their error correction codewords and interleaved.
+func qrCodewords(version int, data []byte) []byte {
+	capacity := qrDataCodewords(version)
+	var bits []bool
+	put := func(value, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, value>>i&1 == 1)
+		}
+	}
+	put(4, 4)
+	if version >= 10 {
+		put(len(data), 16)
+	} else {
+		put(len(data), 8)
+	}
+	for _, c := range data {
+		put(int(c), 8)
+	}
+	for i := 0; i < 4 && len(bits) < 8*capacity; i++ {
+		bits = append(bits, false)
+	}
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+	codewords := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var c byte
+		for _, bit := range bits[i : i+8] {
+			c <<= 1
+			if bit {
+				c |= 1
+			}
+		}
+		codewords = append(codewords, c)
+	}
+	for pad := byte(0xec); len(codewords) < capacity; pad ^= 0xec ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+
+	b := qrBlocks[version-1]
+	generator := rsGenerator(b.ec)
+	var blocks, ecBlocks [][]byte
+	for i := 0; i < b.blocks1+b.blocks2; i++ {
+		n := b.data1
+		if i >= b.blocks1 {
+			n++
+		}
+		blocks = append(blocks, codewords[:n])
+		ecBlocks = append(ecBlocks, rsRemainder(codewords[:n], generator))
+		codewords = codewords[n:]
+	}
+	var result []byte
+	for i := 0; i <= b.data1; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < b.ec; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(256) with the QR code polynomial
+// x^8+x^4+x^3+x^2+1.
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsGenerator returns the coefficients of the Reed-Solomon generator
+// polynomial of degree, highest first without the leading 1.
+func rsGenerator(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 2)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords of data.
+func rsRemainder(data, generator []byte) []byte {
+	result := make([]byte, len(generator))
+	for _, c := range data {
+		factor := c ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range generator {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// qrMatrix is a symbol under construction, function marks the finder,
+// timing, alignment, format and version modules that carry no data.
+type qrMatrix struct {
+	modules  [][]bool
+	function [][]bool
+}
+
+func newQRMatrix(version int) *qrMatrix {
+	size := 17 + 4*version
+	q := &qrMatrix{modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range q.modules {
+		q.modules[y] = make([]bool, size)
+		q.function[y] = make([]bool, size)
+	}
+	for i := 0; i < size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(size-4, 3)
+	q.drawFinder(3, size-4)
+	positions := qrAlignment[version-1]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.set(x+dx, y+dy, distance(dx, dy) != 1)
+				}
+			}
+		}
+	}
+	// Reserve the format modules, drawn for real per mask.
+	q.drawFormat(0)
+	if version >= 7 {
+		rem := version
+		for i := 0; i < 12; i++ {
+			rem = rem<<1 ^ (rem>>11)*0x1f25
+		}
+		bits := version<<12 | rem
+		for i := 0; i < 18; i++ {
+			a, b := size-11+i%3, i/3
+			q.set(a, b, bits>>i&1 == 1)
+			q.set(b, a, bits>>i&1 == 1)
+		}
+	}
+	return q
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// distance returns the number of rings dx, dy is from the centre of a
+// pattern.
+func distance(dx, dy int) int {
+	if abs(dx) > abs(dy) {
+		return abs(dx)
+	}
+	return abs(dy)
+}
+
+func (q *qrMatrix) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// drawFinder draws a finder pattern centred at x, y with its separator.
+func (q *qrMatrix) drawFinder(x, y int) {
+	size := len(q.modules)
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			if x+dx < 0 || x+dx >= size || y+dy < 0 || y+dy >= size {
+				continue
+			}
+			d := distance(dx, dy)
+			q.set(x+dx, y+dy, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format information of level M and
+// mask, and the dark module.
+func (q *qrMatrix) drawFormat(mask int) {
+	data := 0<<3 | mask // level M is 00
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 == 1 }
+	size := len(q.modules)
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.set(size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, size-15+i, bit(i))
+	}
+	q.set(8, size-8, true)
+}
+
+// drawCodewords places the codewords in the two module wide columns from
+// the bottom right, zig-zagging up and down and skipping function modules.
+func (q *qrMatrix) drawCodewords(codewords []byte) {
+	size := len(q.modules)
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vertical := 0; vertical < size; vertical++ {
+			y := vertical
+			if upward {
+				y = size - 1 - vertical
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if q.function[y][x] || i >= 8*len(codewords) {
+					continue
+				}
+				q.modules[y][x] = codewords[i/8]>>(7-i%8)&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by mask, applying it twice
+// removes it.
+func (q *qrMatrix) applyMask(mask int) {
+	for y, row := range q.modules {
+		for x := range row {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y][x] {
+				row[x] = !row[x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol by the rules of ISO/IEC 18004 for choosing the
+// mask: runs of five or more modules of one colour, 2x2 blocks, patterns
+// resembling finders and the imbalance of dark and light modules.
+func (q *qrMatrix) penalty() int {
+	size := len(q.modules)
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+	finder := []bool{true, false, true, true, true, false, true}
+	score, dark := 0, 0
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < size; y++ {
+			run := 1
+			for x := 1; x < size; x++ {
+				if at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					if run == 5 {
+						score += 3
+					} else if run > 5 {
+						score++
+					}
+				} else {
+					run = 1
+				}
+			}
+			for x := 0; x+7 <= size; x++ {
+				match := true
+				for i, d := range finder {
+					if at(x+i, y, vertical) != d {
+						match = false
+						break
+					}
+				}
+				if !match {
+					continue
+				}
+				light := func(from, to int) bool {
+					for i := from; i < to; i++ {
+						if i >= 0 && i < size && at(i, y, vertical) {
+							return false
+						}
+					}
+					return true
+				}
+				if light(x-4, x) || light(x+7, x+11) {
+					score += 40
+				}
+			}
+		}
+	}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x > 0 && y > 0 {
+				c := q.modules[y][x]
+				if q.modules[y-1][x] == c && q.modules[y][x-1] == c && q.modules[y-1][x-1] == c {
+					score += 3
+				}
+			}
+		}
+	}
+	total := size * size
+	deviation := abs(dark*20 - total*10)
+	score += deviation / total * 10
+	return score
+}
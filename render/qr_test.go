@@ -0,0 +1,211 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/sa6mwa/dtg"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// HELLO WORLD in version 1-M, ISO/IEC 18004 tutorials.
+	data := []byte{0x20, 0x5b, 0x0b, 0x78, 0xd1, 0x72, 0xdc, 0x4d, 0x43, 0x40, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11}
+	expected := "c4 23 27 77 eb d7 e7 e2 5d 17"
+	if got := fmt.Sprintf("% x", rsRemainder(data, rsGenerator(10))); got != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, got)
+	}
+}
+
+// readFormat returns the first copy of the format information, most
+// significant bit first.
+func readFormat(modules [][]bool) string {
+	var b strings.Builder
+	bit := func(x, y int) {
+		if modules[y][x] {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	for x := 0; x <= 5; x++ {
+		bit(x, 8)
+	}
+	bit(7, 8)
+	bit(8, 8)
+	bit(8, 7)
+	for y := 5; y >= 0; y-- {
+		bit(8, y)
+	}
+	return b.String()
+}
+
+func TestQRFormat(t *testing.T) {
+	expected := []string{
+		"101010000010010",
+		"101000100100101",
+		"101111001111100",
+		"101101101001011",
+		"100010111111001",
+		"100000011001110",
+		"100111110010111",
+		"100101010100000",
+	}
+	for mask, want := range expected {
+		q := newQRMatrix(1)
+		q.drawFormat(mask)
+		if got := readFormat(q.modules); got != want {
+			t.Errorf("Expected mask %d format \"%s\", but got \"%s\"", mask, want, got)
+		}
+	}
+	q := newQRMatrix(7)
+	var b strings.Builder
+	size := len(q.modules)
+	for i := 17; i >= 0; i-- {
+		if q.modules[i/3][size-11+i%3] {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	if got := b.String(); got != "000111110010010100" {
+		t.Errorf("Expected version 7 information \"000111110010010100\", but got \"%s\"", got)
+	}
+}
+
+// decodeQR reads back the data of a QR code made by NewQR.
+func decodeQR(t *testing.T, qr *QR) []byte {
+	t.Helper()
+	q := newQRMatrix(qr.Version)
+	format := readFormat(qr.Modules)
+	mask := -1
+	for m := 0; m < 8; m++ {
+		q.drawFormat(m)
+		if readFormat(q.modules) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("Unknown format \"%s\"", format)
+	}
+	for y, row := range qr.Modules {
+		copy(q.modules[y], row)
+	}
+	q.applyMask(mask)
+	size := len(q.modules)
+	var codewords []byte
+	var c byte
+	n := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < size; vertical++ {
+			y := vertical
+			if (right+1)&2 == 0 {
+				y = size - 1 - vertical
+			}
+			for x := right; x > right-2; x-- {
+				if q.function[y][x] {
+					continue
+				}
+				c <<= 1
+				if q.modules[y][x] {
+					c |= 1
+				}
+				if n++; n%8 == 0 {
+					codewords = append(codewords, c)
+				}
+			}
+		}
+	}
+	b := qrBlocks[qr.Version-1]
+	blocks := b.blocks1 + b.blocks2
+	data := make([][]byte, blocks)
+	i := 0
+	for j := 0; j <= b.data1; j++ {
+		for k := range data {
+			if j < b.data1 || k >= b.blocks1 {
+				data[k] = append(data[k], codewords[i])
+				i++
+			}
+		}
+	}
+	var all []byte
+	for k, block := range data {
+		ec := make([]byte, b.ec)
+		for j := range ec {
+			ec[j] = codewords[i+j*blocks+k]
+		}
+		if !bytes.Equal(rsRemainder(block, rsGenerator(b.ec)), ec) {
+			t.Errorf("Block %d error correction mismatch", k)
+		}
+		all = append(all, block...)
+	}
+	if all[0]>>4 != 4 {
+		t.Fatalf("Expected byte mode, but got %x", all[0]>>4)
+	}
+	var length int
+	var bits []byte
+	if qr.Version < 10 {
+		length = int(all[0]&0xf)<<4 | int(all[1]>>4)
+		bits = all[1:]
+	} else {
+		length = int(all[0]&0xf)<<12 | int(all[1])<<4 | int(all[2]>>4)
+		bits = all[2:]
+	}
+	result := make([]byte, length)
+	for j := range result {
+		result[j] = bits[j]<<4 | bits[j+1]>>4
+	}
+	return result
+}
+
+func TestNewQR(t *testing.T) {
+	for _, test := range []struct {
+		length, version int
+	}{
+		{0, 1},
+		{14, 1},
+		{15, 2},
+		{60, 4},
+		{100, 6},
+		{150, 8},
+		{213, 10},
+	} {
+		data := []byte(strings.Repeat("151230ZDEC19 ", 20)[:test.length])
+		qr, err := NewQR(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if qr.Version != test.version || len(qr.Modules) != 17+4*test.version {
+			t.Errorf("Expected %d bytes in version %d, but got version %d (%d modules)", test.length, test.version, qr.Version, len(qr.Modules))
+		}
+		if got := decodeQR(t, qr); !bytes.Equal(got, data) {
+			t.Errorf("Expected \"%s\", but got \"%s\"", data, got)
+		}
+	}
+	if _, err := NewQR(make([]byte, 214)); err != ErrTooLong {
+		t.Errorf("Expected %v, but got %v", ErrTooLong, err)
+	}
+}
+
+func TestRendezvous(t *testing.T) {
+	d := dtg.MustParse("151330ADEC19")
+	qr, err := Rendezvous(d, "RV checkpoint 4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := string(decodeQR(t, qr)), dtg.EventAt(d, "RV checkpoint 4").QRPayload(); got != expected {
+		t.Errorf("Expected \"%s\", but got \"%s\"", expected, got)
+	}
+	img := qr.Image(2)
+	if size := (len(qr.Modules) + 8) * 2; img.Bounds().Dx() != size {
+		t.Errorf("Expected %d pixels, but got %d", size, img.Bounds().Dx())
+	}
+	if img.At(0, 0) != color.White || img.At(8, 8) != color.Black {
+		t.Error("Expected a quiet zone and a dark finder corner")
+	}
+}
@@ -0,0 +1,163 @@
+// Package render produces printable documents of DTG tables for the duty
+// desk, e.g. a zone conversion chart and master station logs, as HTML to
+// print from a browser or as simple PDF written without dependencies.
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sa6mwa/dtg"
+)
+
+// Table is a titled table of text, one page (or more) when rendered.
+type Table struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+// ConversionChart returns a chart of the Zulu hours 00-23 and the local
+// hour in each zone letter, from west to east, for converting DTGs by
+// hand. Hours on the previous day are marked with -, on the next day with
+// +. Without letters all letters of dtg.Letters are included.
+func ConversionChart(letters ...string) (Table, error) {
+	zones := dtg.Letters()
+	if len(letters) > 0 {
+		byLetter := make(map[string]dtg.Zone, len(zones))
+		for _, z := range zones {
+			byLetter[z.Letter] = z
+		}
+		zones = zones[:0:0]
+		for _, letter := range letters {
+			z, ok := byLetter[strings.ToUpper(strings.TrimSpace(letter))]
+			if !ok {
+				return Table{}, fmt.Errorf("%w: %q", dtg.ErrInvalidTimeZoneLetter, letter)
+			}
+			zones = append(zones, z)
+		}
+	}
+	t := Table{Title: "Zone conversion chart", Header: []string{"Z"}}
+	for _, z := range zones {
+		t.Header = append(t.Header, z.Letter)
+	}
+	for hour := 0; hour < 24; hour++ {
+		row := []string{fmt.Sprintf("%02d", hour)}
+		for _, z := range zones {
+			local := hour*3600 + z.Offset
+			switch {
+			case local < 0:
+				row = append(row, fmt.Sprintf("%02d-", (local+24*3600)/3600))
+			case local >= 24*3600:
+				row = append(row, fmt.Sprintf("%02d+", (local-24*3600)/3600))
+			default:
+				row = append(row, fmt.Sprintf("%02d", local/3600))
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+// StationLog returns a table per page (Zulu day) of a master station log,
+// see dtg.StationLog.
+func StationLog(pages []dtg.LogPage) []Table {
+	tables := make([]Table, len(pages))
+	for i, page := range pages {
+		t := Table{
+			Title:  "Master station log " + strings.ToUpper(page.Day.Time.Format("02 Jan 2006")),
+			Header: []string{"Serial", "DTG", "FM", "TO", "Text"},
+		}
+		for _, e := range page.Entries {
+			t.Rows = append(t.Rows, []string{fmt.Sprintf("%04d", e.Serial), e.DTG.String(), e.From, e.To, e.Text})
+		}
+		tables[i] = t
+	}
+	return tables
+}
+
+var htmlTemplate *template.Template = template.Must(template.New("tables").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{with index . 0}}{{.Title}}{{end}}</title>
+<style>
+body { font-family: monospace; }
+section { page-break-after: always; break-after: page; }
+section:last-child { page-break-after: auto; break-after: auto; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid black; padding: 2px 4px; text-align: left; vertical-align: top; }
+thead { display: table-header-group; }
+</style>
+</head>
+<body>
+{{- range .}}
+<section>
+<h1>{{.Title}}</h1>
+<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML writes the tables as a printable HTML document, each table on its
+// own page when printed.
+func HTML(w io.Writer, tables ...Table) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	return htmlTemplate.Execute(w, tables)
+}
+
+// lines returns the table as text lines with aligned columns.
+func (t Table) lines() []string {
+	widths := make([]int, len(t.Header))
+	measure := func(row []string) {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	measure(t.Header)
+	for _, row := range t.Rows {
+		measure(row)
+	}
+	format := func(row []string) string {
+		var b strings.Builder
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+			}
+		}
+		return b.String()
+	}
+	lines := make([]string, 0, len(t.Rows)+2)
+	if len(t.Header) > 0 {
+		lines = append(lines, format(t.Header))
+		total := 0
+		for _, w := range widths {
+			total += w + 2
+		}
+		lines = append(lines, strings.Repeat("-", total-2))
+	}
+	for _, row := range t.Rows {
+		lines = append(lines, format(row))
+	}
+	return lines
+}
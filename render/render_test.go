@@ -0,0 +1,137 @@
+package render
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sa6mwa/dtg"
+)
+
+func TestConversionChart(t *testing.T) {
+	chart, err := ConversionChart("Z", "b", "R", "M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(chart.Header, " "); got != "Z Z B R M" {
+		t.Errorf("Expected \"Z Z B R M\", but got \"%s\"", got)
+	}
+	if len(chart.Rows) != 24 {
+		t.Fatalf("Expected 24 rows, but got %d", len(chart.Rows))
+	}
+	for _, tc := range []struct {
+		hour int
+		want string
+	}{
+		{0, "00 00 02 19- 12"},
+		{12, "12 12 14 07 00+"},
+		{23, "23 23 01+ 18 11+"},
+	} {
+		if got := strings.Join(chart.Rows[tc.hour], " "); got != tc.want {
+			t.Errorf("Expected \"%s\", but got \"%s\"", tc.want, got)
+		}
+	}
+	if _, err := ConversionChart("J"); err == nil {
+		t.Error("Expected error for J, but got nil")
+	}
+	all, err := ConversionChart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Header) != len(dtg.Letters())+1 {
+		t.Errorf("Expected %d columns, but got %d", len(dtg.Letters())+1, len(all.Header))
+	}
+}
+
+func testStationLog(t *testing.T) []Table {
+	t.Helper()
+	records := []dtg.Record{
+		{DTG: dtg.MustParse("152330ZDEC19"), Text: "FM ALPHA TO BRAVO <REPORT> (1)"},
+		{DTG: dtg.MustParse("160100ADEC19"), Text: "FM BRAVO TO ALPHA ACK"},
+	}
+	return StationLog(dtg.StationLog(records))
+}
+
+func TestStationLog(t *testing.T) {
+	tables := testStationLog(t)
+	if len(tables) != 2 {
+		t.Fatalf("Expected 2 tables, but got %d", len(tables))
+	}
+	if tables[1].Title != "Master station log 16 DEC 2019" {
+		t.Errorf("Expected \"Master station log 16 DEC 2019\", but got \"%s\"", tables[1].Title)
+	}
+	if got := strings.Join(tables[1].Rows[0], " "); got != "0001 160000ZDEC19 BRAVO ALPHA ACK" {
+		t.Errorf("Expected \"0001 160000ZDEC19 BRAVO ALPHA ACK\", but got \"%s\"", got)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	var b bytes.Buffer
+	if err := HTML(&b, testStationLog(t)...); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"<title>Master station log 15 DEC 2019</title>", "page-break-after", "<td>152330ZDEC19</td>", "&lt;REPORT&gt;"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected HTML to contain \"%s\", but got \"%s\"", want, out)
+		}
+	}
+	if n := strings.Count(out, "<section>"); n != 2 {
+		t.Errorf("Expected 2 sections, but got %d", n)
+	}
+}
+
+func TestPDF(t *testing.T) {
+	long := Table{Title: "Long", Header: []string{"N"}}
+	for i := 0; i < linesPerPage+10; i++ {
+		long.Rows = append(long.Rows, []string{strconv.Itoa(i)})
+	}
+	chart, err := ConversionChart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := PDF(&b, append(testStationLog(t), chart, long)...); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("Expected a PDF document, but got \"%s\"", out)
+	}
+	if !strings.Contains(out, "/Count 5 ") {
+		t.Errorf("Expected 5 pages, but got \"%s\"", out)
+	}
+	if !strings.Contains(out, `(0001    152330ZDEC19  ALPHA  BRAVO  <REPORT> \(1\)) '`) {
+		t.Errorf("Expected an escaped log entry, but got \"%s\"", out)
+	}
+	m := regexp.MustCompile(`startxref\n([0-9]+)\n`).FindStringSubmatch(out)
+	if m == nil {
+		t.Fatal("Expected startxref")
+	}
+	xref, _ := strconv.Atoi(m[1])
+	if !strings.HasPrefix(out[xref:], "xref\n") {
+		t.Fatalf("Expected xref at offset %d", xref)
+	}
+	entries := regexp.MustCompile(`([0-9]{10}) 00000 n `).FindAllStringSubmatch(out[xref:], -1)
+	for i, e := range entries {
+		offset, _ := strconv.Atoi(e[1])
+		want := strconv.Itoa(i+1) + " 0 obj\n"
+		if !strings.HasPrefix(out[offset:], want) {
+			t.Errorf("Expected \"%s\" at offset %d, but got \"%s\"", want, offset, out[offset:offset+len(want)])
+		}
+	}
+	if len(entries) != 4+2*5 {
+		t.Errorf("Expected %d objects, but got %d", 4+2*5, len(entries))
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	if got := pdfString("a(b)\\ é€"); got != `a\(b\)\\ \351?` {
+		t.Errorf("Expected \"a\\(b\\)\\\\ \\351?\", but got \"%s\"", got)
+	}
+	if got := pdfString(strings.Repeat("x", 200)); len(got) != charsPerLine {
+		t.Errorf("Expected %d characters, but got %d", charsPerLine, len(got))
+	}
+}
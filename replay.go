@@ -0,0 +1,117 @@
+package dtg
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Record is a DTG stamped record of archived traffic, e.g. a message or a
+// log line. Text is the record as read, including the DTG.
+type Record struct {
+	DTG  DTG
+	Text string
+}
+
+// ReadRecords reads records from r, one per line, each line starting with a
+// DTG (see ParsePrefix). Empty lines are skipped, any other line without a
+// leading DTG is an error.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	return (*Parser)(nil).ReadRecords(r)
+}
+
+// ReadRecords is like the package level ReadRecords, but uses the Parser's
+// zone table.
+func (p *Parser) ReadRecords(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		dtg, _, err := p.ParsePrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, Record{DTG: dtg, Text: text})
+	}
+	return records, scanner.Err()
+}
+
+// Replayer re-emits archived records with their original relative timing,
+// for exercising downstream systems against historical traffic. The zero
+// value replays in real time.
+type Replayer struct {
+	// Speed compresses time, e.g. 60 replays an hour of traffic per minute.
+	// Zero means 1 (real time), a negative Speed emits all records without
+	// waiting.
+	Speed float64
+}
+
+// Replay calls fn for each record in DTG order, the first immediately and
+// the following when as much time (divided by Speed) has passed as between
+// their DTGs. Records with the same DTG keep their order. Replay stops at
+// the first error from fn or when ctx is done. The timing is relative to
+// the start of the replay, so slow callbacks do not accumulate drift.
+func (rp *Replayer) Replay(ctx context.Context, records []Record, fn func(Record) error) error {
+	if len(records) == 0 {
+		return nil
+	}
+	sorted := make([]Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].DTG.Time.Before(sorted[j].DTG.Time) })
+	speed := rp.Speed
+	if speed == 0 {
+		speed = 1
+	}
+	start := time.Now()
+	first := sorted[0].DTG.Time
+	for _, record := range sorted {
+		if speed > 0 {
+			due := start.Add(time.Duration(float64(record.DTG.Time.Sub(first)) / speed))
+			if err := sleep(ctx, time.Until(due)); err != nil {
+				return err
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayTo is like Replay, but sends the records to ch. It does not close
+// ch.
+func (rp *Replayer) ReplayTo(ctx context.Context, records []Record, ch chan<- Record) error {
+	return rp.Replay(ctx, records, func(record Record) error {
+		select {
+		case ch <- record:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
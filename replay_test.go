@@ -0,0 +1,66 @@
+package dtg
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadRecords(t *testing.T) {
+	records, err := ReadRecords(strings.NewReader("151232ZDEC19 FM STN02 TO STN01\n\n151230Z DEC 19 FM STN01 TO STN02\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].DTG.String() != "151232ZDEC19" || records[1].Text != "151230Z DEC 19 FM STN01 TO STN02" {
+		t.Errorf("Expected two records, but got %v", records)
+	}
+	if _, err := ReadRecords(strings.NewReader("151230ZDEC19 ok\nFM STN01\n")); err == nil || err.Error() != "line 2: "+ErrInvalidDTG.Error() || !errors.Is(err, ErrInvalidDTG) {
+		t.Errorf("Expected the line of the invalid record, but got %v", err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	records := []Record{
+		{DTG: mustParse(t, "151300ZDEC19"), Text: "c"},
+		{DTG: mustParse(t, "151200ZDEC19"), Text: "a"},
+		{DTG: mustParse(t, "151230ZDEC19"), Text: "b1"},
+		{DTG: mustParse(t, "151430BDEC19"), Text: "b2"},
+	}
+	// An hour of traffic in 60ms.
+	rp := &Replayer{Speed: float64(time.Hour / (60 * time.Millisecond))}
+	var got []string
+	var at []time.Duration
+	start := time.Now()
+	if err := rp.Replay(context.Background(), records, func(r Record) error {
+		got = append(got, r.Text)
+		at = append(at, time.Since(start))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != "a,b1,b2,c" {
+		t.Errorf("Expected \"a,b1,b2,c\", but got \"%s\"", strings.Join(got, ","))
+	}
+	if at[1] < 30*time.Millisecond || at[3] < 60*time.Millisecond || at[3] > time.Second {
+		t.Errorf("Expected records at 0, 30, 30 and 60ms, but got %v", at)
+	}
+
+	ch := make(chan Record, len(records))
+	if err := (&Replayer{Speed: -1}).ReplayTo(context.Background(), records, ch); err != nil {
+		t.Fatal(err)
+	}
+	if len(ch) != len(records) {
+		t.Errorf("Expected %d records on the channel, but got %d", len(records), len(ch))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := (&Replayer{}).Replay(ctx, records, func(r Record) error {
+		cancel()
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+}
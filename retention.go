@@ -0,0 +1,58 @@
+package dtg
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidRetention error = errors.New("invalid retention policy (must keep for a positive duration or until the end of a range)")
+
+// RetentionPolicy is an age-off rule of an archive of DTG stamped records,
+// e.g. keep 30 days (KeepFor) or keep until the end of an exercise
+// (KeepUntil). With both, a record is kept until the later of the two.
+// Decisions depend on the instants only, not on the zone of the DTGs, so
+// records stamped in different zones age off consistently.
+type RetentionPolicy struct {
+	// Keep is how long a record is kept after its DTG, or 0.
+	Keep time.Duration
+	// Until keeps every record until its End, unless it is the zero Range.
+	Until Range
+}
+
+// KeepFor returns the RetentionPolicy keeping records for d after their
+// DTG.
+func KeepFor(d time.Duration) RetentionPolicy {
+	return RetentionPolicy{Keep: d}
+}
+
+// KeepUntil returns the RetentionPolicy keeping records until the end of r,
+// e.g. an exercise.
+func KeepUntil(r Range) RetentionPolicy {
+	return RetentionPolicy{Until: r}
+}
+
+// PurgeAt returns when record is to be purged, as a Zulu DTG.
+func (p RetentionPolicy) PurgeAt(record DTG) (DTG, error) {
+	until := !p.Until.End.IsZero()
+	if p.Keep < 0 || (p.Keep == 0 && !until) {
+		return DTG{}, ErrInvalidRetention
+	}
+	at := record.Time
+	if p.Keep > 0 {
+		at = at.Add(p.Keep)
+	}
+	if until && p.Until.End.Time.After(at) {
+		at = p.Until.End.Time
+	}
+	return DTG{Time: at}.Zulu(), nil
+}
+
+// Purge reports whether record is to be purged at now, i.e. its PurgeAt is
+// not after now, and when.
+func (p RetentionPolicy) Purge(record, now DTG) (bool, DTG, error) {
+	at, err := p.PurgeAt(record)
+	if err != nil {
+		return false, DTG{}, err
+	}
+	return !at.Time.After(now.Time), at, nil
+}
@@ -0,0 +1,41 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	exercise, err := ParseRange("010600ZJUN24/302200ZJUN24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		policy  RetentionPolicy
+		record  string
+		now     string
+		purge   bool
+		purgeAt string
+	}{
+		{KeepFor(30 * 24 * time.Hour), "151230ZDEC19", "141230ZJAN20", true, "141230ZJAN20"},
+		{KeepFor(30 * 24 * time.Hour), "151330ADEC19", "141229ZJAN20", false, "141230ZJAN20"},
+		{KeepUntil(exercise), "020800BJUN24", "302200ZJUN24", true, "302200ZJUN24"},
+		{KeepUntil(exercise), "020800BJUN24", "302159ZJUN24", false, "302200ZJUN24"},
+		{RetentionPolicy{Keep: 7 * 24 * time.Hour, Until: exercise}, "281200ZJUN24", "302200ZJUN24", false, "051200ZJUL24"},
+		{RetentionPolicy{Keep: 7 * 24 * time.Hour, Until: exercise}, "011200ZJUN24", "302200ZJUN24", true, "302200ZJUN24"},
+	}
+	for _, test := range tests {
+		purge, at, err := test.policy.Purge(mustParse(t, test.record), mustParse(t, test.now))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if purge != test.purge || at.String() != test.purgeAt {
+			t.Errorf("Expected %s at %s to purge %t at %s, but got %t at %s", test.record, test.now, test.purge, test.purgeAt, purge, at)
+		}
+	}
+	for _, invalid := range []RetentionPolicy{{}, KeepFor(-time.Hour)} {
+		if _, err := invalid.PurgeAt(mustParse(t, "151230ZDEC19")); err != ErrInvalidRetention {
+			t.Errorf("Expected %v for %+v, but got %v", ErrInvalidRetention, invalid, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package dtg
+
+import "time"
+
+// RFC3339 returns the time of dtg in RFC 3339 format with its own offset,
+// e.g. 2019-12-15T12:30:00+01:00 for 151230ADEC19.
+func (dtg DTG) RFC3339() string {
+	return dtg.Time.Format(time.RFC3339)
+}
+
+// FromRFC3339 parses an RFC 3339 timestamp and returns it as a DTG in the
+// zone of letter (J for local time). With an empty letter the offset of
+// the timestamp is kept, which String then writes with the matching letter.
+// Seconds and fractions are kept in the Time, but are not part of the DTG.
+func FromRFC3339(s, letter string) (DTG, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return DTG{}, err
+	}
+	if letter == "" {
+		return DTG{Time: t}, nil
+	}
+	t, err = timeIn(DTG{Time: t}, letter)
+	return DTG{Time: t}, err
+}
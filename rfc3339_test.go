@@ -0,0 +1,49 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestRFC3339(t *testing.T) {
+	tests := []struct {
+		dtg      string
+		expected string
+	}{
+		{"151230ZDEC19", "2019-12-15T12:30:00Z"},
+		{"151230ADEC19", "2019-12-15T12:30:00+01:00"},
+		{"010000YJAN20", "2020-01-01T00:00:00-12:00"},
+	}
+	for _, test := range tests {
+		if got := mustParse(t, test.dtg).RFC3339(); got != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, got)
+		}
+	}
+}
+
+func TestFromRFC3339(t *testing.T) {
+	tests := []struct {
+		input    string
+		letter   string
+		expected string
+	}{
+		{"2019-12-15T12:30:00Z", "", "151230ZDEC19"},
+		{"2019-12-15T12:30:45.123+01:00", "", "151230ADEC19"},
+		{"2019-12-15T12:30:00Z", "B", "151430BDEC19"},
+		{"2019-12-15T12:30:00+02:00", "z", "151030ZDEC19"},
+	}
+	for _, test := range tests {
+		d, err := FromRFC3339(test.input, test.letter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", test.expected, d)
+		}
+	}
+	if _, err := FromRFC3339("2019-12-15 12:30", ""); err == nil {
+		t.Errorf("Expected an error for a non RFC 3339 timestamp")
+	}
+	if _, err := FromRFC3339("2019-12-15T12:30:00Z", "Ö"); err != ErrInvalidTimeZoneLetter {
+		t.Errorf("Expected ErrInvalidTimeZoneLetter, but got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package dtg
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidRollover error = errors.New("invalid rollover schedule (interval must be positive)")
+
+// rolloverEpoch is the Monday rollover schedules are aligned to, so that
+// daily schedules roll over at the same Zulu time every day and weekly
+// schedules on the same weekday.
+var rolloverEpoch time.Time = time.Date(1969, time.December, 29, 0, 0, 0, 0, time.UTC)
+
+// RolloverSchedule is a recurring crypto period rollover, e.g. daily at
+// 0001Z. Rollovers are Offset after 0000Z every Interval counted from a
+// Monday, so an Interval of 24 hours is daily, 168 hours weekly (Offset
+// then includes the weekday) and 8 hours three times a day. All
+// computations are in Zulu time, which avoids surprises across the date
+// line.
+type RolloverSchedule struct {
+	Interval time.Duration
+	Offset   time.Duration
+}
+
+// DailyRollover returns the schedule of a daily rollover at hhmm Zulu,
+// e.g. "0001".
+func DailyRollover(hhmm string) (RolloverSchedule, error) {
+	minutes, err := parseClock(hhmm)
+	if err != nil {
+		return RolloverSchedule{}, err
+	}
+	return RolloverSchedule{Interval: 24 * time.Hour, Offset: time.Duration(minutes) * time.Minute}, nil
+}
+
+// NextRollover returns the first rollover after at, as a Zulu DTG. A
+// rollover exactly at at is not next, it has taken place.
+func (s RolloverSchedule) NextRollover(at DTG) (DTG, error) {
+	if s.Interval <= 0 {
+		return DTG{}, ErrInvalidRollover
+	}
+	first := rolloverEpoch.Add(s.Offset % s.Interval)
+	next := first.Add(at.Time.Sub(first) / s.Interval * s.Interval)
+	// The division truncates towards zero, before the epoch too.
+	for !next.After(at.Time) {
+		next = next.Add(s.Interval)
+	}
+	for next.Add(-s.Interval).After(at.Time) {
+		next = next.Add(-s.Interval)
+	}
+	return DTG{Time: next}, nil
+}
+
+// PreviousRollover returns the last rollover at or before at, i.e. the
+// start of the crypto period in effect at at, as a Zulu DTG.
+func (s RolloverSchedule) PreviousRollover(at DTG) (DTG, error) {
+	next, err := s.NextRollover(at)
+	if err != nil {
+		return DTG{}, err
+	}
+	return DTG{Time: next.Time.Add(-s.Interval)}, nil
+}
+
+// TimeToRollover returns the time from at until the next rollover.
+func (s RolloverSchedule) TimeToRollover(at DTG) (time.Duration, error) {
+	next, err := s.NextRollover(at)
+	if err != nil {
+		return 0, err
+	}
+	return next.Time.Sub(at.Time), nil
+}
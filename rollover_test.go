@@ -0,0 +1,51 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRolloverSchedule(t *testing.T) {
+	daily, err := DailyRollover("0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekly := RolloverSchedule{Interval: 7 * 24 * time.Hour, Offset: 2*24*time.Hour + 12*time.Hour}
+	tests := []struct {
+		schedule RolloverSchedule
+		at       string
+		next     string
+		left     time.Duration
+	}{
+		{daily, "151230ZDEC19", "160001ZDEC19", 11*time.Hour + 31*time.Minute},
+		{daily, "160000ZDEC19", "160001ZDEC19", time.Minute},
+		{daily, "160001ZDEC19", "170001ZDEC19", 24 * time.Hour},
+		{daily, "160030MDEC19", "160001ZDEC19", 11*time.Hour + 31*time.Minute},
+		{daily, "152330WDEC19", "170001ZDEC19", 14*time.Hour + 31*time.Minute},
+		{daily, "312359ZDEC68", "010001ZJAN69", 2 * time.Minute},
+		{weekly, "151230ZDEC19", "181200ZDEC19", 2*24*time.Hour + 23*time.Hour + 30*time.Minute},
+		{RolloverSchedule{Interval: 8 * time.Hour}, "151230ZDEC19", "151600ZDEC19", 3*time.Hour + 30*time.Minute},
+	}
+	for _, test := range tests {
+		at := mustParse(t, test.at)
+		next, err := test.schedule.NextRollover(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.String() != test.next {
+			t.Errorf("Expected the rollover after %s at %s, but got %s", test.at, test.next, next)
+		}
+		if left, _ := test.schedule.TimeToRollover(at); left != test.left {
+			t.Errorf("Expected %s to the rollover after %s, but got %s", test.left, test.at, left)
+		}
+		if previous, _ := test.schedule.PreviousRollover(at); previous.Time.After(at.Time) || next.Time.Sub(previous.Time) != test.schedule.Interval {
+			t.Errorf("Expected the previous rollover at or before %s, but got %s", test.at, previous)
+		}
+	}
+	if _, err := (RolloverSchedule{}).NextRollover(mustParse(t, "151230ZDEC19")); err != ErrInvalidRollover {
+		t.Errorf("Expected %v, but got %v", ErrInvalidRollover, err)
+	}
+	if _, err := DailyRollover("2500"); err != ErrInvalidClock {
+		t.Errorf("Expected %v, but got %v", ErrInvalidClock, err)
+	}
+}
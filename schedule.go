@@ -0,0 +1,207 @@
+package dtg
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingColumn  error = errors.New("missing column")
+	ErrInvalidWeekday error = errors.New("invalid day (must be a weekday name or 1-7, Monday being 1)")
+	ErrInvalidTime    error = errors.New("invalid time (must be HHMM or HH:MM)")
+)
+
+// weekdays maps weekday names and abbreviations to time.Weekday.
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday, "tuesday": time.Tuesday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "wednesday": time.Wednesday,
+	"wed": time.Wednesday, "thursday": time.Thursday, "thu": time.Thursday,
+	"thurs": time.Thursday, "friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sunday": time.Sunday,
+	"sun": time.Sunday,
+}
+
+// scheduleColumns are the accepted header names of each schedule column.
+var scheduleColumns = map[string][]string{
+	"day":   {"day", "weekday"},
+	"start": {"time", "start"},
+	"end":   {"end", "until"},
+	"zone":  {"zone", "letter", "tz"},
+	"title": {"title", "event", "activity", "description"},
+}
+
+// ScheduleEntry is a row of a schedule resolved by ImportSchedule.
+type ScheduleEntry struct {
+	// Range is the activity, End equal to Start if the row has no end.
+	Range Range
+	Title string
+	// Line is the line of the row in the CSV.
+	Line int
+	// Fields holds all columns of the row by (lower case) header name.
+	Fields map[string]string
+}
+
+// ScheduleError reports an invalid row of a schedule.
+type ScheduleError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+func (e *ScheduleError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d: %s: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ScheduleError) Unwrap() error {
+	return e.Err
+}
+
+// ImportSchedule reads a training schedule the way staff author them, a
+// CSV with a header row and one activity per row, and resolves each row to
+// a Range in the week starting at the date of weekStart. Columns are
+// recognized by header name, in any order and case:
+//
+//   - day (or weekday): Monday, mon, ... or 1-7 with Monday being 1
+//   - time (or start): HHMM or HH:MM
+//   - end (or until), optional: an end before the start is the next day
+//   - zone (or letter, tz), optional: the zone letter, J when omitted
+//   - title (or event, activity, description), optional
+//
+// For example:
+//
+//	day,time,end,zone,title
+//	Mon,0800,1000,Z,Range safety brief
+//	Tue,22:00,02:00,A,Night navigation
+//
+// Other columns are kept in the Fields of the entries. The first invalid
+// row fails the import with a ScheduleError.
+func ImportSchedule(r io.Reader, weekStart time.Time) ([]ScheduleEntry, error) {
+	return (*Parser)(nil).ImportSchedule(r, weekStart)
+}
+
+// ImportSchedule is like the package level ImportSchedule, but uses the
+// Parser's zone table.
+func (p *Parser) ImportSchedule(r io.Reader, weekStart time.Time) ([]ScheduleEntry, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+	columns := map[string]int{}
+	for column, names := range scheduleColumns {
+		columns[column] = -1
+		for i, h := range header {
+			for _, name := range names {
+				if h == name && columns[column] < 0 {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	for _, required := range []string{"day", "start"} {
+		if columns[required] < 0 {
+			return nil, &ScheduleError{Line: 1, Column: required, Err: ErrMissingColumn}
+		}
+	}
+	var entries []ScheduleEntry
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		value := func(column string) string {
+			if i := columns[column]; i >= 0 && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		entry := ScheduleEntry{Title: value("title"), Line: line, Fields: map[string]string{}}
+		for i, h := range header {
+			if i < len(record) {
+				entry.Fields[h] = record[i]
+			}
+		}
+		date, err := scheduleDate(weekStart, value("day"))
+		if err != nil {
+			return nil, &ScheduleError{Line: line, Column: "day", Err: err}
+		}
+		start, err := p.scheduleDTG(date, value("start"), value("zone"))
+		if err != nil {
+			return nil, scheduleError(line, "time", err)
+		}
+		end := start
+		if value("end") != "" {
+			if end, err = p.scheduleDTG(date, value("end"), value("zone")); err != nil {
+				return nil, scheduleError(line, "end", err)
+			}
+			if end.Time.Before(start.Time) {
+				if end, err = p.scheduleDTG(date.AddDate(0, 0, 1), value("end"), value("zone")); err != nil {
+					return nil, scheduleError(line, "end", err)
+				}
+			}
+		}
+		entry.Range = Range{Start: start, End: end}
+		entries = append(entries, entry)
+	}
+}
+
+// scheduleError returns a ScheduleError for the first invalid field of a
+// DTG, blaming the zone column for an invalid letter.
+func scheduleError(line int, column string, err error) error {
+	if errs, ok := err.(FieldErrors); ok {
+		if errs[0].Field == "Letter" {
+			column = "zone"
+		}
+		err = errs[0]
+	}
+	return &ScheduleError{Line: line, Column: column, Err: err}
+}
+
+// scheduleDate returns the date of day in the week starting at weekStart.
+func scheduleDate(weekStart time.Time, day string) (time.Time, error) {
+	y, m, d := weekStart.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	weekday, ok := weekdays[strings.ToLower(day)]
+	if !ok {
+		n, err := strconv.Atoi(day)
+		if err != nil || n < 1 || n > 7 {
+			return time.Time{}, ErrInvalidWeekday
+		}
+		weekday = time.Weekday(n % 7)
+	}
+	return start.AddDate(0, 0, (int(weekday)-int(start.Weekday())+7)%7), nil
+}
+
+// scheduleDTG returns the DTG at hhmm in zone on date.
+func (p *Parser) scheduleDTG(date time.Time, hhmm, zone string) (DTG, error) {
+	hhmm = strings.Replace(hhmm, ":", "", 1)
+	if len(hhmm) == 3 {
+		hhmm = "0" + hhmm
+	}
+	if len(hhmm) != 4 || !isDigits(hhmm) {
+		return DTG{}, ErrInvalidTime
+	}
+	hour, _ := strconv.Atoi(hhmm[:2])
+	minute, _ := strconv.Atoi(hhmm[2:])
+	return p.FromFields(Fields{Year: date.Year(), Month: date.Month(), Day: date.Day(), Hour: hour, Minute: minute, Letter: zone})
+}
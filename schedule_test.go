@@ -0,0 +1,66 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportSchedule(t *testing.T) {
+	schedule := `Activity,Day,Time,End,Zone,Instructor
+# Week 51
+Range safety brief,Mon,0800,1000,Z,Sgt Berg
+Night navigation,tue,22:00,02:00,A,Lt Holm
+Stand-to,7,530,,z,
+`
+	// A Wednesday, the week starts on the day given.
+	weekStart := time.Date(2019, 12, 11, 15, 0, 0, 0, time.UTC)
+	entries, err := ImportSchedule(strings.NewReader(schedule), weekStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		title string
+		rng   string
+		line  int
+	}{
+		{"Range safety brief", "160800ZDEC19/161000ZDEC19", 3},
+		{"Night navigation", "172200ADEC19/180200ADEC19", 4},
+		{"Stand-to", "150530ZDEC19", 5},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, but got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i, e := range entries {
+		if e.Title != expected[i].title || e.Range.String() != expected[i].rng || e.Line != expected[i].line {
+			t.Errorf("Expected %s %s on line %d, but got %s %s on line %d", expected[i].title, expected[i].rng, expected[i].line, e.Title, e.Range, e.Line)
+		}
+	}
+	if entries[0].Fields["instructor"] != "Sgt Berg" {
+		t.Errorf("Expected the instructor column in Fields, but got %v", entries[0].Fields)
+	}
+}
+
+func TestImportScheduleErrors(t *testing.T) {
+	weekStart := time.Date(2019, 12, 16, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		line     int
+		column   string
+		err      error
+	}{
+		{"title,time\nx,0800\n", 1, "day", ErrMissingColumn},
+		{"day,time\nmon,0800\nfunday,0800\n", 3, "day", ErrInvalidWeekday},
+		{"day,time\nmon,8\n", 2, "time", ErrInvalidTime},
+		{"day,time,zone\nmon,0800,Ö\n", 2, "zone", ErrInvalidTimeZoneLetter},
+		{"day,time,end\nmon,0800,2460\n", 2, "end", ErrFieldRange},
+	}
+	for _, test := range tests {
+		_, err := ImportSchedule(strings.NewReader(test.schedule), weekStart)
+		var se *ScheduleError
+		if !errors.As(err, &se) || se.Line != test.line || se.Column != test.column || !errors.Is(err, test.err) {
+			t.Errorf("Expected %v in column %s on line %d, but got %v", test.err, test.column, test.line, err)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package dtg
+
+import "time"
+
+// ShiftLetter returns dtg as seen n zones east (n > 0) or west (n < 0) of
+// its own zone, i.e. the same instant with the clock moved n hours. The
+// zone of dtg is its offset truncated to whole hours, the way String picks
+// the letter. Shifting wraps around the date line: one zone east of M
+// (UTC+12) is X (UTC-11) and the day changes accordingly, while one zone
+// west of X is Y (UTC-12).
+func ShiftLetter(dtg DTG, n int) DTG {
+	_, offset := dtg.Time.Zone()
+	hours := offset/3600 + n%24
+	switch {
+	case hours > 12:
+		hours -= 24
+	case hours < -12:
+		hours += 24
+	}
+	offset = hours * 3600
+	return DTG{Time: dtg.Time.In(time.FixedZone(numericTimeZone(offset), offset))}
+}
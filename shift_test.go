@@ -0,0 +1,33 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestShiftLetter(t *testing.T) {
+	tests := []struct {
+		dtg      string
+		n        int
+		expected string
+	}{
+		{"151230ZDEC19", 0, "151230ZDEC19"},
+		{"151230ZDEC19", 2, "151430BDEC19"},
+		{"151230ZDEC19", -3, "150930PDEC19"},
+		{"151230LDEC19", 1, "151330MDEC19"},
+		{"151230MDEC19", 1, "141330XDEC19"},
+		{"151230XDEC19", -1, "151130YDEC19"},
+		{"010030YJAN20", -1, "012330LJAN20"},
+		{"151230ZDEC19", 24, "151230ZDEC19"},
+		{"151230ZDEC19", -25, "151130NDEC19"},
+	}
+	for _, test := range tests {
+		d := mustParse(t, test.dtg)
+		got := ShiftLetter(d, test.n)
+		if got.String() != test.expected {
+			t.Errorf("Expected %s shifted %d zones to be \"%s\", but got \"%s\"", test.dtg, test.n, test.expected, got)
+		}
+		if !got.Time.Equal(d.Time) {
+			t.Errorf("Expected %s shifted %d zones to be the same instant, but got %s", test.dtg, test.n, got.Time)
+		}
+	}
+}
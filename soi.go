@@ -0,0 +1,102 @@
+package dtg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// soiRegexp matches a validity period of a Signal Operating Instructions
+// item, e.g. "FREQ LIST ED 3 EFF 150600Z TO 220600Z".
+var soiRegexp *regexp.Regexp = regexp.MustCompile(`(?i)^\s*(.*?)\s*\bEFF\s+(\S+)\s+TO\s+(\S+)\s*$`)
+
+// SOI are the validity periods of Signal Operating Instructions items, by
+// item, in the order they were read. An item may have several periods,
+// e.g. one per edition.
+type SOI map[string][]Range
+
+// ParseSOI reads validity periods, one per line, in the form "item EFF
+// start TO end", e.g. "CALL SIGNS ED 2 EFF 150600Z TO 220600Z". Lines
+// without a period are ignored. The start is resolved as by Parse, an end
+// without month (or year) takes them from the start and, should it then be
+// before the start, is in the following month (or year), e.g. "EFF
+// 280600ZNOV19 TO 040600Z" ends 040600ZDEC19.
+func ParseSOI(r io.Reader) (SOI, error) {
+	return (*Parser)(nil).ParseSOI(r)
+}
+
+// ParseSOI is like the package level ParseSOI, but uses the Parser's zone
+// table.
+func (p *Parser) ParseSOI(r io.Reader) (SOI, error) {
+	soi := SOI{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		match := soiRegexp.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		period, err := p.soiPeriod(match[2], match[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		item := strings.ToUpper(match[1])
+		soi[item] = append(soi[item], period)
+	}
+	return soi, scanner.Err()
+}
+
+func (p *Parser) soiPeriod(startString, endString string) (Range, error) {
+	start, err := p.Parse(startString)
+	if err != nil {
+		return Range{}, err
+	}
+	end, err := p.ParseDetailed(endString)
+	if err != nil {
+		return Range{}, err
+	}
+	// Resolve an omitted month and year of the end from the start rather
+	// than from now.
+	switch {
+	case !end.ExplicitMonth:
+		end, err = p.ParseDetailed(strings.TrimSpace(endString) + strings.ToUpper(start.Time.Format(monthLayout+yearLayout)))
+		if err == nil && end.DTG.Time.Before(start.Time) {
+			end.DTG.Time = end.DTG.Time.AddDate(0, 1, 0)
+		}
+	case !end.ExplicitYear:
+		end, err = p.ParseDetailed(strings.TrimSpace(endString) + start.Time.Format(yearLayout))
+		if err == nil && end.DTG.Time.Before(start.Time) {
+			end.DTG.Time = end.DTG.Time.AddDate(1, 0, 0)
+		}
+	}
+	if err != nil {
+		return Range{}, err
+	}
+	return NewRange(start, end.DTG)
+}
+
+// InEffect reports whether any period of item includes at. A period is in
+// effect from its start until, but not including, its end, when the next
+// edition usually takes effect.
+func (s SOI) InEffect(item string, at DTG) bool {
+	for _, period := range s[strings.ToUpper(strings.TrimSpace(item))] {
+		if !at.Time.Before(period.Start.Time) && at.Time.Before(period.End.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+// Effective returns the items in effect at, sorted.
+func (s SOI) Effective(at DTG) []string {
+	var items []string
+	for item := range s {
+		if s.InEffect(item, at) {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	return items
+}
@@ -0,0 +1,53 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSOI(t *testing.T) {
+	soi, err := ParseSOI(strings.NewReader(`SOI 12-3, 1 BDE
+FREQ LIST ED 2 EFF 080600ZDEC19 TO 150600ZDEC19
+freq list ed 3 eff 150600ZDEC19 to 220600ZDEC19
+CALL SIGNS EFF 280600ZNOV19 TO 040600Z
+CALL SIGNS EFF 040600ZDEC19 TO 111200ZDEC19
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(soi) != 3 || len(soi["CALL SIGNS"]) != 2 {
+		t.Fatalf("Expected three items, but got %v", soi)
+	}
+	if r := soi["CALL SIGNS"][0]; r.Start.String() != "280600ZNOV19" || r.End.Time.Month() != r.Start.Time.Month()+1 || r.End.Time.Day() != 4 {
+		t.Errorf("Expected the end in the month after the start, but got %s", r)
+	}
+	tests := []struct {
+		item     string
+		at       string
+		expected bool
+	}{
+		{"FREQ LIST ED 2", "100000ZDEC19", true},
+		{"FREQ LIST ED 2", "150600ZDEC19", false},
+		{"freq list ed 3", "150600ZDEC19", true},
+		{"FREQ LIST ED 3", "220559ZDEC19", true},
+		{"FREQ LIST ED 3", "220600ZDEC19", false},
+		{"CALL SIGNS", "100000ZDEC19", true},
+		{"CALL SIGNS", "120000ZDEC19", false},
+		{"UNKNOWN", "100000ZDEC19", false},
+	}
+	for _, test := range tests {
+		if got := soi.InEffect(test.item, mustParse(t, test.at)); got != test.expected {
+			t.Errorf("Expected %s in effect at %s to be %t, but got %t", test.item, test.at, test.expected, got)
+		}
+	}
+	if items := strings.Join(soi.Effective(mustParse(t, "100000ZDEC19")), ","); items != "CALL SIGNS,FREQ LIST ED 2" {
+		t.Errorf("Expected \"CALL SIGNS,FREQ LIST ED 2\", but got \"%s\"", items)
+	}
+	if _, err := ParseSOI(strings.NewReader("\nKEYLIST EFF 150600ZDEC19 TO 220600QQ\n")); !errors.Is(err, ErrInvalidDTG) || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("Expected an invalid DTG on line 2, but got %v", err)
+	}
+	if _, err := ParseSOI(strings.NewReader("KEYLIST EFF 150600ZDEC19 TO 140600ZDEC19\n")); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected %v, but got %v", ErrInvalidRange, err)
+	}
+}
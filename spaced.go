@@ -0,0 +1,45 @@
+package dtg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// SpacedRegexp matches a Date Time Group in the spaced convention of
+// several allied SOPs, where the day, the time with the zone letter, the
+// month and the year are separate groups, e.g. 15 1200Z DEC 19. All groups
+// are mandatory, in upper case and separated by exactly one space.
+var SpacedRegexp *regexp.Regexp = regexp.MustCompile(`^([0-9]{2}) ([0-9]{2})([0-9]{2})([A-Z]\*?) (JAN|FEB|MAR|APR|MAY|JUN|JUL|AUG|SEP|OCT|NOV|DEC) ([0-9]{2})$`)
+
+var ErrNotSpaced error = errors.New("not a spaced DTG (dd HHMMZ MMM YY)")
+
+// FormatSpaced returns the Date Time Group of dtg in the spaced convention,
+// e.g. 15 1200Z DEC 19, see SpacedRegexp. The zone letter is chosen like
+// DTG.String() does.
+func FormatSpaced(dtg DTG) string {
+	return (*Formatter)(nil).FormatSpaced(dtg)
+}
+
+// FormatSpaced is like the package level FormatSpaced, but uses the
+// Formatter's zone table.
+func (f *Formatter) FormatSpaced(dtg DTG) string {
+	var buf [dtgBufferSize + 3]byte
+	return string(f.append(buf[:0], dtg, groupSpaced))
+}
+
+// ParseSpaced parses a Date Time Group only if it is in the spaced
+// convention produced by FormatSpaced, otherwise ErrNotSpaced is returned.
+// Parse with WithLenient accepts it among other forms.
+func ParseSpaced(s string) (DTG, error) {
+	return (*Parser)(nil).ParseSpaced(s)
+}
+
+// ParseSpaced is like the package level ParseSpaced, but uses the Parser's
+// zone table.
+func (p *Parser) ParseSpaced(s string) (DTG, error) {
+	if !SpacedRegexp.MatchString(s) {
+		return DTG{}, ErrNotSpaced
+	}
+	return p.Parse(strings.ReplaceAll(s, " ", ""))
+}
@@ -0,0 +1,53 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestFormatSpaced(t *testing.T) {
+	for _, s := range []string{"151200ZDEC19", "010000BJAN20", "311159YMAY21"} {
+		expected := s[:2] + " " + s[2:7] + " " + s[7:10] + " " + s[10:]
+		got := FormatSpaced(mustParse(t, s))
+		if got != expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected, got)
+		}
+		d, err := ParseSpaced(got)
+		if err != nil {
+			t.Errorf("Expected \"%s\" to parse, but got %v", got, err)
+		} else if d.String() != s {
+			t.Errorf("Expected \"%s\", but got \"%s\"", s, d)
+		}
+	}
+	zones := DefaultZoneTable().Clone()
+	if err := zones.Set("D*", 4*3600+1800); err != nil {
+		t.Fatal(err)
+	}
+	d, err := (&Parser{Zones: zones}).ParseSpaced("15 1200D* DEC 19")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := (&Formatter{Zones: zones}).FormatSpaced(d); s != "15 1200D* DEC 19" {
+		t.Errorf("Expected \"15 1200D* DEC 19\", but got \"%s\"", s)
+	}
+}
+
+func TestParseSpaced(t *testing.T) {
+	for _, s := range []string{
+		"151200ZDEC19",
+		"151200Z DEC 19",
+		"15 1200 DEC 19",
+		"15 1200Z DEC",
+		"15  1200Z DEC 19",
+		"15 1200z dec 19",
+		" 15 1200Z DEC 19",
+		"15 1200Z DEC 2019",
+		"15 1200Z OKT 19",
+	} {
+		if _, err := ParseSpaced(s); err != ErrNotSpaced {
+			t.Errorf("Expected ErrNotSpaced for \"%s\", but got %v", s, err)
+		}
+	}
+	if _, err := ParseSpaced("32 1200Z DEC 19"); err == nil || err == ErrNotSpaced {
+		t.Errorf("Expected an invalid date error, but got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package dtg
+
+import (
+	"strings"
+)
+
+// spokenDigits are the ICAO/ITU radiotelephony pronunciations of digits and
+// common speech recognition spellings of them.
+var spokenDigits = map[string]string{
+	"zeero": "0", "zeroh": "0", "zero": "0",
+	"wun": "1", "one": "1",
+	"too": "2", "two": "2",
+	"tree": "3", "three": "3",
+	"fower": "4", "four": "4",
+	"fife": "5", "five": "5",
+	"six": "6",
+	"seven": "7",
+	"ait": "8", "eight": "8",
+	"niner": "9", "nine": "9",
+}
+
+// ambiguousDigits are homophones of digits that are only corrected when next
+// to another spoken digit, as they are ordinary words too ("from 1200 to
+// 1300").
+var ambiguousDigits = map[string]string{
+	"oh": "0", "o": "0",
+	"won": "1",
+	"to": "2",
+	"free": "3",
+	"for": "4", "fore": "4",
+	"ate": "8",
+}
+
+// CorrectSpeech post-processes speech recognition output so it can be
+// parsed: radiotelephony pronunciations such as "wun", "too", "tree",
+// "fower", "fife", "ait" and "niner" (also hyphenated, as in "nin-er") and
+// spoken digits are replaced with digits, and runs of adjacent digits are
+// joined. Homophones that are also ordinary words ("to", "for", "won", "oh",
+// "ate", "free") are only corrected next to another spoken digit, not next
+// to digits already in s. Words are otherwise left as recognized, so
+//
+//	"wun fife wun too tree zero zulu"
+//
+// becomes "151230 zulu", suitable for ParseLoose (which applies
+// CorrectSpeech itself) or, if fully recognized, Parse.
+func CorrectSpeech(s string) string {
+	words := strings.Fields(s)
+	digits := make([]string, len(words))
+	// spoken marks the digits that were words, the only neighbours that
+	// correct a homophone.
+	spoken := make([]bool, len(words))
+	for i, w := range words {
+		key := strings.ToLower(strings.ReplaceAll(strings.Trim(w, ".,"), "-", ""))
+		if i > 0 {
+			// Leave compounds such as "twenty three" to ParseLoose.
+			_, tens := tensWords[strings.ToLower(words[i-1])]
+			if _, unit := numberWords[key]; tens && unit {
+				continue
+			}
+		}
+		if d, ok := spokenDigits[key]; ok {
+			digits[i] = d
+			spoken[i] = true
+		} else if isDigits(key) {
+			digits[i] = key
+		}
+	}
+	for i, w := range words {
+		if digits[i] != "" {
+			continue
+		}
+		d, ok := ambiguousDigits[strings.ToLower(strings.Trim(w, ".,"))]
+		if ok && ((i > 0 && spoken[i-1]) || (i+1 < len(words) && spoken[i+1])) {
+			digits[i] = d
+			spoken[i] = true
+		}
+	}
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 && !(digits[i] != "" && digits[i-1] != "") {
+			b.WriteByte(' ')
+		}
+		if digits[i] != "" {
+			b.WriteString(digits[i])
+		} else {
+			b.WriteString(w)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestCorrectSpeech(t *testing.T) {
+	testTable := []struct {
+		input    string
+		expected string
+	}{
+		{`wun fife wun too tree zero zulu`, `151230 zulu`},
+		{`one five one two three zero Zulu December one niner`, `151230 Zulu December 19`},
+		{`wun fife wun too tree zee-ro zulu dec wun nin-er`, `151230 zulu dec 19`},
+		{`fower fife wun too tree zeero`, `451230`},
+		{`ait to fife six won fore`, `825614`},
+		{`meet at wun ait to oh oh zulu`, `meet at 18200 zulu`},
+		{`report to the command post for orders`, `report to the command post for orders`},
+		{`from 1200 to 1300`, `from 1200 to 1300`},
+		{`151230Z DEC 19`, `151230Z DEC 19`},
+		{`15 12 30 zulu`, `151230 zulu`},
+		{``, ``},
+	}
+	for _, v := range testTable {
+		if s := CorrectSpeech(v.input); s != v.expected {
+			t.Errorf("Expected \"%s\" to become \"%s\", but got \"%s\"", v.input, v.expected, s)
+		}
+	}
+	dtg, err := ParseLoose(`wun fife wun too tree zero zulu december wun niner`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dtg.String() != `151230ZDEC19` {
+		t.Errorf("Expected \"151230ZDEC19\", but got \"%s\"", dtg.String())
+	}
+	if compact, _ := looseToCompact(CorrectSpeech(`zero one twenty niner fifty three zulu`)); compact != `012953Z` {
+		t.Errorf("Expected \"012953Z\", but got \"%s\"", compact)
+	}
+	if err := Validate(CorrectSpeech(`wun fife wun too tree zero`)); err != nil {
+		t.Errorf("Expected corrected digits to validate, but got %v", err)
+	}
+}
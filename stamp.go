@@ -0,0 +1,147 @@
+package dtg
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidStamp error = errors.New("invalid DTG stamp")
+
+// Sources of a Stamp. NTP, GPS and system are also the names of the
+// TimeSources of NTPTimeSource, GPSTimeSource and SystemTimeSource.
+const (
+	SourceNTP      string = "ntp"
+	SourceGPS      string = "gps"
+	SourceSystem   string = "system"
+	SourceOperator string = "operator"
+)
+
+// Confidence is how much the time of a Stamp can be trusted.
+type Confidence int
+
+const (
+	// ConfidenceUnknown is the zero value.
+	ConfidenceUnknown Confidence = iota
+	// ConfidenceLow is e.g. a free-running clock that may have drifted.
+	ConfidenceLow
+	// ConfidenceMedium is e.g. an operator entered DTG, right to the
+	// minute at best.
+	ConfidenceMedium
+	// ConfidenceHigh is e.g. a clock synchronized with NTP or GPS.
+	ConfidenceHigh
+)
+
+var confidenceNames = []string{"unknown", "low", "medium", "high"}
+
+// String returns the confidence in lower case, e.g. "high".
+func (c Confidence) String() string {
+	if c < 0 || int(c) >= len(confidenceNames) {
+		return confidenceNames[ConfidenceUnknown]
+	}
+	return confidenceNames[c]
+}
+
+// MarshalText implements encoding.TextMarshaler, see String.
+func (c Confidence) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for the names of
+// String.
+func (c *Confidence) UnmarshalText(text []byte) error {
+	for i, name := range confidenceNames {
+		if strings.EqualFold(string(text), name) {
+			*c = Confidence(i)
+			return nil
+		}
+	}
+	return ErrInvalidStamp
+}
+
+// SourceConfidence returns the default Confidence of a source: high for NTP
+// and GPS, medium for operator entered and low for the (free-running)
+// system clock.
+func SourceConfidence(source string) Confidence {
+	switch source {
+	case SourceNTP, SourceGPS:
+		return ConfidenceHigh
+	case SourceOperator:
+		return ConfidenceMedium
+	case SourceSystem:
+		return ConfidenceLow
+	}
+	return ConfidenceUnknown
+}
+
+// Stamp is a DTG annotated with where its time came from and how much it
+// can be trusted, so that downstream consumers can weigh each timestamp.
+// The DTG is not embedded, so that the methods of DTG and time.Time (e.g.
+// MarshalText or GobEncode) do not marshal a Stamp without its source and
+// confidence.
+type Stamp struct {
+	DTG DTG
+	// Source is e.g. SourceNTP or the name of a TimeSource.
+	Source     string
+	Confidence Confidence
+}
+
+// NewStamp returns the Stamp of dtg from source with its SourceConfidence.
+func NewStamp(dtg DTG, source string) Stamp {
+	return Stamp{DTG: dtg, Source: source, Confidence: SourceConfidence(source)}
+}
+
+// Stamp returns the current time (see Now) as a Stamp with the name of the
+// source it came from.
+func (f *TimeFallback) Stamp() (Stamp, error) {
+	d, source, err := f.Now()
+	if err != nil {
+		return Stamp{}, err
+	}
+	return NewStamp(d, source), nil
+}
+
+// String returns the DTG with the source and confidence, e.g.
+// "151230ZDEC19 (ntp, high)".
+func (s Stamp) String() string {
+	return s.DTG.String() + " (" + s.Source + ", " + s.Confidence.String() + ")"
+}
+
+// stampJSON is the JSON form of a Stamp, the DTG as written alongside its
+// precise instant.
+type stampJSON struct {
+	DTG        string     `json:"dtg"`
+	Time       *time.Time `json:"time,omitempty"`
+	Source     string     `json:"source,omitempty"`
+	Confidence Confidence `json:"confidence"`
+}
+
+// MarshalJSON implements json.Marshaler, e.g. {"dtg":"151230ZDEC19",
+// "time":"2019-12-15T12:30:05.1Z","source":"ntp","confidence":"high"}.
+func (s Stamp) MarshalJSON() ([]byte, error) {
+	t := s.DTG.Time.UTC()
+	return json.Marshal(stampJSON{DTG: s.DTG.String(), Time: &t, Source: s.Source, Confidence: s.Confidence})
+}
+
+// UnmarshalJSON implements json.Unmarshaler for the form of MarshalJSON.
+// The time is optional, the DTG is used without it (in which case it should
+// be fully qualified), otherwise they must agree to the minute.
+func (s *Stamp) UnmarshalJSON(data []byte) error {
+	var v stampJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	d, err := Parse(v.DTG)
+	if err != nil {
+		return err
+	}
+	if v.Time != nil {
+		if !v.Time.Truncate(time.Minute).Equal(d.Time) {
+			return ErrInvalidStamp
+		}
+		d = DTG{Time: v.Time.In(d.Location())}
+	}
+	*s = Stamp{DTG: d, Source: v.Source, Confidence: v.Confidence}
+	return nil
+}
@@ -0,0 +1,66 @@
+package dtg
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStamp(t *testing.T) {
+	received := DTG{Time: time.Date(2019, time.December, 15, 13, 30, 5, 100000000, time.FixedZone("+0100", 3600))}
+	s := NewStamp(received, SourceNTP)
+	if s.Confidence != ConfidenceHigh || s.String() != "151330ADEC19 (ntp, high)" {
+		t.Errorf("Expected \"151330ADEC19 (ntp, high)\", but got \"%s\"", s)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"dtg":"151330ADEC19","time":"2019-12-15T12:30:05.1Z","source":"ntp","confidence":"high"}`; string(b) != expected {
+		t.Errorf("Expected %s, but got %s", expected, b)
+	}
+	var u Stamp
+	if err := json.Unmarshal(b, &u); err != nil {
+		t.Fatal(err)
+	}
+	if !u.DTG.Equal(received.Time) || u.DTG.ZoneLetter() != "A" || u.Source != SourceNTP || u.Confidence != ConfidenceHigh {
+		t.Errorf("Expected %s, but got %s", s, u)
+	}
+	if err := json.Unmarshal([]byte(`{"dtg":"151230ZDEC19","source":"operator","confidence":"MEDIUM"}`), &u); err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != "151230ZDEC19 (operator, medium)" {
+		t.Errorf("Expected \"151230ZDEC19 (operator, medium)\", but got \"%s\"", u)
+	}
+	for _, invalid := range []string{
+		`{"dtg":"151231ZDEC19","time":"2019-12-15T12:30:05Z"}`,
+		`{"dtg":"151230ZDEC19","confidence":"certain"}`,
+		`{"dtg":"441230ZDEC19"}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &u); err == nil {
+			t.Errorf("Expected %s to fail, but got %s", invalid, u)
+		}
+	}
+	for source, expected := range map[string]Confidence{SourceGPS: ConfidenceHigh, SourceSystem: ConfidenceLow, SourceOperator: ConfidenceMedium, "sundial": ConfidenceUnknown} {
+		if c := SourceConfidence(source); c != expected {
+			t.Errorf("Expected %s for %s, but got %s", expected, source, c)
+		}
+	}
+	stamp, err := NewTimeFallback(SystemTimeSource()).Stamp()
+	if err != nil || stamp.Source != SourceSystem || stamp.Confidence != ConfidenceLow || time.Since(stamp.DTG.Time) > time.Second {
+		t.Errorf("Expected a low confidence stamp of the system clock, but got %s (%v)", stamp, err)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatal(err)
+	}
+	var g Stamp
+	if err := gob.NewDecoder(&buf).Decode(&g); err != nil {
+		t.Fatal(err)
+	}
+	if !g.DTG.Equal(s.DTG.Time) || g.Source != SourceNTP || g.Confidence != ConfidenceHigh {
+		t.Errorf("Expected gob to keep %s, but got %s", s, g)
+	}
+}
@@ -0,0 +1,128 @@
+package dtg
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// addressRegexp matches the originator and the addressee of a message,
+// e.g. "FM STN01 TO STN02".
+var addressRegexp *regexp.Regexp = regexp.MustCompile(`(?i)\bFM\s+(\S+)\s+TO\s+(\S+)\s*`)
+
+// LogEntry is a line of a master station log.
+type LogEntry struct {
+	// Serial is the number of the entry in its day, from 1.
+	Serial int
+	DTG    DTG
+	From   string
+	To     string
+	Text   string
+}
+
+// LogPage are the entries of a master station log for one Zulu day.
+type LogPage struct {
+	// Day is 0000Z of the day.
+	Day     DTG
+	Entries []LogEntry
+}
+
+// StationLog arranges records, e.g. from MergeStreams, into a master
+// station log with one page per Zulu day in DTG order. DTGs are in Zulu
+// and serial numbers start over each day. The originator and addressee are
+// taken from "FM x TO y" in the text of a record and the text after it is
+// the entry text (the text after the leading DTG when there is no FM and
+// TO).
+func StationLog(records []Record) []LogPage {
+	sorted := make([]Record, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].DTG.Time.Before(sorted[j].DTG.Time) })
+	var pages []LogPage
+	for _, record := range sorted {
+		zulu := record.DTG.Zulu()
+		day := DTG{Time: zulu.Time.Truncate(24 * time.Hour)}
+		if len(pages) == 0 || !pages[len(pages)-1].Day.Time.Equal(day.Time) {
+			pages = append(pages, LogPage{Day: day})
+		}
+		page := &pages[len(pages)-1]
+		entry := LogEntry{Serial: len(page.Entries) + 1, DTG: zulu, Text: record.Text}
+		if loc := addressRegexp.FindStringSubmatchIndex(record.Text); loc != nil {
+			entry.From = strings.ToUpper(record.Text[loc[2]:loc[3]])
+			entry.To = strings.ToUpper(record.Text[loc[4]:loc[5]])
+			entry.Text = record.Text[loc[1]:]
+		} else if _, rest, err := ParsePrefix(record.Text); err == nil {
+			entry.Text = rest
+		}
+		entry.Text = strings.TrimSpace(entry.Text)
+		page.Entries = append(page.Entries, entry)
+	}
+	return pages
+}
+
+// logDay is the heading date of a page, e.g. 15 DEC 2019.
+func logDay(d DTG) string {
+	return strings.ToUpper(d.Time.Format("02 Jan 2006"))
+}
+
+// WriteStationLog writes the pages as plain text for a line printer, each
+// page headed by its Zulu day and separated by a form feed.
+func WriteStationLog(w io.Writer, pages []LogPage) error {
+	for i, page := range pages {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\f"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "MASTER STATION LOG %s\n\n%-6s %-12s %-8s %-8s %s\n", logDay(page.Day), "SERIAL", "DTG", "FM", "TO", "TEXT"); err != nil {
+			return err
+		}
+		for _, e := range page.Entries {
+			if _, err := fmt.Fprintf(w, "%04d   %-12s %-8s %-8s %s\n", e.Serial, e.DTG, e.From, e.To, e.Text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var stationLogTemplate *template.Template = template.Must(template.New("log").Funcs(template.FuncMap{"day": logDay}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Master station log</title>
+<style>
+body { font-family: monospace; }
+section { page-break-after: always; break-after: page; }
+section:last-child { page-break-after: auto; break-after: auto; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid black; padding: 2px 4px; text-align: left; vertical-align: top; }
+thead { display: table-header-group; }
+</style>
+</head>
+<body>
+{{- range .}}
+<section>
+<h1>Master station log {{day .Day}}</h1>
+<table>
+<thead><tr><th>Serial</th><th>DTG</th><th>FM</th><th>TO</th><th>Text</th></tr></thead>
+<tbody>
+{{- range .Entries}}
+<tr><td>{{printf "%04d" .Serial}}</td><td>{{.DTG}}</td><td>{{.From}}</td><td>{{.To}}</td><td>{{.Text}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteStationLogHTML writes the pages as a printable HTML document, one
+// page per Zulu day when printed (or saved as PDF) from a browser.
+func WriteStationLogHTML(w io.Writer, pages []LogPage) error {
+	return stationLogTemplate.Execute(w, pages)
+}
@@ -0,0 +1,58 @@
+package dtg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStationLog(t *testing.T) {
+	records := []Record{
+		{DTG: mustParse(t, "160030ADEC19"), Text: "160030ADEC19 fm stn03 to stn01 QSL"},
+		{DTG: mustParse(t, "151230ZDEC19"), Text: "151230ZDEC19 FM STN01 TO STN02 RADIO CHECK"},
+		{DTG: mustParse(t, "152359ZDEC19"), Text: "152359ZDEC19 NET CLOSED"},
+		{DTG: mustParse(t, "160100ZDEC19"), Text: "R 160100ZDEC19 FM STN02 TO STN03 <b>SITREP</b>"},
+	}
+	pages := StationLog(records)
+	if len(pages) != 2 || len(pages[0].Entries) != 3 || len(pages[1].Entries) != 1 {
+		t.Fatalf("Expected a page for the 15th with 3 entries and one for the 16th, but got %+v", pages)
+	}
+	if e := pages[0].Entries[1]; e.Serial != 2 || e.DTG.String() != "152330ZDEC19" || e.From != "STN03" || e.To != "STN01" || e.Text != "QSL" {
+		t.Errorf("Unexpected entry %+v", e)
+	}
+	if e := pages[0].Entries[2]; e.From != "" || e.Text != "NET CLOSED" {
+		t.Errorf("Unexpected entry %+v", e)
+	}
+	if e := pages[1].Entries[0]; e.Serial != 1 || pages[1].Day.String() != "160000ZDEC19" {
+		t.Errorf("Expected serials to start over on the 16th, but got %+v", e)
+	}
+	var text strings.Builder
+	if err := WriteStationLog(&text, pages); err != nil {
+		t.Fatal(err)
+	}
+	expected := `MASTER STATION LOG 15 DEC 2019
+
+SERIAL DTG          FM       TO       TEXT
+0001   151230ZDEC19 STN01    STN02    RADIO CHECK
+0002   152330ZDEC19 STN03    STN01    QSL
+0003   152359ZDEC19                   NET CLOSED
+` + "\f" + `MASTER STATION LOG 16 DEC 2019
+
+SERIAL DTG          FM       TO       TEXT
+0001   160100ZDEC19 STN02    STN03    <b>SITREP</b>
+`
+	if text.String() != expected {
+		t.Errorf("Expected\n%s\nbut got\n%s", expected, text.String())
+	}
+	var html strings.Builder
+	if err := WriteStationLogHTML(&html, pages); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"<h1>Master station log 16 DEC 2019</h1>", "<td>0002</td><td>152330ZDEC19</td><td>STN03</td><td>STN01</td><td>QSL</td>", "&lt;b&gt;SITREP&lt;/b&gt;"} {
+		if !strings.Contains(html.String(), s) {
+			t.Errorf("Expected the HTML to contain %s, but got\n%s", s, html.String())
+		}
+	}
+	if strings.Count(html.String(), "<section>") != 2 {
+		t.Errorf("Expected two pages, but got\n%s", html.String())
+	}
+}
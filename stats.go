@@ -0,0 +1,64 @@
+package dtg
+
+import "sort"
+
+// LetterCounts counts DTGs per zone designator, e.g. to audit whether the
+// traffic of a unit complies with a Zulu-only SOP. Use Add to aggregate the
+// matches of several documents.
+type LetterCounts map[string]int
+
+// LetterCount is the number of DTGs written with a zone letter.
+type LetterCount struct {
+	Letter string
+	Count  int
+}
+
+// CountLetters returns the number of DTGs per zone designator among
+// matches, see FindAll.
+func CountLetters(matches []Match) LetterCounts {
+	counts := LetterCounts{}
+	counts.Add(matches)
+	return counts
+}
+
+// Add counts the designators of matches.
+func (c LetterCounts) Add(matches []Match) {
+	for _, m := range matches {
+		c[m.Designator]++
+	}
+}
+
+// Total returns the number of DTGs counted.
+func (c LetterCounts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// Share returns the fraction (0-1) of DTGs written with letter, or 0 if
+// nothing has been counted.
+func (c LetterCounts) Share(letter string) float64 {
+	total := c.Total()
+	if total == 0 {
+		return 0
+	}
+	return float64(c[letter]) / float64(total)
+}
+
+// Sorted returns the counts with the most common letter first. Letters
+// with the same count are in alphabetical order.
+func (c LetterCounts) Sorted() []LetterCount {
+	sorted := make([]LetterCount, 0, len(c))
+	for letter, n := range c {
+		sorted = append(sorted, LetterCount{Letter: letter, Count: n})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Letter < sorted[j].Letter
+	})
+	return sorted
+}
@@ -0,0 +1,29 @@
+package dtg
+
+import (
+	"testing"
+)
+
+func TestCountLetters(t *testing.T) {
+	counts := CountLetters(FindAll("151230Z 151300J DEC 151400ZDEC19 151500B"))
+	counts.Add(FindAll("R 161200J FM HQ, 161300Z"))
+	expected := []LetterCount{{"Z", 3}, {"J", 2}, {"B", 1}}
+	sorted := counts.Sorted()
+	if len(sorted) != len(expected) {
+		t.Fatalf("Expected %v, but got %v", expected, sorted)
+	}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("Expected %v, but got %v", expected[i], sorted[i])
+		}
+	}
+	if counts.Total() != 6 {
+		t.Errorf("Expected a total of 6, but got %d", counts.Total())
+	}
+	if share := counts.Share("Z"); share != 0.5 {
+		t.Errorf("Expected Z share 0.5, but got %v", share)
+	}
+	if share := (LetterCounts{}).Share("Z"); share != 0 {
+		t.Errorf("Expected 0 share of an empty count, but got %v", share)
+	}
+}
@@ -0,0 +1,110 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultTag is the struct tag SetDefaults reads.
+const DefaultTag string = "dtgdefault"
+
+var ErrDefaultsTarget error = errors.New("SetDefaults needs a non-nil pointer to a struct")
+var ErrDefaultsField error = errors.New("dtgdefault tag on a field that is not a DTG, *DTG or time.Time")
+
+var (
+	dtgType  = reflect.TypeOf(DTG{})
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// SetDefaults fills the zero DTG, *DTG and time.Time fields of the struct v
+// points to that are tagged dtgdefault, e.g. after loading a config:
+//
+//	type Config struct {
+//		Start    dtg.DTG  `dtgdefault:"010600Z"`
+//		Deadline *dtg.DTG `dtgdefault:"+24h"`
+//	}
+//
+// A value with a leading + or - is a time.ParseDuration relative to the
+// reference time, anything else is a DTG as of Parse. Nested structs (and
+// non-nil pointers to structs) are filled too. Errors name the field, e.g.
+// "Deadline: ...".
+func SetDefaults(v interface{}, opts ...Option) error {
+	return (*Parser)(nil).SetDefaults(v, opts...)
+}
+
+// SetDefaults is like the package level SetDefaults, but parses with p.
+func (p *Parser) SetDefaults(v interface{}, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrDefaultsTarget
+	}
+	// One reference time for all fields, so that relative defaults agree.
+	p = p.with(opts)
+	return p.with([]Option{WithReferenceTime(p.reference())}).setDefaults(rv.Elem(), "")
+}
+
+func (p *Parser) setDefaults(rv reflect.Value, path string) error {
+	for i := 0; i < rv.NumField(); i++ {
+		field, value := rv.Type().Field(i), rv.Field(i)
+		if !value.CanSet() {
+			continue
+		}
+		name := path + field.Name
+		tag, tagged := field.Tag.Lookup(DefaultTag)
+		switch {
+		case tagged:
+			if err := p.setDefault(value, tag); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		case value.Kind() == reflect.Struct && value.Type() != dtgType && value.Type() != timeType:
+			if err := p.setDefaults(value, name+"."); err != nil {
+				return err
+			}
+		case value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct:
+			if err := p.setDefaults(value.Elem(), name+"."); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Parser) setDefault(value reflect.Value, tag string) error {
+	switch value.Type() {
+	case dtgType, timeType, reflect.PtrTo(dtgType):
+	default:
+		return ErrDefaultsField
+	}
+	if !value.IsZero() {
+		return nil
+	}
+	d, err := p.parseDefault(tag)
+	if err != nil {
+		return err
+	}
+	switch value.Type() {
+	case dtgType:
+		value.Set(reflect.ValueOf(d))
+	case timeType:
+		value.Set(reflect.ValueOf(d.Time))
+	default:
+		value.Set(reflect.ValueOf(&d))
+	}
+	return nil
+}
+
+// parseDefault returns the DTG of a dtgdefault tag.
+func (p *Parser) parseDefault(tag string) (DTG, error) {
+	tag = strings.TrimSpace(tag)
+	if strings.HasPrefix(tag, "+") || strings.HasPrefix(tag, "-") {
+		d, err := time.ParseDuration(tag)
+		if err != nil {
+			return DTG{}, err
+		}
+		return DTG{Time: p.reference().Add(d)}, nil
+	}
+	return p.Parse(tag)
+}
@@ -0,0 +1,54 @@
+package dtg
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetDefaults(t *testing.T) {
+	reference := time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC)
+	kept := mustParse(t, "151230ZDEC19")
+	type window struct {
+		Open time.Time `dtgdefault:"-90m"`
+	}
+	config := struct {
+		Start    DTG  `dtgdefault:"010600Z"`
+		Deadline *DTG `dtgdefault:"+24h"`
+		Kept     DTG  `dtgdefault:"010600Z"`
+		Untagged DTG
+		Window   window
+		Next     *window
+		hidden   DTG `dtgdefault:"010600Z"`
+	}{Kept: kept, Next: &window{}}
+	if err := SetDefaults(&config, WithReferenceTime(reference)); err != nil {
+		t.Fatal(err)
+	}
+	if config.Start.String() != "010600ZDEC19" {
+		t.Errorf("Expected Start \"010600ZDEC19\", but got \"%s\"", config.Start)
+	}
+	if config.Deadline == nil || !config.Deadline.Equal(reference.Add(24*time.Hour)) {
+		t.Errorf("Expected Deadline %s, but got %v", reference.Add(24*time.Hour), config.Deadline)
+	}
+	if !config.Kept.Equal(kept.Time) || !config.Untagged.IsZero() || !config.hidden.IsZero() {
+		t.Errorf("Expected only zero tagged fields to be set, but got %+v", config)
+	}
+	if !config.Window.Open.Equal(reference.Add(-90*time.Minute)) || !config.Next.Open.Equal(config.Window.Open) {
+		t.Errorf("Expected nested Open %s, but got %s and %s", reference.Add(-90*time.Minute), config.Window.Open, config.Next.Open)
+	}
+	var invalid struct {
+		Start DTG `dtgdefault:"321200Z"`
+	}
+	if err := SetDefaults(&invalid); !errors.Is(err, ErrInvalidDay) || err.Error()[:7] != "Start: " {
+		t.Errorf("Expected \"Start: \" and %v, but got %v", ErrInvalidDay, err)
+	}
+	var wrong struct {
+		Start string `dtgdefault:"010600Z"`
+	}
+	if err := SetDefaults(&wrong); !errors.Is(err, ErrDefaultsField) {
+		t.Errorf("Expected %v, but got %v", ErrDefaultsField, err)
+	}
+	if err := SetDefaults(config); err != ErrDefaultsTarget {
+		t.Errorf("Expected %v, but got %v", ErrDefaultsTarget, err)
+	}
+}
@@ -0,0 +1,118 @@
+package dtg
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// suggest returns the DTG s, which DtgRegexp does not match in upper case,
+// was most likely meant to be, e.g. 121212AFEB01 for 121212AFXB01 or
+// 121212OFEB02 for 121212ÖFEB02, or an empty string. Only wrong letters and
+// months are corrected: a letter by its Latin look-alike or its base letter
+// without diacritics, a month by the one month closest to it (at most one
+// edit away from its abbreviation or name). The suggestion must validate with p.
+func (p *Parser) suggest(s string) string {
+	r := []rune(strings.ToUpper(s))
+	// Correct the first problem until there are none, a corrected month may
+	// shift the parts after it.
+	for corrections := 0; corrections < 4; corrections++ {
+		problems := diagnoseAll(string(r), nil)
+		if len(problems) == 0 {
+			break
+		}
+		problem := problems[0]
+		switch problem.field {
+		case "Letter":
+			letter, ok := baseLetter(r[problem.pos])
+			if !ok {
+				return ""
+			}
+			r[problem.pos] = letter
+		case "Month":
+			end := problem.pos
+			for end < len(r) && (r[end] < '0' || r[end] > '9') {
+				end++
+			}
+			month, ok := nearestMonth(string(r[problem.pos:end]))
+			if !ok {
+				return ""
+			}
+			r = append(r[:problem.pos], append([]rune(month), r[end:]...)...)
+		default:
+			return ""
+		}
+	}
+	suggestion := string(r)
+	// A suggestion DtgRegexp matches does not suggest again.
+	if !DtgRegexp.MatchString(suggestion) || p.Validate(suggestion) != nil {
+		return ""
+	}
+	return suggestion
+}
+
+// baseLetter returns the upper case letter A-Z r looks like or is written
+// with, e.g. O for Ö and Cyrillic О.
+func baseLetter(r rune) (rune, bool) {
+	if c, ok := confusables[r]; ok {
+		r = c
+	}
+	for _, c := range strings.ToUpper(norm.NFD.String(string(r))) {
+		// The first rune of the decomposition is the base letter.
+		return c, c >= 'A' && c <= 'Z'
+	}
+	return 0, false
+}
+
+// nearestMonth returns the English month abbreviation of the abbreviation
+// or month name at edit distance 1 (an insertion, deletion, substitution or
+// transposition) from s, unless there are several months.
+func nearestMonth(s string) (string, bool) {
+	nearest := ""
+	for i, abbreviation := range monthAbbreviations {
+		if editDistance(s, abbreviation) > 1 && editDistance(s, strings.ToUpper(time.Month(i+1).String())) > 1 {
+			continue
+		}
+		if nearest != "" {
+			return "", false
+		}
+		nearest = abbreviation
+	}
+	return nearest, nearest != ""
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b, i.e. the Levenshtein distance also counting a transposition of
+// adjacent characters as one edit.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, minInt(d[i][j-1]+1, d[i-1][j-1]+cost))
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
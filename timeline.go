@@ -0,0 +1,173 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnknownVersion error = errors.New("unknown plan or timeline version")
+
+// Timeline is a set of named DTGs of a plan, e.g. H-HOUR or phase lines.
+type Timeline map[string]DTG
+
+// TimelineVersion is a version of a plan's Timeline kept by a PlanStore.
+// Versions are numbered from 1.
+type TimelineVersion struct {
+	Version  int
+	Recorded DTG
+	Timeline Timeline
+}
+
+// TimelineChange is the difference of one named DTG between two timelines.
+type TimelineChange struct {
+	Name  string
+	Kind  ChangeKind
+	Old   DTG
+	New   DTG
+	Delta time.Duration
+}
+
+// String describes the change, e.g. "H-HOUR slipped 6h to 161200ZDEC19".
+func (c TimelineChange) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("%s added at %s", c.Name, c.New)
+	case Removed:
+		return fmt.Sprintf("%s removed (was %s)", c.Name, c.Old)
+	}
+	switch {
+	case c.Delta > 0:
+		return fmt.Sprintf("%s slipped %s to %s", c.Name, shortDuration(c.Delta), c.New)
+	case c.Delta < 0:
+		return fmt.Sprintf("%s advanced %s to %s", c.Name, shortDuration(-c.Delta), c.New)
+	}
+	return fmt.Sprintf("%s rewritten as %s", c.Name, c.New)
+}
+
+// DiffTimelines returns the changes from timeline a to b, sorted by name. A
+// DTG expressed in another zone but denoting the same instant is not a
+// change.
+func DiffTimelines(a, b Timeline) []TimelineChange {
+	var changes []TimelineChange
+	for name, old := range a {
+		if new, ok := b[name]; !ok {
+			changes = append(changes, TimelineChange{Name: name, Kind: Removed, Old: old})
+		} else if !new.Time.Equal(old.Time) {
+			changes = append(changes, TimelineChange{Name: name, Kind: Changed, Old: old, New: new, Delta: new.Time.Sub(old.Time)})
+		}
+	}
+	for name, new := range b {
+		if _, ok := a[name]; !ok {
+			changes = append(changes, TimelineChange{Name: name, Kind: Added, New: new})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// TimelineFromDocument builds a Timeline from a document where each named
+// DTG is on a line of its own after its name, e.g. "H-HOUR 160600ZDEC19" or
+// "PL ALPHA: 160730Z DEC 19". Lines without a name before the first DTG
+// are ignored, as are later lines repeating a name.
+func TimelineFromDocument(text string) Timeline {
+	timeline := Timeline{}
+	for _, line := range strings.Split(text, "\n") {
+		matches := FindAll(line)
+		if len(matches) == 0 {
+			continue
+		}
+		name := strings.TrimRight(strings.TrimSpace(line[:matches[0].Start]), ":=- \t")
+		if _, seen := timeline[name]; name == "" || seen {
+			continue
+		}
+		timeline[name] = matches[0].DTG
+	}
+	return timeline
+}
+
+// PlanStore keeps successive versions of named plan timelines, so slips of
+// e.g. H-hour can be tracked and reported. A PlanStore is safe for
+// concurrent use.
+type PlanStore struct {
+	mu    sync.RWMutex
+	plans map[string][]TimelineVersion
+}
+
+// NewPlanStore returns an empty PlanStore.
+func NewPlanStore() *PlanStore {
+	return &PlanStore{plans: make(map[string][]TimelineVersion)}
+}
+
+// Commit stores a new version of the plan's timeline recorded at the given
+// DTG and returns its version number. The timeline is copied.
+func (s *PlanStore) Commit(plan string, recorded DTG, timeline Timeline) int {
+	copied := make(Timeline, len(timeline))
+	for k, v := range timeline {
+		copied[k] = v
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	version := len(s.plans[plan]) + 1
+	s.plans[plan] = append(s.plans[plan], TimelineVersion{Version: version, Recorded: recorded, Timeline: copied})
+	return version
+}
+
+// Version returns a version of the plan.
+func (s *PlanStore) Version(plan string, version int) (TimelineVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	versions := s.plans[plan]
+	if version < 1 || version > len(versions) {
+		return TimelineVersion{}, ErrUnknownVersion
+	}
+	return versions[version-1], nil
+}
+
+// Latest returns the latest version of the plan.
+func (s *PlanStore) Latest(plan string) (TimelineVersion, error) {
+	s.mu.RLock()
+	n := len(s.plans[plan])
+	s.mu.RUnlock()
+	return s.Version(plan, n)
+}
+
+// Versions returns all versions of the plan, oldest first.
+func (s *PlanStore) Versions(plan string) []TimelineVersion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	versions := make([]TimelineVersion, len(s.plans[plan]))
+	copy(versions, s.plans[plan])
+	return versions
+}
+
+// Diff returns the changes between two versions of the plan.
+func (s *PlanStore) Diff(plan string, from, to int) ([]TimelineChange, error) {
+	a, err := s.Version(plan, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.Version(plan, to)
+	if err != nil {
+		return nil, err
+	}
+	return DiffTimelines(a.Timeline, b.Timeline), nil
+}
+
+// ChangeLog renders every change between consecutive versions of the plan,
+// oldest first, with the day it was recorded, e.g. "H-HOUR slipped 6h to
+// 161200ZDEC19 on 14 DEC".
+func (s *PlanStore) ChangeLog(plan string) []string {
+	versions := s.Versions(plan)
+	var log []string
+	for i := 1; i < len(versions); i++ {
+		on := strings.ToUpper(versions[i].Recorded.Time.Format("02 Jan"))
+		for _, c := range DiffTimelines(versions[i-1].Timeline, versions[i].Timeline) {
+			log = append(log, c.String()+" on "+on)
+		}
+	}
+	return log
+}
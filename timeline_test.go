@@ -0,0 +1,63 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlanStore(t *testing.T) {
+	s := NewPlanStore()
+	v1 := TimelineFromDocument("OPORD\nH-HOUR 160600ZDEC19\nPL ALPHA: 160730Z DEC 19\nPL BRAVO NLT 161000ZDEC19\n161200ZDEC19\n")
+	if len(v1) != 3 {
+		t.Fatalf("Expected 3 named DTGs, but got %v", v1)
+	}
+	if n := s.Commit("OP FROST", mustParse(t, `131200ZDEC19`), v1); n != 1 {
+		t.Errorf("Expected version 1, but got %d", n)
+	}
+	v2 := TimelineFromDocument("FRAGO 1\nH-HOUR 161200ZDEC19\nPL ALPHA: 160630Z DEC 19\nPL BRAVO NLT 161100ADEC19\nENDEX 201200ZDEC19\n")
+	s.Commit("OP FROST", mustParse(t, `141530ZDEC19`), v2)
+	v3 := Timeline{"H-HOUR": mustParse(t, `161200ZDEC19`)}
+	s.Commit("OP FROST", mustParse(t, `150800ZDEC19`), v3)
+	v3["H-HOUR"] = mustParse(t, `010000ZJAN20`)
+	if latest, _ := s.Latest("OP FROST"); latest.Version != 3 || latest.Timeline["H-HOUR"].String() != `161200ZDEC19` {
+		t.Errorf("Expected the stored timeline to be a copy, but got %v", latest)
+	}
+
+	changes, err := s.Diff("OP FROST", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 || changes[0].Name != "ENDEX" || changes[1].Delta != 6*time.Hour || changes[2].Delta != -time.Hour {
+		t.Errorf("Expected ENDEX added, H-HOUR +6h and PL ALPHA -1h, but got %v", changes)
+	}
+	expected := []string{
+		`ENDEX added at 201200ZDEC19 on 14 DEC`,
+		`H-HOUR slipped 6h to 161200ZDEC19 on 14 DEC`,
+		`PL ALPHA advanced 1h to 160630ZDEC19 on 14 DEC`,
+		`ENDEX removed (was 201200ZDEC19) on 15 DEC`,
+		`PL ALPHA removed (was 160630ZDEC19) on 15 DEC`,
+		`PL BRAVO NLT removed (was 161100ADEC19) on 15 DEC`,
+	}
+	log := s.ChangeLog("OP FROST")
+	if len(log) != len(expected) {
+		t.Fatalf("Expected %d log entries, but got %v", len(expected), log)
+	}
+	for i := range log {
+		if log[i] != expected[i] {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected[i], log[i])
+		}
+	}
+	if _, err := s.Diff("OP FROST", 0, 2); err != ErrUnknownVersion {
+		t.Errorf("Expected %v, but got %v", ErrUnknownVersion, err)
+	}
+	if _, err := s.Latest("OP THAW"); err != ErrUnknownVersion {
+		t.Errorf("Expected %v, but got %v", ErrUnknownVersion, err)
+	}
+	if n := len(s.Versions("OP FROST")); n != 3 {
+		t.Errorf("Expected 3 versions, but got %d", n)
+	}
+	rewritten := TimelineChange{Name: "H-HOUR", Kind: Changed, New: mustParse(t, `161300ADEC19`)}
+	if s := rewritten.String(); s != `H-HOUR rewritten as 161300ADEC19` {
+		t.Errorf("Expected \"H-HOUR rewritten as 161300ADEC19\", but got \"%s\"", s)
+	}
+}
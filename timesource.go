@@ -0,0 +1,131 @@
+package dtg
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sa6mwa/dtg/internal/ntp"
+)
+
+var (
+	ErrNoTimeSource error = errors.New("no time source available")
+	ErrNoGPSFix     error = errors.New("no GPS fix in the NMEA sentences read")
+)
+
+// DefaultMinInterval is how often a TimeSource is queried at most when its
+// MinInterval is zero.
+const DefaultMinInterval time.Duration = time.Minute
+
+// gpsMaxSentences is how many NMEA sentences GPSTimeSource reads per query
+// looking for a fix, a receiver sends several per second.
+const gpsMaxSentences = 32
+
+// TimeSource is one source of the current time in a TimeFallback, e.g.
+// NTPTimeSource, GPSTimeSource or SystemTimeSource.
+type TimeSource struct {
+	// Name identifies the source a DTG stamp came from, e.g. "ntp".
+	Name string
+	// Now returns the current time according to the source.
+	Now func() (time.Time, error)
+	// MinInterval rate limits the source, DefaultMinInterval when zero.
+	// Within it, the last good reading is advanced by the local monotonic
+	// clock rather than querying the source again, and a source that
+	// failed is skipped.
+	MinInterval time.Duration
+}
+
+// NTPTimeSource returns the TimeSource "ntp" querying server (host or
+// host:port, an empty server uses pool.ntp.org).
+func NTPTimeSource(server string) TimeSource {
+	return TimeSource{Name: SourceNTP, Now: func() (time.Time, error) {
+		resp, err := ntp.Query(server, 0)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Now().Add(resp.ClockOffset), nil
+	}}
+}
+
+// GPSTimeSource returns the TimeSource "gps" reading NMEA 0183 sentences
+// from r, e.g. a serial line of a GPS receiver, and taking the time of the
+// first RMC or ZDA sentence with a valid fix, see ParseNMEATime. It is not
+// safe to share r.
+func GPSTimeSource(r io.Reader) TimeSource {
+	scanner := bufio.NewScanner(r)
+	var mu sync.Mutex
+	return TimeSource{Name: SourceGPS, Now: func() (time.Time, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for i := 0; i < gpsMaxSentences && scanner.Scan(); i++ {
+			if d, err := ParseNMEATime(scanner.Text()); err == nil {
+				return d.Time, nil
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return time.Time{}, err
+		}
+		return time.Time{}, ErrNoGPSFix
+	}}
+}
+
+// SystemTimeSource returns the TimeSource "system", the local clock, which
+// never fails and is the last resort of a TimeFallback.
+func SystemTimeSource() TimeSource {
+	return TimeSource{Name: SourceSystem, Now: func() (time.Time, error) {
+		return time.Now(), nil
+	}}
+}
+
+// TimeFallback stamps DTGs from the first of its sources that works, e.g.
+// NTP, then GPS, then the system clock for field deployments with
+// unreliable network time, and tells which source each stamp came from. A
+// TimeFallback is safe for concurrent use.
+type TimeFallback struct {
+	sources []TimeSource
+	mu      sync.Mutex
+	states  []timeSourceState
+}
+
+type timeSourceState struct {
+	// queried is when the source was last queried, by the local clock
+	// (with its monotonic reading).
+	queried time.Time
+	reading time.Time
+	err     error
+}
+
+// NewTimeFallback returns a TimeFallback trying sources in order.
+func NewTimeFallback(sources ...TimeSource) *TimeFallback {
+	return &TimeFallback{sources: sources, states: make([]timeSourceState, len(sources))}
+}
+
+// Now returns the current time as a Zulu DTG and the name of the source it
+// came from, or ErrNoTimeSource (wrapping the error of the last source
+// queried) when no source works.
+func (f *TimeFallback) Now() (DTG, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := ErrNoTimeSource
+	for i, source := range f.sources {
+		state := &f.states[i]
+		interval := source.MinInterval
+		if interval <= 0 {
+			interval = DefaultMinInterval
+		}
+		if state.queried.IsZero() || time.Since(state.queried) >= interval {
+			reading, sourceErr := source.Now()
+			*state = timeSourceState{queried: time.Now(), reading: reading, err: sourceErr}
+			if sourceErr != nil {
+				err = fmt.Errorf("%w: %s: %v", ErrNoTimeSource, source.Name, sourceErr)
+			}
+		}
+		if state.err == nil {
+			return DTG{Time: state.reading.Add(time.Since(state.queried)).UTC()}, source.Name, nil
+		}
+	}
+	return DTG{}, "", err
+}
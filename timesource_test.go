@@ -0,0 +1,64 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeFallback(t *testing.T) {
+	failure := errors.New("unreachable")
+	var ntpQueries, gpsQueries int
+	unreachable := TimeSource{Name: "ntp", MinInterval: time.Hour, Now: func() (time.Time, error) {
+		ntpQueries++
+		return time.Time{}, failure
+	}}
+	gpsTime := time.Date(2019, time.December, 15, 12, 30, 0, 0, time.UTC)
+	gps := TimeSource{Name: "gps", MinInterval: time.Hour, Now: func() (time.Time, error) {
+		gpsQueries++
+		return gpsTime, nil
+	}}
+	f := NewTimeFallback(unreachable, gps, SystemTimeSource())
+	for i := 0; i < 3; i++ {
+		d, source, err := f.Now()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if source != "gps" || d.Time.Sub(gpsTime) < 0 || d.Time.Sub(gpsTime) > time.Second || d.ZoneLetter() != "Z" {
+			t.Errorf("Expected about %s from gps, but got %s from %s", gpsTime, d.Time, source)
+		}
+	}
+	if ntpQueries != 1 || gpsQueries != 1 {
+		t.Errorf("Expected one query of each source within MinInterval, but got %d and %d", ntpQueries, gpsQueries)
+	}
+	d, source, err := NewTimeFallback(unreachable, SystemTimeSource()).Now()
+	if err != nil || source != "system" || time.Since(d.Time) > time.Second {
+		t.Errorf("Expected the system clock, but got %s from %s (%v)", d.Time, source, err)
+	}
+	if _, _, err := NewTimeFallback(unreachable).Now(); !errors.Is(err, ErrNoTimeSource) {
+		t.Errorf("Expected %v, but got %v", ErrNoTimeSource, err)
+	}
+}
+
+func TestGPSTimeSource(t *testing.T) {
+	serial := strings.NewReader("$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n" +
+		"$GPRMC,123519,V,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*7D\r\n" +
+		"$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6B\r\n" +
+		"$GPRMC,123519.50,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*41\r\n" +
+		"$GNRMC,123520.50,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*55\r\n")
+	gps := GPSTimeSource(serial)
+	got, err := gps.Now()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(1994, time.March, 23, 12, 35, 19, 500000000, time.UTC); !got.Equal(expected) {
+		t.Errorf("Expected %s, but got %s", expected, got)
+	}
+	if got, err := gps.Now(); err != nil || !got.Equal(time.Date(1994, time.March, 23, 12, 35, 20, 500000000, time.UTC)) {
+		t.Errorf("Expected the next fix, but got %s (%v)", got, err)
+	}
+	if _, err := gps.Now(); err != ErrNoGPSFix {
+		t.Errorf("Expected %v, but got %v", ErrNoGPSFix, err)
+	}
+}
@@ -0,0 +1,105 @@
+package dtg
+
+import (
+	"sync"
+	"time"
+)
+
+// TrafficCounter counts messages per DTG minute over a sliding window, for
+// gateways reporting traffic rates in DTG labelled intervals. Counts older
+// than the window (relative to the latest DTG counted) are discarded. A
+// TrafficCounter is safe for concurrent use.
+type TrafficCounter struct {
+	mu      sync.Mutex
+	window  int64
+	latest  int64
+	buckets map[int64]int
+}
+
+// MinuteCount is the number of messages counted in the minute starting at
+// DTG.
+type MinuteCount struct {
+	DTG   DTG
+	Count int
+}
+
+// NewTrafficCounter returns a counter with a sliding window of the given
+// length, rounded up to whole minutes (at least one minute).
+func NewTrafficCounter(window time.Duration) *TrafficCounter {
+	minutes := int64((window + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return &TrafficCounter{window: minutes, buckets: make(map[int64]int)}
+}
+
+// Window returns the length of the sliding window.
+func (c *TrafficCounter) Window() time.Duration {
+	return time.Duration(c.window) * time.Minute
+}
+
+// Count adds a message stamped with dtg. Messages older than the window
+// (relative to the latest counted) are ignored.
+func (c *TrafficCounter) Count(dtg DTG) {
+	minute := unixMinute(dtg)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if minute > c.latest || len(c.buckets) == 0 {
+		c.latest = minute
+		for m := range c.buckets {
+			if m <= minute-c.window {
+				delete(c.buckets, m)
+			}
+		}
+	}
+	if minute <= c.latest-c.window {
+		return
+	}
+	c.buckets[minute]++
+}
+
+// Total returns the number of messages in the window ending with (and
+// including) the minute of at.
+func (c *TrafficCounter) Total(at DTG) int {
+	end := unixMinute(at)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	total := 0
+	for m, n := range c.buckets {
+		if m > end-c.window && m <= end {
+			total += n
+		}
+	}
+	return total
+}
+
+// RatePerHour returns the message rate in the window ending at at, scaled
+// to messages per hour.
+func (c *TrafficCounter) RatePerHour(at DTG) float64 {
+	return float64(c.Total(at)) * 60 / float64(c.window)
+}
+
+// Minutes returns the count of every minute in the window ending at at,
+// oldest first, labelled with the Zulu DTG of each minute. Minutes without
+// traffic are included with a zero count.
+func (c *TrafficCounter) Minutes(at DTG) []MinuteCount {
+	end := unixMinute(at)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make([]MinuteCount, 0, c.window)
+	for m := end - c.window + 1; m <= end; m++ {
+		counts = append(counts, MinuteCount{
+			DTG:   DTG{Time: time.Unix(m*60, 0).UTC()},
+			Count: c.buckets[m],
+		})
+	}
+	return counts
+}
+
+func unixMinute(dtg DTG) int64 {
+	seconds := dtg.Time.Unix()
+	if seconds < 0 && seconds%60 != 0 {
+		return seconds/60 - 1
+	}
+	return seconds / 60
+}
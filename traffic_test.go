@@ -0,0 +1,79 @@
+package dtg
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTrafficCounter(t *testing.T) {
+	c := NewTrafficCounter(10 * time.Minute)
+	if c.Window() != 10*time.Minute {
+		t.Errorf("Expected a 10 minute window, but got %s", c.Window())
+	}
+	start := mustParse(t, `151200ZDEC19`)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Three messages per minute, in another zone and with seconds.
+			at := start.Time.In(time.FixedZone("", 3600)).Add(time.Duration(i)*time.Minute + 30*time.Second)
+			for n := 0; n < 3; n++ {
+				c.Count(DTG{at})
+			}
+		}(i)
+	}
+	wg.Wait()
+	end := DTG{start.Time.Add(9 * time.Minute)}
+	if total := c.Total(end); total != 30 {
+		t.Errorf("Expected 30 messages in the window, but got %d", total)
+	}
+	if rate := c.RatePerHour(end); rate != 180 {
+		t.Errorf("Expected a rate of 180 per hour, but got %f", rate)
+	}
+	minutes := c.Minutes(end)
+	if len(minutes) != 10 || minutes[0].DTG.String() != `151200ZDEC19` || minutes[9].DTG.String() != `151209ZDEC19` {
+		t.Fatalf("Expected 10 minutes from 151200ZDEC19 to 151209ZDEC19, but got %v", minutes)
+	}
+	for _, m := range minutes {
+		if m.Count != 3 {
+			t.Errorf("Expected 3 messages at %s, but got %d", m.DTG, m.Count)
+		}
+	}
+	// Slide the window five minutes.
+	later := DTG{start.Time.Add(14 * time.Minute)}
+	c.Count(later)
+	if total := c.Total(later); total != 16 {
+		t.Errorf("Expected 16 messages after sliding the window, but got %d", total)
+	}
+	// Too old to be counted.
+	c.Count(start)
+	if total := c.Total(later); total != 16 {
+		t.Errorf("Expected messages older than the window to be ignored, but got total %d", total)
+	}
+	if minutes := c.Minutes(later); minutes[0].Count != 3 || minutes[5].Count != 0 || minutes[9].Count != 1 {
+		t.Errorf("Expected counts 3, 0 and 1 at minutes 0, 5 and 9, but got %v", minutes)
+	}
+	if NewTrafficCounter(0).Window() != time.Minute {
+		t.Error("Expected the window to be at least a minute")
+	}
+}
+
+func TestUnixMinute(t *testing.T) {
+	for _, v := range []struct {
+		t        time.Time
+		expected int64
+	}{
+		{time.Unix(0, 0), 0},
+		{time.Unix(59, 0), 0},
+		{time.Unix(60, 0), 1},
+		{time.Unix(-1, 0), -1},
+		{time.Unix(-60, 0), -1},
+		{time.Unix(-61, 0), -2},
+	} {
+		if m := unixMinute(DTG{v.t}); m != v.expected {
+			t.Errorf("Expected minute %d for %d, but got %d", v.expected, v.t.Unix(), m)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package dtg
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidInterval error = errors.New("invalid DTG interval (end before start)")
+
+// Uncertain is a DTG with an uncertainty, denoting the interval from
+// DTG-Uncertainty to DTG+Uncertainty. It is used for fusion of reports with
+// vague times, e.g. "between 151200Z and 151300Z". The DTG is not embedded,
+// so that the methods of time.Time do not marshal an Uncertain without its
+// uncertainty or compare it as an instant, see PossiblyBefore and the other
+// interval comparisons.
+type Uncertain struct {
+	DTG         DTG
+	Uncertainty time.Duration
+}
+
+// Between returns the Uncertain covering start to end: the midpoint (in the
+// zone of start) with half the distance as uncertainty.
+func Between(start, end DTG) (Uncertain, error) {
+	if end.Time.Before(start.Time) {
+		return Uncertain{}, ErrInvalidInterval
+	}
+	half := end.Time.Sub(start.Time) / 2
+	return Uncertain{DTG: DTG{Time: start.Time.Add(half)}, Uncertainty: half}, nil
+}
+
+// ParseBetween parses an interval such as "BETWEEN 151200Z AND 151300Z",
+// "BTN 151200ZDEC19 AND 151300ZDEC19" or "151200Z TO 151300Z". The end may
+// be given as just HHMM (optionally followed by the zone letter), in which
+// case day, zone, month and year are taken from the start and an end earlier
+// than the start is on the following day.
+func ParseBetween(s string) (Uncertain, error) {
+	return (*Parser)(nil).ParseBetween(s)
+}
+
+// ParseBetween is like the package level ParseBetween, but uses the
+// Parser's zone table.
+func (p *Parser) ParseBetween(s string) (Uncertain, error) {
+	words := strings.Fields(strings.ToUpper(s))
+	if len(words) > 0 && (words[0] == "BETWEEN" || words[0] == "BTN" || words[0] == "FROM") {
+		words = words[1:]
+	}
+	if len(words) != 3 || (words[1] != "AND" && words[1] != "TO" && words[1] != "-") {
+		return Uncertain{}, ErrInvalidDTG
+	}
+	start, err := p.Parse(words[0])
+	if err != nil {
+		return Uncertain{}, err
+	}
+	var end DTG
+	if hhmm := strings.TrimSuffix(words[2], start.ZoneLetter()); len(hhmm) == 4 {
+		end, err = p.Parse(start.Time.Format(dayLayout) + hhmm + start.ZoneLetter() + strings.ToUpper(start.Time.Format(monthLayout+yearLayout)))
+		if err != nil {
+			return Uncertain{}, err
+		}
+		if end.Time.Before(start.Time) {
+			end.Time = end.Time.AddDate(0, 0, 1)
+		}
+	} else if end, err = p.Parse(words[2]); err != nil {
+		return Uncertain{}, err
+	}
+	return Between(start, end)
+}
+
+// Earliest returns the start of the interval.
+func (u Uncertain) Earliest() time.Time {
+	return u.DTG.Time.Add(-u.Uncertainty)
+}
+
+// Latest returns the end of the interval.
+func (u Uncertain) Latest() time.Time {
+	return u.DTG.Time.Add(u.Uncertainty)
+}
+
+// PossiblyBefore reports whether u may be before v, i.e. u starts before v
+// ends.
+func (u Uncertain) PossiblyBefore(v Uncertain) bool {
+	return u.Earliest().Before(v.Latest())
+}
+
+// DefinitelyBefore reports whether all of u is before all of v.
+func (u Uncertain) DefinitelyBefore(v Uncertain) bool {
+	return u.Latest().Before(v.Earliest())
+}
+
+// PossiblyAfter reports whether u may be after v, i.e. u ends after v
+// starts.
+func (u Uncertain) PossiblyAfter(v Uncertain) bool {
+	return u.Latest().After(v.Earliest())
+}
+
+// DefinitelyAfter reports whether all of u is after all of v.
+func (u Uncertain) DefinitelyAfter(v Uncertain) bool {
+	return u.Earliest().After(v.Latest())
+}
+
+// Overlaps reports whether the intervals of u and v have any instant in
+// common.
+func (u Uncertain) Overlaps(v Uncertain) bool {
+	return !u.Earliest().After(v.Latest()) && !v.Earliest().After(u.Latest())
+}
+
+// String returns the DTG followed by the uncertainty, e.g.
+// "151230ZDEC19±30m", or just the DTG when there is no uncertainty.
+func (u Uncertain) String() string {
+	if u.Uncertainty == 0 {
+		return u.DTG.String()
+	}
+	return u.DTG.String() + "±" + shortDuration(u.Uncertainty)
+}
+
+// shortDuration formats d as e.g. 1h30m, 45m or 2h, dropping zero units and
+// anything below a minute unless d is shorter than a minute.
+func shortDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + shortDuration(-d)
+	}
+	if d < time.Minute {
+		return d.String()
+	}
+	d = d.Round(time.Minute)
+	s := strings.TrimSuffix(d.String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
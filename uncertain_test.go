@@ -0,0 +1,116 @@
+package dtg
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseBetween(t *testing.T) {
+	testTable := []struct {
+		input       string
+		expected    string
+		uncertainty time.Duration
+	}{
+		{`between 151200ZDEC19 and 151300ZDEC19`, `151230ZDEC19`, 30 * time.Minute},
+		{`BTN 151200ZDEC19 AND 1300`, `151230ZDEC19`, 30 * time.Minute},
+		{`151200BDEC19 TO 1500B`, `151330BDEC19`, 90 * time.Minute},
+		{`152300ZDEC19 - 0100Z`, `160000ZDEC19`, time.Hour},
+		{`FROM 151200ZDEC19 TO 151200ZDEC19`, `151200ZDEC19`, 0},
+		{`between 151200ADEC19 and 151300ZDEC19`, `151300ADEC19`, time.Hour},
+	}
+	for _, v := range testTable {
+		u, err := ParseBetween(v.input)
+		if err != nil {
+			t.Fatalf("Unexpected error for \"%s\": %v", v.input, err)
+		}
+		if u.DTG.String() != v.expected || u.Uncertainty != v.uncertainty {
+			t.Errorf("Expected \"%s\" to be %s±%s, but got %s±%s", v.input, v.expected, v.uncertainty, u.DTG, u.Uncertainty)
+		}
+	}
+	invalid := []string{``, `151200ZDEC19`, `between 151300ZDEC19 and 151200ZDEC19`, `151200ZDEC19 and`, `151200ZDEC19 or 151300ZDEC19`, `151200ZDEC19 TO 9900`}
+	for _, v := range invalid {
+		if _, err := ParseBetween(v); err == nil {
+			t.Errorf("Expected to fail on \"%s\", but succeeded", v)
+		}
+	}
+}
+
+func TestUncertain(t *testing.T) {
+	a, _ := ParseBetween(`151200ZDEC19 TO 1300`)
+	b, _ := ParseBetween(`151245ZDEC19 TO 1400`)
+	c, _ := ParseBetween(`151500ZDEC19 TO 1600`)
+	exact := Uncertain{DTG: mustParse(t, `151230ZDEC19`)}
+	testTable := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{`a PossiblyBefore b`, a.PossiblyBefore(b), true},
+		{`a DefinitelyBefore b`, a.DefinitelyBefore(b), false},
+		{`a DefinitelyBefore c`, a.DefinitelyBefore(c), true},
+		{`b PossiblyAfter a`, b.PossiblyAfter(a), true},
+		{`b DefinitelyAfter a`, b.DefinitelyAfter(a), false},
+		{`c DefinitelyAfter b`, c.DefinitelyAfter(b), true},
+		{`c PossiblyBefore a`, c.PossiblyBefore(a), false},
+		{`a Overlaps b`, a.Overlaps(b), true},
+		{`a Overlaps c`, a.Overlaps(c), false},
+		{`exact Overlaps a`, exact.Overlaps(a), true},
+		{`exact DefinitelyBefore b`, exact.DefinitelyBefore(b), true},
+	}
+	for _, v := range testTable {
+		if v.got != v.expected {
+			t.Errorf("Expected %s to be %t", v.name, v.expected)
+		}
+	}
+	for u, expected := range map[Uncertain]string{
+		a:                                `151230ZDEC19±30m`,
+		exact:                            `151230ZDEC19`,
+		{exact.DTG, 90 * time.Minute}:    `151230ZDEC19±1h30m`,
+		{exact.DTG, 2 * time.Hour}:       `151230ZDEC19±2h`,
+		{exact.DTG, 30 * time.Second}:    `151230ZDEC19±30s`,
+		{exact.DTG, 26 * time.Hour}:      `151230ZDEC19±26h`,
+		{exact.DTG, 26*time.Hour + 60e9}: `151230ZDEC19±26h1m`,
+	} {
+		if u.String() != expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", expected, u.String())
+		}
+	}
+	if _, err := Between(c.DTG, a.DTG); err != ErrInvalidInterval {
+		t.Errorf("Expected %v, but got %v", ErrInvalidInterval, err)
+	}
+}
+
+func TestUncertainMarshal(t *testing.T) {
+	u, err := ParseBetween(`151200BDEC19 TO 1500B`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := interface{}(u).(interface{ Before(time.Time) bool }); ok {
+		t.Error("Expected Uncertain not to compare as an instant")
+	}
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var j Uncertain
+	if err := json.Unmarshal(b, &j); err != nil {
+		t.Fatal(err)
+	}
+	if j.String() != `151330BDEC19±1h30m` || !j.DTG.Equal(u.DTG.Time) {
+		t.Errorf("Expected JSON to keep %s, but got %s (%s)", u, j, b)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(u); err != nil {
+		t.Fatal(err)
+	}
+	var g Uncertain
+	if err := gob.NewDecoder(&buf).Decode(&g); err != nil {
+		t.Fatal(err)
+	}
+	if g.String() != `151330BDEC19±1h30m` || !g.Latest().Equal(u.Latest()) {
+		t.Errorf("Expected gob to keep %s, but got %s", u, g)
+	}
+}
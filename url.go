@@ -0,0 +1,61 @@
+package dtg
+
+import (
+	"net/url"
+	"unicode/utf8"
+)
+
+// URLSegment returns the canonical Date Time Group for use in a URL path
+// segment or query value. With the default zone table the canonical form
+// only consists of digits and upper case letters, which are unreserved
+// characters in RFC 3986, so the returned string is identical to String()
+// and never needs escaping. The asterisk of extended designators (e.g. D*)
+// is a valid sub-delimiter in a path segment, but some encoders (such as
+// url.PathEscape) still escape it as %2A, ParseURLSegment accepts both.
+func (dtg DTG) URLSegment() string {
+	return dtg.String()
+}
+
+// ParseURLSegment parses a Date Time Group from a raw (possibly percent
+// encoded) URL path segment, e.g. as returned by a router before decoding.
+// A malformed escape is reported as a *ParseError of ErrInvalidDTG at the
+// escape.
+func ParseURLSegment(segment string) (DTG, error) {
+	return (*Parser)(nil).ParseURLSegment(segment)
+}
+
+// ParseURLSegment is like the package level ParseURLSegment, but uses the
+// Parser's zone table.
+func (p *Parser) ParseURLSegment(segment string) (DTG, error) {
+	unescaped, err := url.PathUnescape(segment)
+	if err != nil {
+		pos, end := badEscape(segment)
+		return DTG{}, &ParseError{Input: segment, Pos: pos, End: end, Reason: err.Error(), Err: ErrInvalidDTG}
+	}
+	return p.Parse(unescaped)
+}
+
+// badEscape returns the characters of the first escape in segment, a
+// percent sign and the (up to) two characters after it, that is not
+// followed by two hexadecimal digits.
+func badEscape(segment string) (int, int) {
+	for i := 0; i < len(segment); i++ {
+		if segment[i] != '%' {
+			continue
+		}
+		if i+2 >= len(segment) || !isHex(segment[i+1]) || !isHex(segment[i+2]) {
+			end := i + 3
+			if end > len(segment) {
+				end = len(segment)
+			}
+			pos := utf8.RuneCountInString(segment[:i])
+			return pos, pos + utf8.RuneCountInString(segment[i:end])
+		}
+		i += 2
+	}
+	return 0, utf8.RuneCountInString(segment)
+}
+
+func isHex(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
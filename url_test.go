@@ -0,0 +1,53 @@
+package dtg
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestParseURLSegment(t *testing.T) {
+	testTable := []struct {
+		segment  string
+		expected string
+	}{
+		{`152359ZDEC19`, `152359ZDEC19`},
+		{`152359zdec19`, `152359ZDEC19`},
+		{`%31%35%32%33%35%39ZDEC19`, `152359ZDEC19`},
+		{`%20152359ZDEC19%20`, `152359ZDEC19`},
+	}
+	for _, v := range testTable {
+		dtg, err := ParseURLSegment(v.segment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dtg.URLSegment() != v.expected {
+			t.Errorf("Expected \"%s\", but got \"%s\"", v.expected, dtg.URLSegment())
+		}
+	}
+	for _, invalid := range []string{`%ZZ152359ZDEC19`, `152359Z%2FDEC19`, ``} {
+		if _, err := ParseURLSegment(invalid); err == nil {
+			t.Errorf("Expected to fail on \"%s\", but succeeded", invalid)
+		}
+	}
+	for segment, expected := range map[string][2]int{`%ZZ152359ZDEC19`: {0, 3}, `1523%3`: {4, 6}, `%31%2G52359ZDEC19`: {3, 6}} {
+		_, err := ParseURLSegment(segment)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || !errors.Is(err, ErrInvalidDTG) || parseErr.Input != segment || [2]int{parseErr.Pos, parseErr.End} != expected {
+			t.Errorf("Expected a *ParseError at %v of \"%s\", but got %#v", expected, segment, err)
+		}
+	}
+	for _, v := range testVectors {
+		if s := url.PathEscape(v.Canonical); s != v.Canonical {
+			t.Errorf("Expected canonical form \"%s\" to be URL-safe, but it escapes to \"%s\"", v.Canonical, s)
+		}
+	}
+	zones := DefaultZoneTable().Clone()
+	zones.Set("D*", 4*3600+1800)
+	p := &Parser{Zones: zones}
+	for _, segment := range []string{`151200D*DEC19`, url.PathEscape(`151200D*DEC19`)} {
+		if _, err := p.ParseURLSegment(segment); err != nil {
+			t.Errorf("Expected \"%s\" to parse with an extended designator, but got %v", segment, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package dtg
+
+import "time"
+
+// TestVector is a reference input for Parse together with the canonical
+// String() of the result and the instant (in UTC) it denotes.
+type TestVector struct {
+	Input     string
+	Canonical string
+	Instant   time.Time
+}
+
+// testVectors are fully qualified DTGs, so they resolve the same regardless
+// of the current date and the local time zone.
+var testVectors = []TestVector{
+	{`152359ZDEC19`, `152359ZDEC19`, time.Date(2019, time.December, 15, 23, 59, 0, 0, time.UTC)},
+	{`150102AJAN01`, `150102AJAN01`, time.Date(2001, time.January, 15, 0, 2, 0, 0, time.UTC)},
+	{`181920BFEB84`, `181920BFEB84`, time.Date(1984, time.February, 18, 17, 20, 0, 0, time.UTC)},
+	{`181920CFEB26`, `181920CFEB26`, time.Date(2026, time.February, 18, 16, 20, 0, 0, time.UTC)},
+	{`181920DFEB26`, `181920DFEB26`, time.Date(2026, time.February, 18, 15, 20, 0, 0, time.UTC)},
+	{`181920EFEB26`, `181920EFEB26`, time.Date(2026, time.February, 18, 14, 20, 0, 0, time.UTC)},
+	{`181920FFEB26`, `181920FFEB26`, time.Date(2026, time.February, 18, 13, 20, 0, 0, time.UTC)},
+	{`181920GFEB26`, `181920GFEB26`, time.Date(2026, time.February, 18, 12, 20, 0, 0, time.UTC)},
+	{`181920HFEB26`, `181920HFEB26`, time.Date(2026, time.February, 18, 11, 20, 0, 0, time.UTC)},
+	{`181920IFEB26`, `181920IFEB26`, time.Date(2026, time.February, 18, 10, 20, 0, 0, time.UTC)},
+	{`181920KFEB26`, `181920KFEB26`, time.Date(2026, time.February, 18, 9, 20, 0, 0, time.UTC)},
+	{`181920LFEB26`, `181920LFEB26`, time.Date(2026, time.February, 18, 8, 20, 0, 0, time.UTC)},
+	{`181920MFEB26`, `181920MFEB26`, time.Date(2026, time.February, 18, 7, 20, 0, 0, time.UTC)},
+	{`181920NFEB26`, `181920NFEB26`, time.Date(2026, time.February, 18, 20, 20, 0, 0, time.UTC)},
+	{`181920OFEB26`, `181920OFEB26`, time.Date(2026, time.February, 18, 21, 20, 0, 0, time.UTC)},
+	{`181920PFEB26`, `181920PFEB26`, time.Date(2026, time.February, 18, 22, 20, 0, 0, time.UTC)},
+	{`181920QFEB26`, `181920QFEB26`, time.Date(2026, time.February, 18, 23, 20, 0, 0, time.UTC)},
+	{`181920RFEB26`, `181920RFEB26`, time.Date(2026, time.February, 19, 0, 20, 0, 0, time.UTC)},
+	{`181920SFEB26`, `181920SFEB26`, time.Date(2026, time.February, 19, 1, 20, 0, 0, time.UTC)},
+	{`181920TFEB26`, `181920TFEB26`, time.Date(2026, time.February, 19, 2, 20, 0, 0, time.UTC)},
+	{`181920UFEB26`, `181920UFEB26`, time.Date(2026, time.February, 19, 3, 20, 0, 0, time.UTC)},
+	{`181920VFEB26`, `181920VFEB26`, time.Date(2026, time.February, 19, 4, 20, 0, 0, time.UTC)},
+	{`181920WFEB26`, `181920WFEB26`, time.Date(2026, time.February, 19, 5, 20, 0, 0, time.UTC)},
+	{`181920XFEB26`, `181920XFEB26`, time.Date(2026, time.February, 19, 6, 20, 0, 0, time.UTC)},
+	{`181920YFEB26`, `181920YFEB26`, time.Date(2026, time.February, 19, 7, 20, 0, 0, time.UTC)},
+	{`271337ZJAN29`, `271337ZJAN29`, time.Date(2029, time.January, 27, 13, 37, 0, 0, time.UTC)},
+	{`271337BDEC10`, `271337BDEC10`, time.Date(2010, time.December, 27, 11, 37, 0, 0, time.UTC)},
+	{`171819udec28`, `171819UDEC28`, time.Date(2028, time.December, 18, 2, 19, 0, 0, time.UTC)},
+	{`171819AAPR12`, `171819AAPR12`, time.Date(2012, time.April, 17, 17, 19, 0, 0, time.UTC)},
+	{`151337AAPR31`, `151337AAPR31`, time.Date(2031, time.April, 15, 12, 37, 0, 0, time.UTC)},
+	{` 010000nmar99 `, `010000NMAR99`, time.Date(1999, time.March, 1, 1, 0, 0, 0, time.UTC)},
+	{`290000ZFEB24`, `290000ZFEB24`, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	{`312359MDEC69`, `312359MDEC69`, time.Date(1969, time.December, 31, 11, 59, 0, 0, time.UTC)},
+	{`010000YJAN00`, `010000YJAN00`, time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)},
+}
+
+// TestVectors returns the table of reference parse results used by the
+// package's own tests. Bindings and wrappers in other languages can use it to
+// verify that they agree with this implementation. The returned slice is a
+// copy and can be modified freely.
+func TestVectors() []TestVector {
+	vectors := make([]TestVector, len(testVectors))
+	copy(vectors, testVectors)
+	return vectors
+}
@@ -0,0 +1,19 @@
+package dtg
+
+import "testing"
+
+func TestTestVectors(t *testing.T) {
+	vectors := TestVectors()
+	if len(vectors) != len(testVectors) {
+		t.Fatalf("Expected %d vectors, but got %d", len(testVectors), len(vectors))
+	}
+	vectors[0].Canonical = "modified"
+	if testVectors[0].Canonical == "modified" {
+		t.Error("Expected TestVectors to return a copy, but the package table was modified")
+	}
+	for _, v := range testVectors {
+		if err := Validate(v.Canonical); err != nil {
+			t.Errorf("Expected canonical form \"%s\" to validate, but got %v", v.Canonical, err)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package dtg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrReadOnlyZoneTable error = errors.New("zone table is read-only, Clone it to make changes")
+	ErrInvalidZoneOffset error = errors.New("invalid zone offset (must be within -18 to +18 hours)")
+)
+
+// maxZoneOffset is the largest offset accepted in a ZoneTable, the same
+// limit as the numeric -0700 layout can express in practice.
+const maxZoneOffset int = 18 * 60 * 60
+
+// ZoneTable maps time zone designators to UTC offsets. A designator is a
+// letter A-Z, optionally followed by an asterisk for the half-hour zones
+// some legacy systems use (for example D* for UTC+4:30). The letter J is
+// never part of the default table as it denotes the local time zone, but a
+// table may define it to pin "local" to a fixed offset.
+//
+// The default table returned by DefaultZoneTable is immutable, Clone it to
+// add or override designators. A ZoneTable is safe for concurrent reads, but
+// must not be modified while in use by a Parser or Formatter.
+type ZoneTable struct {
+	offsets  map[string]int
+	readOnly bool
+}
+
+//go:generate go run ./internal/zonegen
+
+// zoneData is a row of the default zone table, generated from zones.csv into
+// zones_gen.go.
+type zoneData struct {
+	letter   string
+	offset   int
+	phonetic string
+	examples string
+}
+
+// Zone describes a letter of the default zone table, see Letters.
+type Zone struct {
+	Letter string
+	// Offset is in seconds east of UTC.
+	Offset int
+	// Phonetic is the NATO phonetic name of the letter, e.g. Zulu.
+	Phonetic string
+	// Examples are locations in the zone, e.g. "Athens, Greece".
+	Examples string
+}
+
+// Letters returns the letters of the default zone table with their offset,
+// phonetic name and example locations from west to east (Y to M), for
+// building pickers and documentation. J is not included as it denotes the
+// local time zone.
+func Letters() []Zone {
+	zones := make([]Zone, len(defaultZoneData))
+	for i, z := range defaultZoneData {
+		zones[i] = Zone{Letter: z.letter, Offset: z.offset, Phonetic: z.phonetic, Examples: z.examples}
+	}
+	return zones
+}
+
+var defaultZones *ZoneTable = newDefaultZoneTable()
+
+func newDefaultZoneTable() *ZoneTable {
+	zt := &ZoneTable{offsets: make(map[string]int, len(defaultZoneData))}
+	for _, z := range defaultZoneData {
+		zt.offsets[z.letter] = z.offset
+	}
+	zt.readOnly = true
+	return zt
+}
+
+// Clone returns a modifiable copy of the zone table.
+func (zt *ZoneTable) Clone() *ZoneTable {
+	clone := &ZoneTable{offsets: make(map[string]int, len(zt.offsets))}
+	for k, v := range zt.offsets {
+		clone.offsets[k] = v
+	}
+	return clone
+}
+
+// Set adds or overrides the offset (in seconds east of UTC) of a designator.
+func (zt *ZoneTable) Set(designator string, offset int) error {
+	if zt.readOnly {
+		return ErrReadOnlyZoneTable
+	}
+	designator = strings.ToUpper(strings.TrimSpace(designator))
+	if !validDesignator(designator) {
+		return ErrInvalidTimeZoneLetter
+	}
+	if offset < -maxZoneOffset || offset > maxZoneOffset {
+		return ErrInvalidZoneOffset
+	}
+	if zt.offsets == nil {
+		zt.offsets = make(map[string]int)
+	}
+	zt.offsets[designator] = offset
+	return nil
+}
+
+// Remove deletes a designator from the table. Removing a designator that is
+// not in the table is not an error.
+func (zt *ZoneTable) Remove(designator string) error {
+	if zt.readOnly {
+		return ErrReadOnlyZoneTable
+	}
+	delete(zt.offsets, strings.ToUpper(strings.TrimSpace(designator)))
+	return nil
+}
+
+// Offset returns the offset in seconds east of UTC of a designator and
+// whether the designator is in the table.
+func (zt *ZoneTable) Offset(designator string) (int, bool) {
+	offset, ok := zt.offsets[strings.ToUpper(strings.TrimSpace(designator))]
+	return offset, ok
+}
+
+// Designator returns the designator for an offset in seconds east of UTC.
+// Should several designators share the offset, the first in alphabetical
+// order is returned.
+func (zt *ZoneTable) Designator(offset int) (string, bool) {
+	found := ""
+	for designator, o := range zt.offsets {
+		if o == offset && (found == "" || designator < found) {
+			found = designator
+		}
+	}
+	return found, found != ""
+}
+
+// Designators returns all designators in the table in alphabetical order.
+func (zt *ZoneTable) Designators() []string {
+	designators := make([]string, 0, len(zt.offsets))
+	for designator := range zt.offsets {
+		designators = append(designators, designator)
+	}
+	sort.Strings(designators)
+	return designators
+}
+
+// Location returns a fixed time.Location for a designator in the table. The
+// name of the location is the numeric time zone (e.g +0100), see
+// GetNumericTimeZone.
+func (zt *ZoneTable) Location(designator string) (*time.Location, error) {
+	offset, ok := zt.Offset(designator)
+	if !ok {
+		return nil, ErrInvalidTimeZoneLetter
+	}
+	return time.FixedZone(numericTimeZone(offset), offset), nil
+}
+
+// location resolves a designator the way Parse does: an empty designator
+// means J, designators in the table are fixed offsets and J (unless defined
+// in the table) is the local time zone at the time given by the optional
+// dayHourMinuteMonthYear, see GetNumericTimeZone.
+func (zt *ZoneTable) location(designator string, dayHourMinuteMonthYear ...string) (*time.Location, error) {
+	return zt.locationAt(time.Now(), designator, dayHourMinuteMonthYear...)
+}
+
+// locationAt is location with now as the current time and its location as
+// the local time zone.
+func (zt *ZoneTable) locationAt(now time.Time, designator string, dayHourMinuteMonthYear ...string) (*time.Location, error) {
+	if designator == "" {
+		designator = "J"
+	}
+	if _, ok := zt.Offset(designator); ok {
+		return zt.Location(designator)
+	}
+	if designator == "J" {
+		return localTimeZoneAt(now, dayHourMinuteMonthYear...)
+	}
+	return nil, ErrInvalidTimeZoneLetter
+}
+
+// validDesignator reports whether s is a letter A-Z optionally followed by
+// an asterisk.
+func validDesignator(s string) bool {
+	letter, size := utf8.DecodeRuneInString(s)
+	if letter < 'A' || letter > 'Z' {
+		return false
+	}
+	rest := s[size:]
+	return rest == "" || rest == "*"
+}
+
+// numericTimeZone formats an offset in seconds east of UTC as -0700.
+func numericTimeZone(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
@@ -0,0 +1,64 @@
+// Code generated by zonegen from zones.csv; DO NOT EDIT.
+
+package dtg
+
+// DefaultZoneTable returns the read-only ACP 121 zone letter table used by
+// Parse, GetNumericTimeZone and DTG.String(). J is not in the table as it
+// denotes the local time zone.
+//
+// UTC-12: Y (e.g., Fiji)
+// UTC-11: X (American Samoa)
+// UTC-10: W (Honolulu, HI)
+// UTC-9: V (Juneau, AK)
+// UTC-8: U (PST, Los Angeles, CA)
+// UTC-7: T (MST, Denver, CO)
+// UTC-6: S (CST, Dallas, TX)
+// UTC-5: R (EST, New York, NY)
+// UTC-4: Q (Halifax, Nova Scotia)
+// UTC-3: P (Buenos Aires, Argentina)
+// UTC-2: O (Godthab, Greenland)
+// UTC-1: N (Azores)
+// UTC+-0: Z (Zulu time)
+// UTC+1: A (France)
+// UTC+2: B (Athens, Greece)
+// UTC+3: C (Arab Standard Time, Iraq, Bahrain, Kuwait, Saudi Arabia, Yemen, Qatar)
+// UTC+4: D (Used for Moscow, Russia, and Afghanistan, however, Afghanistan is technically +4:30 from UTC)
+// UTC+5: E (Pakistan, Kazakhstan, Tajikistan, Uzbekistan, and Turkmenistan)
+// UTC+6: F (Bangladesh)
+// UTC+7: G (Thailand)
+// UTC+8: H (Beijing, China)
+// UTC+9: I (Tokyo, Japan)
+// UTC+10: K (Brisbane, Australia)
+// UTC+11: L (Sydney, Australia)
+// UTC+12: M (Wellington, New Zealand)
+func DefaultZoneTable() *ZoneTable {
+	return defaultZones
+}
+
+var defaultZoneData = []zoneData{
+	{"Y", -43200, "Yankee", "e.g., Fiji"},
+	{"X", -39600, "X-ray", "American Samoa"},
+	{"W", -36000, "Whiskey", "Honolulu, HI"},
+	{"V", -32400, "Victor", "Juneau, AK"},
+	{"U", -28800, "Uniform", "PST, Los Angeles, CA"},
+	{"T", -25200, "Tango", "MST, Denver, CO"},
+	{"S", -21600, "Sierra", "CST, Dallas, TX"},
+	{"R", -18000, "Romeo", "EST, New York, NY"},
+	{"Q", -14400, "Quebec", "Halifax, Nova Scotia"},
+	{"P", -10800, "Papa", "Buenos Aires, Argentina"},
+	{"O", -7200, "Oscar", "Godthab, Greenland"},
+	{"N", -3600, "November", "Azores"},
+	{"Z", 0, "Zulu", "Zulu time"},
+	{"A", 3600, "Alpha", "France"},
+	{"B", 7200, "Bravo", "Athens, Greece"},
+	{"C", 10800, "Charlie", "Arab Standard Time, Iraq, Bahrain, Kuwait, Saudi Arabia, Yemen, Qatar"},
+	{"D", 14400, "Delta", "Used for Moscow, Russia, and Afghanistan, however, Afghanistan is technically +4:30 from UTC"},
+	{"E", 18000, "Echo", "Pakistan, Kazakhstan, Tajikistan, Uzbekistan, and Turkmenistan"},
+	{"F", 21600, "Foxtrot", "Bangladesh"},
+	{"G", 25200, "Golf", "Thailand"},
+	{"H", 28800, "Hotel", "Beijing, China"},
+	{"I", 32400, "India", "Tokyo, Japan"},
+	{"K", 36000, "Kilo", "Brisbane, Australia"},
+	{"L", 39600, "Lima", "Sydney, Australia"},
+	{"M", 43200, "Mike", "Wellington, New Zealand"},
+}
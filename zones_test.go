@@ -0,0 +1,107 @@
+package dtg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultZoneTable(t *testing.T) {
+	zones := DefaultZoneTable()
+	if err := zones.Set("A", 0); err != ErrReadOnlyZoneTable {
+		t.Errorf("Expected %v when modifying the default table, but got %v", ErrReadOnlyZoneTable, err)
+	}
+	if err := zones.Remove("A"); err != ErrReadOnlyZoneTable {
+		t.Errorf("Expected %v when removing from the default table, but got %v", ErrReadOnlyZoneTable, err)
+	}
+	if n := len(zones.Designators()); n != 25 {
+		t.Errorf("Expected 25 designators in the default table, but got %d", n)
+	}
+	if _, ok := zones.Offset("J"); ok {
+		t.Error("Expected J to be absent from the default table")
+	}
+	testTable := []struct {
+		designator string
+		offset     int
+	}{
+		{`Z`, 0},
+		{`A`, 3600},
+		{`I`, 9 * 3600},
+		{`K`, 10 * 3600},
+		{`M`, 12 * 3600},
+		{`N`, -3600},
+		{`Y`, -12 * 3600},
+		{`r`, -5 * 3600},
+	}
+	for _, v := range testTable {
+		offset, ok := zones.Offset(v.designator)
+		if !ok || offset != v.offset {
+			t.Errorf("Expected offset %d for \"%s\", but got %d (found=%t)", v.offset, v.designator, offset, ok)
+		}
+	}
+}
+
+func TestLetters(t *testing.T) {
+	letters := Letters()
+	if len(letters) != 25 {
+		t.Fatalf("Expected 25 letters, but got %d", len(letters))
+	}
+	for i, z := range letters {
+		if offset, ok := DefaultZoneTable().Offset(z.Letter); !ok || offset != z.Offset || z.Phonetic == "" || z.Examples == "" {
+			t.Errorf("Unexpected letter %+v", z)
+		}
+		if i > 0 && letters[i-1].Offset >= z.Offset {
+			t.Errorf("Expected %s west of %s", letters[i-1].Letter, z.Letter)
+		}
+	}
+	if z := letters[12]; z.Letter != "Z" || z.Phonetic != "Zulu" || z.Offset != 0 {
+		t.Errorf("Expected Zulu in the middle, but got %+v", z)
+	}
+	letters[0].Letter = "Q"
+	if Letters()[0].Letter != "Y" {
+		t.Error("Expected Letters to return a copy")
+	}
+}
+
+func TestZoneTableClone(t *testing.T) {
+	zones := DefaultZoneTable().Clone()
+	if err := zones.Set("D*", 4*3600+1800); err != nil {
+		t.Fatal(err)
+	}
+	if err := zones.Set("r", -4*3600); err != nil {
+		t.Fatal(err)
+	}
+	if err := zones.Remove("Y"); err != nil {
+		t.Fatal(err)
+	}
+	if offset, _ := DefaultZoneTable().Offset("R"); offset != -5*3600 {
+		t.Errorf("Expected the default table to be unaffected by changes to a clone, but R is %d", offset)
+	}
+	if _, ok := zones.Offset("Y"); ok {
+		t.Error("Expected Y to be removed from the clone")
+	}
+	if designator, ok := zones.Designator(-4 * 3600); !ok || designator != "Q" {
+		t.Errorf("Expected the alphabetically first designator Q for -0400, but got \"%s\"", designator)
+	}
+	loc, err := zones.Location("d*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.String() != "+0430" {
+		t.Errorf("Expected location name +0430, but got \"%s\"", loc.String())
+	}
+	if _, offset := time.Now().In(loc).Zone(); offset != 4*3600+1800 {
+		t.Errorf("Expected offset %d, but got %d", 4*3600+1800, offset)
+	}
+	for _, invalid := range []string{``, `*`, `AB`, `Ö`, `1`, `D**`} {
+		if err := zones.Set(invalid, 0); err != ErrInvalidTimeZoneLetter {
+			t.Errorf("Expected %v for designator \"%s\", but got %v", ErrInvalidTimeZoneLetter, invalid, err)
+		}
+	}
+	if err := zones.Set("J", 19*3600); err != ErrInvalidZoneOffset {
+		t.Errorf("Expected %v, but got %v", ErrInvalidZoneOffset, err)
+	}
+	var empty ZoneTable
+	if err := empty.Set("J", 3600); err != nil {
+		t.Errorf("Expected the zero value ZoneTable to be usable, but got %v", err)
+	}
+}